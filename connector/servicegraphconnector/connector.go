@@ -0,0 +1,592 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package servicegraphconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	semconv "go.opentelemetry.io/collector/semconv/v1.13.0"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/store"
+)
+
+const (
+	metricKeySeparator = string(byte(0))
+	clientKind         = "client"
+	serverKind         = "server"
+)
+
+var (
+	defaultLatencyHistogramBuckets = []float64{
+		0.002, 0.004, 0.006, 0.008, 0.01, 0.05, 0.1, 0.2, 0.4, 0.8, 1, 1.4, 2, 5, 10, 15,
+	}
+	defaultPeerAttributes = []string{
+		semconv.AttributePeerService, semconv.AttributeDBName, semconv.AttributeDBSystem, semconv.AttributeMessagingSystem,
+		semconv.AttributeNetSockPeerAddr, semconv.AttributeNetPeerName, semconv.AttributeRPCService, semconv.AttributeNetSockPeerName,
+		semconv.AttributeHTTPURL, semconv.AttributeHTTPTarget,
+	}
+	// databaseNameAttributes are the attributes used to name the server of a database request,
+	// the higher the front, the higher the priority.
+	databaseNameAttributes = []string{
+		semconv.AttributePeerService, semconv.AttributeDBName, semconv.AttributeDBSystem,
+	}
+)
+
+type metricSeries struct {
+	dimensions  pcommon.Map
+	lastUpdated int64 // Used to remove stale series
+}
+
+// latencyHistogram holds the buckets of the latency histograms of every series.
+type latencyHistogram struct {
+	sum          map[string]float64
+	count        map[string]uint64
+	bucketCounts map[string][]uint64
+}
+
+func newLatencyHistogram() latencyHistogram {
+	return latencyHistogram{
+		sum:          make(map[string]float64),
+		count:        make(map[string]uint64),
+		bucketCounts: make(map[string][]uint64),
+	}
+}
+
+func (h latencyHistogram) update(key string, bounds []float64, duration float64) {
+	index := sort.SearchFloat64s(bounds, duration) // Search bucket index
+	if _, ok := h.bucketCounts[key]; !ok {
+		h.bucketCounts[key] = make([]uint64, len(bounds)+1)
+	}
+	h.sum[key] += duration
+	h.count[key]++
+	h.bucketCounts[key][index]++
+}
+
+func (h latencyHistogram) delete(key string) {
+	delete(h.sum, key)
+	delete(h.count, key)
+	delete(h.bucketCounts, key)
+}
+
+var _ connector.Traces = (*serviceGraphConnector)(nil)
+
+type serviceGraphConnector struct {
+	config          *Config
+	logger          *zap.Logger
+	metricsConsumer consumer.Metrics
+
+	store *store.Store
+
+	startTime time.Time
+
+	seriesMutex              sync.Mutex
+	reqTotal                 map[string]int64
+	reqFailedTotal           map[string]int64
+	reqDurationBounds        []float64
+	reqServerDurationSeconds latencyHistogram
+	reqClientDurationSeconds latencyHistogram
+
+	metricMutex sync.RWMutex
+	keyToMetric map[string]metricSeries
+
+	shutdownCh chan interface{}
+}
+
+func newConnector(logger *zap.Logger, config component.Config) *serviceGraphConnector {
+	cConfig := config.(*Config)
+
+	bounds := defaultLatencyHistogramBuckets
+	if cConfig.LatencyHistogramBuckets != nil {
+		bounds = mapDurationsToSeconds(cConfig.LatencyHistogramBuckets)
+	}
+
+	if cConfig.CacheLoop <= 0 {
+		cConfig.CacheLoop = time.Minute
+	}
+
+	if cConfig.StoreExpirationLoop <= 0 {
+		cConfig.StoreExpirationLoop = 2 * time.Second
+	}
+
+	if cConfig.VirtualNodePeerAttributes == nil {
+		cConfig.VirtualNodePeerAttributes = defaultPeerAttributes
+	}
+
+	return &serviceGraphConnector{
+		config:                   cConfig,
+		logger:                   logger,
+		startTime:                time.Now(),
+		reqTotal:                 make(map[string]int64),
+		reqFailedTotal:           make(map[string]int64),
+		reqDurationBounds:        bounds,
+		reqServerDurationSeconds: newLatencyHistogram(),
+		reqClientDurationSeconds: newLatencyHistogram(),
+		keyToMetric:              make(map[string]metricSeries),
+		shutdownCh:               make(chan interface{}),
+	}
+}
+
+func (p *serviceGraphConnector) Start(_ context.Context, _ component.Host) error {
+	p.store = store.NewStore(p.config.Store.TTL, p.config.Store.MaxItems, p.onComplete, p.onExpire)
+
+	go p.cacheLoop(p.config.CacheLoop)
+
+	go p.storeExpirationLoop(p.config.StoreExpirationLoop)
+
+	p.logger.Info("Started servicegraphconnector")
+	return nil
+}
+
+func (p *serviceGraphConnector) Shutdown(_ context.Context) error {
+	p.logger.Info("Shutting down servicegraphconnector")
+	close(p.shutdownCh)
+	return nil
+}
+
+func (p *serviceGraphConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (p *serviceGraphConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if err := p.aggregateMetrics(ctx, td); err != nil {
+		return fmt.Errorf("failed to aggregate metrics: %w", err)
+	}
+
+	md, err := p.buildMetrics()
+	if err != nil {
+		return fmt.Errorf("failed to build metrics: %w", err)
+	}
+
+	// Skip empty metrics.
+	if md.MetricCount() == 0 {
+		return nil
+	}
+
+	return p.metricsConsumer.ConsumeMetrics(ctx, md)
+}
+
+func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.Traces) (err error) {
+	var isNew bool
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rSpans := rss.At(i)
+
+		rAttributes := rSpans.Resource().Attributes()
+
+		serviceName, ok := findServiceName(rAttributes)
+		if !ok {
+			// If service.name doesn't exist, skip processing this trace
+			continue
+		}
+
+		scopeSpans := rSpans.ScopeSpans()
+		for j := 0; j < scopeSpans.Len(); j++ {
+			spans := scopeSpans.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+
+				connectionType := store.Unknown
+
+				switch span.Kind() {
+				case ptrace.SpanKindProducer:
+					// override connection type and continue processing as span kind client
+					connectionType = store.MessagingSystem
+					fallthrough
+				case ptrace.SpanKindClient:
+					traceID := span.TraceID()
+					key := store.NewKey(traceID, span.SpanID())
+					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
+						e.TraceID = traceID
+						e.ConnectionType = connectionType
+						e.ClientService = serviceName
+						e.ClientLatencySec = spanDuration(span)
+						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
+						p.upsertDimensions(clientKind, e.Dimensions, rAttributes, span.Attributes())
+
+						if virtualNodeFeatureGate.IsEnabled() {
+							p.upsertPeerAttributes(p.config.VirtualNodePeerAttributes, e.Peer, span.Attributes())
+						}
+
+						// A database request will only have one span, we don't wait for the server
+						// span but just copy details from the client span
+						if dbName, ok := findDatabaseName(rAttributes, span.Attributes()); ok {
+							e.ConnectionType = store.Database
+							e.ServerService = dbName
+							e.ServerLatencySec = spanDuration(span)
+						}
+					})
+				case ptrace.SpanKindConsumer:
+					// override connection type and continue processing as span kind server
+					connectionType = store.MessagingSystem
+					fallthrough
+				case ptrace.SpanKindServer:
+					traceID := span.TraceID()
+					key := store.NewKey(traceID, span.ParentSpanID())
+					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
+						e.TraceID = traceID
+						e.ConnectionType = connectionType
+						e.ServerService = serviceName
+						e.ServerLatencySec = spanDuration(span)
+						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
+						p.upsertDimensions(serverKind, e.Dimensions, rAttributes, span.Attributes())
+					})
+				default:
+					// this span is not part of an edge
+					continue
+				}
+
+				if errors.Is(err, store.ErrTooManyItems) {
+					stats.Record(ctx, statDroppedSpans.M(1))
+					continue
+				}
+
+				// UpsertEdge will only return ErrTooManyItems
+				if err != nil {
+					return err
+				}
+
+				if isNew {
+					stats.Record(ctx, statTotalEdges.M(1))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (p *serviceGraphConnector) upsertDimensions(kind string, m map[string]string, resourceAttr pcommon.Map, spanAttr pcommon.Map) {
+	for _, dim := range p.config.Dimensions {
+		if v, ok := findAttributeValue(dim, resourceAttr, spanAttr); ok {
+			m[kind+"_"+dim] = v
+		}
+	}
+}
+
+func (p *serviceGraphConnector) upsertPeerAttributes(m []string, peers map[string]string, spanAttr pcommon.Map) {
+	for _, s := range m {
+		if v, ok := findAttributeValue(s, spanAttr); ok {
+			peers[s] = v
+			break
+		}
+	}
+}
+
+func (p *serviceGraphConnector) onComplete(e *store.Edge) {
+	p.logger.Debug(
+		"edge completed",
+		zap.String("client_service", e.ClientService),
+		zap.String("server_service", e.ServerService),
+		zap.String("connection_type", string(e.ConnectionType)),
+		zap.Stringer("trace_id", e.TraceID),
+	)
+	p.aggregateMetricsForEdge(e)
+}
+
+func (p *serviceGraphConnector) onExpire(e *store.Edge) {
+	p.logger.Debug(
+		"edge expired",
+		zap.String("client_service", e.ClientService),
+		zap.String("server_service", e.ServerService),
+		zap.String("connection_type", string(e.ConnectionType)),
+		zap.Stringer("trace_id", e.TraceID),
+	)
+
+	stats.Record(context.Background(), statExpiredEdges.M(1))
+
+	if virtualNodeFeatureGate.IsEnabled() {
+		e.ConnectionType = store.VirtualNode
+		// The latency of the missing side is unknown, the virtual node reuses the latency seen by the instrumented side.
+		if len(e.ClientService) == 0 && e.Key.SpanIDIsEmpty() {
+			e.ClientService = "user"
+			e.ClientLatencySec = e.ServerLatencySec
+			p.onComplete(e)
+		}
+
+		if len(e.ServerService) == 0 {
+			e.ServerService = p.getPeerHost(p.config.VirtualNodePeerAttributes, e.Peer)
+			e.ServerLatencySec = e.ClientLatencySec
+			p.onComplete(e)
+		}
+	}
+}
+
+func (p *serviceGraphConnector) aggregateMetricsForEdge(e *store.Edge) {
+	metricKey := p.buildMetricKey(e.ClientService, e.ServerService, string(e.ConnectionType), e.Dimensions)
+	dimensions := buildDimensions(e)
+
+	p.seriesMutex.Lock()
+	defer p.seriesMutex.Unlock()
+	p.updateSeries(metricKey, dimensions)
+	p.updateCountMetrics(metricKey)
+	if e.Failed {
+		p.updateErrorMetrics(metricKey)
+	}
+	p.updateDurationMetrics(metricKey, e.ServerLatencySec, e.ClientLatencySec)
+}
+
+func (p *serviceGraphConnector) updateSeries(key string, dimensions pcommon.Map) {
+	p.metricMutex.Lock()
+	defer p.metricMutex.Unlock()
+	// Overwrite the series if it already exists
+	p.keyToMetric[key] = metricSeries{
+		dimensions:  dimensions,
+		lastUpdated: time.Now().UnixMilli(),
+	}
+}
+
+func (p *serviceGraphConnector) dimensionsForSeries(key string) (pcommon.Map, bool) {
+	p.metricMutex.RLock()
+	defer p.metricMutex.RUnlock()
+	if series, ok := p.keyToMetric[key]; ok {
+		return series.dimensions, true
+	}
+
+	return pcommon.Map{}, false
+}
+
+func (p *serviceGraphConnector) updateCountMetrics(key string) { p.reqTotal[key]++ }
+
+func (p *serviceGraphConnector) updateErrorMetrics(key string) { p.reqFailedTotal[key]++ }
+
+func (p *serviceGraphConnector) updateDurationMetrics(key string, serverDuration, clientDuration float64) {
+	p.reqServerDurationSeconds.update(key, p.reqDurationBounds, serverDuration)
+	p.reqClientDurationSeconds.update(key, p.reqDurationBounds, clientDuration)
+}
+
+func buildDimensions(e *store.Edge) pcommon.Map {
+	dims := pcommon.NewMap()
+	dims.PutStr("client", e.ClientService)
+	dims.PutStr("server", e.ServerService)
+	dims.PutStr("connection_type", string(e.ConnectionType))
+	dims.PutBool("failed", e.Failed)
+	for k, v := range e.Dimensions {
+		dims.PutStr(k, v)
+	}
+	return dims
+}
+
+func (p *serviceGraphConnector) buildMetrics() (pmetric.Metrics, error) {
+	m := pmetric.NewMetrics()
+	ilm := m.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	ilm.Scope().SetName("traces_service_graph")
+
+	// Obtain write lock to reset data
+	p.seriesMutex.Lock()
+	defer p.seriesMutex.Unlock()
+
+	if err := p.collectCountMetrics(ilm); err != nil {
+		return m, err
+	}
+
+	if err := p.collectLatencyMetrics(ilm, "traces_service_graph_request_server_seconds", p.reqServerDurationSeconds); err != nil {
+		return m, err
+	}
+
+	if err := p.collectLatencyMetrics(ilm, "traces_service_graph_request_client_seconds", p.reqClientDurationSeconds); err != nil {
+		return m, err
+	}
+
+	return m, nil
+}
+
+func (p *serviceGraphConnector) collectCountMetrics(ilm pmetric.ScopeMetrics) error {
+	for key, c := range p.reqTotal {
+		mCount := ilm.Metrics().AppendEmpty()
+		mCount.SetName("traces_service_graph_request_total")
+		mCount.SetEmptySum().SetIsMonotonic(true)
+		// TODO: Support other aggregation temporalities
+		mCount.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		dpCalls := mCount.Sum().DataPoints().AppendEmpty()
+		dpCalls.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+		dpCalls.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		dpCalls.SetIntValue(c)
+
+		dimensions, ok := p.dimensionsForSeries(key)
+		if !ok {
+			return fmt.Errorf("failed to find dimensions for key %s", key)
+		}
+
+		dimensions.CopyTo(dpCalls.Attributes())
+	}
+
+	for key, c := range p.reqFailedTotal {
+		mCount := ilm.Metrics().AppendEmpty()
+		mCount.SetName("traces_service_graph_request_failed_total")
+		mCount.SetEmptySum().SetIsMonotonic(true)
+		// TODO: Support other aggregation temporalities
+		mCount.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		dpCalls := mCount.Sum().DataPoints().AppendEmpty()
+		dpCalls.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+		dpCalls.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		dpCalls.SetIntValue(c)
+
+		dimensions, ok := p.dimensionsForSeries(key)
+		if !ok {
+			return fmt.Errorf("failed to find dimensions for key %s", key)
+		}
+
+		dimensions.CopyTo(dpCalls.Attributes())
+	}
+
+	return nil
+}
+
+func (p *serviceGraphConnector) collectLatencyMetrics(ilm pmetric.ScopeMetrics, name string, h latencyHistogram) error {
+	for key := range h.count {
+		mDuration := ilm.Metrics().AppendEmpty()
+		mDuration.SetName(name)
+		mDuration.SetUnit("s")
+		// TODO: Support other aggregation temporalities
+		mDuration.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		timestamp := pcommon.NewTimestampFromTime(time.Now())
+
+		dpDuration := mDuration.Histogram().DataPoints().AppendEmpty()
+		dpDuration.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+		dpDuration.SetTimestamp(timestamp)
+		dpDuration.ExplicitBounds().FromRaw(p.reqDurationBounds)
+		dpDuration.BucketCounts().FromRaw(h.bucketCounts[key])
+		dpDuration.SetCount(h.count[key])
+		dpDuration.SetSum(h.sum[key])
+
+		// TODO: Support exemplars
+
+		dimensions, ok := p.dimensionsForSeries(key)
+		if !ok {
+			return fmt.Errorf("failed to find dimensions for key %s", key)
+		}
+
+		dimensions.CopyTo(dpDuration.Attributes())
+	}
+	return nil
+}
+
+func (p *serviceGraphConnector) buildMetricKey(clientName, serverName, connectionType string, edgeDimensions map[string]string) string {
+	var metricKey strings.Builder
+	metricKey.WriteString(clientName + metricKeySeparator + serverName + metricKeySeparator + connectionType)
+
+	// The dimensions of an edge are prefixed with the kind of the span they were found in.
+	for _, kind := range []string{clientKind, serverKind} {
+		for _, dimName := range p.config.Dimensions {
+			dim, ok := edgeDimensions[kind+"_"+dimName]
+			if !ok {
+				continue
+			}
+			metricKey.WriteString(metricKeySeparator + kind + "_" + dimName + "=" + dim)
+		}
+	}
+
+	return metricKey.String()
+}
+
+// storeExpirationLoop periodically expires old entries from the store.
+func (p *serviceGraphConnector) storeExpirationLoop(d time.Duration) {
+	t := time.NewTicker(d)
+	for {
+		select {
+		case <-t.C:
+			p.store.Expire()
+		case <-p.shutdownCh:
+			return
+		}
+	}
+}
+
+func (p *serviceGraphConnector) getPeerHost(m []string, peers map[string]string) string {
+	peerStr := "unknown"
+	for _, s := range m {
+		if peer, ok := peers[s]; ok {
+			peerStr = peer
+			break
+		}
+	}
+	return peerStr
+}
+
+// cacheLoop periodically cleans the cache
+func (p *serviceGraphConnector) cacheLoop(d time.Duration) {
+	t := time.NewTicker(d)
+	for {
+		select {
+		case <-t.C:
+			p.cleanCache()
+		case <-p.shutdownCh:
+			return
+		}
+	}
+
+}
+
+// cleanCache removes series that have not been updated in 15 minutes
+func (p *serviceGraphConnector) cleanCache() {
+	var staleSeries []string
+	p.metricMutex.RLock()
+	for key, series := range p.keyToMetric {
+		if series.lastUpdated+15*time.Minute.Milliseconds() < time.Now().UnixMilli() {
+			staleSeries = append(staleSeries, key)
+		}
+	}
+	p.metricMutex.RUnlock()
+
+	p.metricMutex.Lock()
+	for _, key := range staleSeries {
+		delete(p.keyToMetric, key)
+	}
+	p.metricMutex.Unlock()
+
+	p.seriesMutex.Lock()
+	for _, key := range staleSeries {
+		delete(p.reqTotal, key)
+		delete(p.reqFailedTotal, key)
+		p.reqServerDurationSeconds.delete(key)
+		p.reqClientDurationSeconds.delete(key)
+	}
+	p.seriesMutex.Unlock()
+}
+
+// findDatabaseName returns the name of the database a client span makes a request to,
+// the span is considered a database request when it has either a db.name or a db.system attribute.
+func findDatabaseName(attributes ...pcommon.Map) (string, bool) {
+	_, hasName := findAttributeValue(semconv.AttributeDBName, attributes...)
+	_, hasSystem := findAttributeValue(semconv.AttributeDBSystem, attributes...)
+	if !hasName && !hasSystem {
+		return "", false
+	}
+	for _, attr := range databaseNameAttributes {
+		if v, ok := findAttributeValue(attr, attributes...); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// spanDuration returns the duration of the span in seconds.
+func spanDuration(span ptrace.Span) float64 {
+	return float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Second.Nanoseconds())
+}
+
+func mapDurationsToSeconds(vs []time.Duration) []float64 {
+	vsm := make([]float64, len(vs))
+	for i, v := range vs {
+		vsm[i] = v.Seconds()
+	}
+	return vsm
+}
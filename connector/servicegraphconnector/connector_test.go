@@ -0,0 +1,455 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package servicegraphconnector
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	semconv "go.opentelemetry.io/collector/semconv/v1.13.0"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestConnectorStart(t *testing.T) {
+	// Create servicegraph connector
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+
+	procCreationParams := connectortest.NewNopCreateSettings()
+	traceConnector, err := factory.CreateTracesToMetrics(context.Background(), procCreationParams, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	// Test
+	smc := traceConnector.(*serviceGraphConnector)
+	err = smc.Start(context.Background(), componenttest.NewNopHost())
+
+	// Verify
+	assert.NoError(t, err)
+	assert.NoError(t, smc.Shutdown(context.Background()))
+}
+
+func TestConnectorShutdown(t *testing.T) {
+	// Prepare
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+
+	// Test
+	next := new(consumertest.MetricsSink)
+	p := newConnector(zaptest.NewLogger(t), cfg)
+	p.metricsConsumer = next
+	err := p.Shutdown(context.Background())
+
+	// Verify
+	assert.NoError(t, err)
+}
+
+func TestConnectorConsume(t *testing.T) {
+	// set virtual node feature
+	_ = featuregate.GlobalRegistry().Set(virtualNodeFeatureGate.ID(), true)
+	defer func() {
+		_ = featuregate.GlobalRegistry().Set(virtualNodeFeatureGate.ID(), false)
+	}()
+
+	for _, tc := range []struct {
+		name          string
+		sampleTraces  ptrace.Traces
+		verifyMetrics func(t *testing.T, md pmetric.Metrics)
+	}{
+		{
+			name:          "traces with client and server span",
+			sampleTraces:  buildSampleTrace(t, "val"),
+			verifyMetrics: verifyHappyCaseMetrics,
+		},
+		{
+			name: "incomplete traces with virtual server span",
+			sampleTraces: incompleteClientTraces(map[string]string{
+				semconv.AttributeNetSockPeerAddr: "127.10.10.1",
+			}),
+			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
+				attributes := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes()
+				verifyAttr(t, attributes, "server", "127.10.10.1")
+				verifyAttr(t, attributes, "connection_type", "virtual_node")
+			},
+		},
+		{
+			name: "incomplete traces with virtual server span named by peer.service",
+			sampleTraces: incompleteClientTraces(map[string]string{
+				semconv.AttributeNetSockPeerAddr: "127.10.10.1",
+				semconv.AttributePeerService:     "payments",
+			}),
+			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
+				attributes := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes()
+				verifyAttr(t, attributes, "server", "payments")
+			},
+		},
+		{
+			name: "incomplete traces without peer attributes",
+			sampleTraces: incompleteClientTraces(map[string]string{
+				"some-attribute": "val",
+			}),
+			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
+				attributes := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes()
+				verifyAttr(t, attributes, "server", "unknown")
+			},
+		},
+		{
+			name:         "incomplete traces with virtual client span",
+			sampleTraces: incompleteServerTraces(false),
+			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
+				attributes := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes()
+				verifyAttr(t, attributes, "client", "user")
+			},
+		},
+		{
+			name:         "incomplete traces with client span lost",
+			sampleTraces: incompleteServerTraces(true),
+			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
+				assert.Equal(t, 0, md.MetricCount())
+			},
+		},
+		{
+			name: "database request identified by db.system",
+			sampleTraces: incompleteClientTraces(map[string]string{
+				semconv.AttributeDBSystem: "postgresql",
+			}),
+			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
+				attributes := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes()
+				verifyAttr(t, attributes, "server", "postgresql")
+				verifyAttr(t, attributes, "connection_type", "database")
+			},
+		},
+		{
+			name: "database request named by peer.service",
+			sampleTraces: incompleteClientTraces(map[string]string{
+				semconv.AttributeDBSystem:    "postgresql",
+				semconv.AttributeDBName:      "orders",
+				semconv.AttributePeerService: "orders-db",
+			}),
+			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
+				attributes := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes()
+				verifyAttr(t, attributes, "server", "orders-db")
+				verifyAttr(t, attributes, "connection_type", "database")
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare
+			cfg := &Config{
+				Dimensions: []string{"some-attribute", "non-existing-attribute"},
+				Store: StoreConfig{
+					MaxItems: 10,
+					TTL:      time.Nanosecond,
+				},
+			}
+			conn := newConnector(zaptest.NewLogger(t), cfg)
+			conn.metricsConsumer = new(consumertest.MetricsSink)
+
+			assert.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+			// Test & verify
+			assert.NoError(t, conn.ConsumeTraces(context.Background(), tc.sampleTraces))
+
+			// Force collection
+			conn.store.Expire()
+			md, err := conn.buildMetrics()
+			assert.NoError(t, err)
+			tc.verifyMetrics(t, md)
+
+			// Shutdown the connector
+			assert.NoError(t, conn.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestConnectorConsumeForwardsMetrics(t *testing.T) {
+	// Prepare
+	cfg := &Config{
+		Dimensions: []string{"some-attribute", "non-existing-attribute"},
+		Store:      StoreConfig{MaxItems: 10},
+	}
+
+	sink := new(consumertest.MetricsSink)
+	conn := newConnector(zaptest.NewLogger(t), cfg)
+	conn.metricsConsumer = sink
+
+	assert.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+	// Test
+	td := buildSampleTrace(t, "val")
+	assert.NoError(t, conn.ConsumeTraces(context.Background(), td))
+
+	// Verify
+	require.Len(t, sink.AllMetrics(), 1)
+	verifyHappyCaseMetrics(t, sink.AllMetrics()[0])
+
+	// Shutdown the connector
+	assert.NoError(t, conn.Shutdown(context.Background()))
+}
+
+func verifyHappyCaseMetrics(t *testing.T, md pmetric.Metrics) {
+	assert.Equal(t, 3, md.MetricCount())
+
+	rms := md.ResourceMetrics()
+	assert.Equal(t, 1, rms.Len())
+
+	sms := rms.At(0).ScopeMetrics()
+	assert.Equal(t, 1, sms.Len())
+
+	ms := sms.At(0).Metrics()
+	assert.Equal(t, 3, ms.Len())
+
+	mCount := ms.At(0)
+	verifyCount(t, mCount)
+
+	mServerDuration := ms.At(1)
+	assert.Equal(t, "traces_service_graph_request_server_seconds", mServerDuration.Name())
+	verifyDuration(t, mServerDuration)
+
+	mClientDuration := ms.At(2)
+	assert.Equal(t, "traces_service_graph_request_client_seconds", mClientDuration.Name())
+	verifyDuration(t, mClientDuration)
+}
+
+func verifyCount(t *testing.T, m pmetric.Metric) {
+	assert.Equal(t, "traces_service_graph_request_total", m.Name())
+
+	assert.Equal(t, pmetric.MetricTypeSum, m.Type())
+	dps := m.Sum().DataPoints()
+	assert.Equal(t, 1, dps.Len())
+
+	dp := dps.At(0)
+	assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+	assert.Equal(t, int64(1), dp.IntValue())
+
+	attributes := dp.Attributes()
+	assert.Equal(t, 5, attributes.Len())
+	verifyAttr(t, attributes, "client", "some-service")
+	verifyAttr(t, attributes, "server", "some-service")
+	verifyAttr(t, attributes, "connection_type", "")
+	verifyAttr(t, attributes, "failed", "false")
+	verifyAttr(t, attributes, "client_some-attribute", "val")
+}
+
+func verifyDuration(t *testing.T, m pmetric.Metric) {
+	assert.Equal(t, pmetric.MetricTypeHistogram, m.Type())
+	assert.Equal(t, "s", m.Unit())
+	dps := m.Histogram().DataPoints()
+	assert.Equal(t, 1, dps.Len())
+
+	dp := dps.At(0)
+	assert.Equal(t, float64(1), dp.Sum()) // Duration: 1sec
+	assert.Equal(t, uint64(1), dp.Count())
+	buckets := pcommon.NewUInt64Slice()
+	buckets.FromRaw([]uint64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+	assert.Equal(t, buckets, dp.BucketCounts())
+
+	attributes := dp.Attributes()
+	assert.Equal(t, 5, attributes.Len())
+	verifyAttr(t, attributes, "client", "some-service")
+	verifyAttr(t, attributes, "server", "some-service")
+	verifyAttr(t, attributes, "connection_type", "")
+	verifyAttr(t, attributes, "client_some-attribute", "val")
+}
+
+func verifyAttr(t *testing.T, attrs pcommon.Map, k, expected string) {
+	v, ok := attrs.Get(k)
+	assert.True(t, ok)
+	assert.Equal(t, expected, v.AsString())
+}
+
+func buildSampleTrace(t *testing.T, attrValue string) ptrace.Traces {
+	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	tEnd := time.Date(2022, 1, 2, 3, 4, 6, 6, time.UTC)
+
+	traces := ptrace.NewTraces()
+
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().PutStr(semconv.AttributeServiceName, "some-service")
+
+	scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
+
+	var traceID pcommon.TraceID
+	_, err := rand.Read(traceID[:])
+	assert.NoError(t, err)
+
+	var clientSpanID, serverSpanID pcommon.SpanID
+	_, err = rand.Read(clientSpanID[:])
+	assert.NoError(t, err)
+	_, err = rand.Read(serverSpanID[:])
+	assert.NoError(t, err)
+
+	clientSpan := scopeSpans.Spans().AppendEmpty()
+	clientSpan.SetName("client span")
+	clientSpan.SetSpanID(clientSpanID)
+	clientSpan.SetTraceID(traceID)
+	clientSpan.SetKind(ptrace.SpanKindClient)
+	clientSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
+	clientSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(tEnd))
+	clientSpan.Attributes().PutStr("some-attribute", attrValue) // Attribute selected as dimension for metrics
+
+	serverSpan := scopeSpans.Spans().AppendEmpty()
+	serverSpan.SetName("server span")
+	serverSpan.SetSpanID(serverSpanID)
+	serverSpan.SetTraceID(traceID)
+	serverSpan.SetParentSpanID(clientSpanID)
+	serverSpan.SetKind(ptrace.SpanKindServer)
+	serverSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
+	serverSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(tEnd))
+
+	return traces
+}
+
+func incompleteClientTraces(attributes map[string]string) ptrace.Traces {
+	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	tEnd := time.Date(2022, 1, 2, 3, 4, 6, 6, time.UTC)
+
+	traces := ptrace.NewTraces()
+
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().PutStr(semconv.AttributeServiceName, "some-client-service")
+
+	scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
+	anotherTraceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	anotherClientSpanID := pcommon.SpanID([8]byte{1, 2, 3, 4, 4, 3, 2, 1})
+	clientSpanNoServerSpan := scopeSpans.Spans().AppendEmpty()
+	clientSpanNoServerSpan.SetName("client span")
+	clientSpanNoServerSpan.SetSpanID(anotherClientSpanID)
+	clientSpanNoServerSpan.SetTraceID(anotherTraceID)
+	clientSpanNoServerSpan.SetKind(ptrace.SpanKindClient)
+	clientSpanNoServerSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
+	clientSpanNoServerSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(tEnd))
+	for k, v := range attributes {
+		clientSpanNoServerSpan.Attributes().PutStr(k, v)
+	}
+
+	return traces
+}
+
+func incompleteServerTraces(withParentSpan bool) ptrace.Traces {
+	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	tEnd := time.Date(2022, 1, 2, 3, 4, 6, 6, time.UTC)
+
+	traces := ptrace.NewTraces()
+
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().PutStr(semconv.AttributeServiceName, "some-server-service")
+	scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
+	anotherTraceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+	serverSpanNoClientSpan := scopeSpans.Spans().AppendEmpty()
+	serverSpanNoClientSpan.SetName("server span")
+	serverSpanNoClientSpan.SetSpanID([8]byte{0x19, 0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26})
+	if withParentSpan {
+		serverSpanNoClientSpan.SetParentSpanID([8]byte{0x27, 0x28, 0x29, 0x30, 0x31, 0x32, 0x33, 0x34})
+	}
+	serverSpanNoClientSpan.SetTraceID(anotherTraceID)
+	serverSpanNoClientSpan.SetKind(ptrace.SpanKindServer)
+	serverSpanNoClientSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
+	serverSpanNoClientSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(tEnd))
+	return traces
+}
+
+func TestUpdateDurationMetrics(t *testing.T) {
+	p := serviceGraphConnector{
+		reqTotal:                 make(map[string]int64),
+		reqFailedTotal:           make(map[string]int64),
+		reqDurationBounds:        defaultLatencyHistogramBuckets,
+		reqServerDurationSeconds: newLatencyHistogram(),
+		reqClientDurationSeconds: newLatencyHistogram(),
+		keyToMetric:              make(map[string]metricSeries),
+		config: &Config{
+			Dimensions: []string{},
+		},
+	}
+	metricKey := p.buildMetricKey("foo", "bar", "", map[string]string{})
+
+	testCases := []struct {
+		caseStr  string
+		duration float64
+	}{
+
+		{
+			caseStr:  "index 0 latency",
+			duration: 0,
+		},
+		{
+			caseStr:  "out-of-range latency 1",
+			duration: 25,
+		},
+		{
+			caseStr:  "out-of-range latency 2",
+			duration: 125,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.caseStr, func(t *testing.T) {
+			p.updateDurationMetrics(metricKey, tc.duration, tc.duration)
+		})
+	}
+	assert.Equal(t, uint64(3), p.reqServerDurationSeconds.count[metricKey])
+	assert.Equal(t, uint64(2), p.reqClientDurationSeconds.bucketCounts[metricKey][len(defaultLatencyHistogramBuckets)])
+}
+
+func TestBuildMetricKey(t *testing.T) {
+	p := serviceGraphConnector{
+		config: &Config{
+			Dimensions: []string{"region"},
+		},
+	}
+
+	sameRegion := p.buildMetricKey("foo", "bar", "", map[string]string{"client_region": "eu", "server_region": "eu"})
+	otherRegion := p.buildMetricKey("foo", "bar", "", map[string]string{"client_region": "eu", "server_region": "us"})
+	noRegion := p.buildMetricKey("foo", "bar", "", map[string]string{})
+
+	assert.NotEqual(t, sameRegion, otherRegion)
+	assert.NotEqual(t, sameRegion, noRegion)
+	assert.Equal(t, sameRegion, p.buildMetricKey("foo", "bar", "", map[string]string{"server_region": "eu", "client_region": "eu"}))
+}
+
+func TestStaleSeriesCleanup(t *testing.T) {
+	// Prepare
+	cfg := &Config{
+		Dimensions: []string{"some-attribute", "non-existing-attribute"},
+		Store: StoreConfig{
+			MaxItems: 10,
+			TTL:      time.Second,
+		},
+	}
+
+	p := newConnector(zaptest.NewLogger(t), cfg)
+	p.metricsConsumer = new(consumertest.MetricsSink)
+
+	assert.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	// ConsumeTraces
+	td := buildSampleTrace(t, "first")
+	assert.NoError(t, p.ConsumeTraces(context.Background(), td))
+
+	// Make series stale and force a cache cleanup
+	for key, metric := range p.keyToMetric {
+		metric.lastUpdated = 0
+		p.keyToMetric[key] = metric
+	}
+	p.cleanCache()
+	assert.Equal(t, 0, len(p.keyToMetric))
+	assert.Equal(t, 0, len(p.reqServerDurationSeconds.count))
+	assert.Equal(t, 0, len(p.reqClientDurationSeconds.count))
+
+	// ConsumeTraces with a trace with different attribute value
+	td = buildSampleTrace(t, "second")
+	assert.NoError(t, p.ConsumeTraces(context.Background(), td))
+
+	// Shutdown the connector
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package servicegraphconnector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestNewConnector(t *testing.T) {
+	for _, tc := range []struct {
+		name                            string
+		latencyHistogramBuckets         []time.Duration
+		expectedLatencyHistogramBuckets []float64
+	}{
+		{
+			name:                            "simplest config (use defaults)",
+			expectedLatencyHistogramBuckets: defaultLatencyHistogramBuckets,
+		},
+		{
+			name:                            "latency histogram configured",
+			latencyHistogramBuckets:         []time.Duration{2 * time.Millisecond, time.Second},
+			expectedLatencyHistogramBuckets: []float64{0.002, 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare
+			factory := NewFactory()
+
+			creationParams := connectortest.NewNopCreateSettings()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.LatencyHistogramBuckets = tc.latencyHistogramBuckets
+
+			// Test
+			conn, err := factory.CreateTracesToMetrics(context.Background(), creationParams, cfg, consumertest.NewNop())
+			smc := conn.(*serviceGraphConnector)
+
+			// Verify
+			assert.NoError(t, err)
+			assert.NotNil(t, smc)
+
+			assert.Equal(t, tc.expectedLatencyHistogramBuckets, smc.reqDurationBounds)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package servicegraphconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+)
+
+var (
+	statDroppedSpans = stats.Int64(buildConnectorCustomMetricName("dropped_spans"), "Number of spans dropped when trying to add edges", stats.UnitDimensionless)
+	statTotalEdges   = stats.Int64(buildConnectorCustomMetricName("total_edges"), "Total number of unique edges", stats.UnitDimensionless)
+	statExpiredEdges = stats.Int64(buildConnectorCustomMetricName("expired_edges"), "Number of edges that expired before finding its matching span", stats.UnitDimensionless)
+)
+
+// buildConnectorCustomMetricName is used to be build a metric name following
+// the standards used in the Collector, e.g. connector/servicegraph/total_edges.
+func buildConnectorCustomMetricName(metric string) string {
+	return "connector/" + typeStr + "/" + metric
+}
+
+func serviceGraphConnectorViews() []*view.View {
+	droppedSpansView := &view.View{
+		Name:        statDroppedSpans.Name(),
+		Description: statDroppedSpans.Description(),
+		Measure:     statDroppedSpans,
+		Aggregation: view.Count(),
+	}
+	totalEdgesView := &view.View{
+		Name:        statTotalEdges.Name(),
+		Description: statTotalEdges.Description(),
+		Measure:     statTotalEdges,
+		Aggregation: view.Count(),
+	}
+	expiredEdgesView := &view.View{
+		Name:        statExpiredEdges.Name(),
+		Description: statExpiredEdges.Description(),
+		Measure:     statExpiredEdges,
+		Aggregation: view.Count(),
+	}
+
+	return []*view.View{
+		droppedSpansView,
+		totalEdgesView,
+		expiredEdgesView,
+	}
+}
@@ -54,6 +54,12 @@ type Config struct {
 
 	// Namespace is the namespace of the metrics emitted by the connector.
 	Namespace string `mapstructure:"namespace"`
+
+	// Exemplars defines the configuration for exemplars attached to the duration histogram.
+	Exemplars ExemplarsConfig `mapstructure:"exemplars"`
+
+	// Events defines the configuration for the counter over span events.
+	Events EventsConfig `mapstructure:"events"`
 }
 
 type HistogramConfig struct {
@@ -71,6 +77,19 @@ type ExplicitHistogramConfig struct {
 	Buckets []time.Duration `mapstructure:"buckets"`
 }
 
+type ExemplarsConfig struct {
+	// Enabled attaches exemplars carrying the trace and span IDs of the observed spans
+	// to the duration histogram data points.
+	Enabled bool `mapstructure:"enabled"`
+}
+
+type EventsConfig struct {
+	// Enabled generates the events counter, with the span event name as an additional dimension.
+	Enabled bool `mapstructure:"enabled"`
+	// Dimensions defines the list of additional dimensions fetched from the span event's attributes.
+	Dimensions []Dimension `mapstructure:"dimensions"`
+}
+
 var _ component.ConfigValidator = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
@@ -90,6 +109,12 @@ func (c Config) Validate() error {
 	if c.Histogram.Explicit != nil && c.Histogram.Exponential != nil {
 		return errors.New("use either `explicit` or `exponential` buckets histogram")
 	}
+
+	if c.Events.Enabled {
+		if err = validateEventDimensions(c.Dimensions, c.Events.Dimensions); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -118,3 +143,13 @@ func validateDimensions(dimensions []Dimension) error {
 
 	return nil
 }
+
+// validateEventDimensions checks duplicates between the event dimensions, the event name
+// dimension and the span dimensions, as all of them are added to the events metric.
+func validateEventDimensions(dimensions []Dimension, eventDimensions []Dimension) error {
+	all := make([]Dimension, 0, len(dimensions)+len(eventDimensions)+1)
+	all = append(all, dimensions...)
+	all = append(all, Dimension{Name: eventNameKey})
+	all = append(all, eventDimensions...)
+	return validateDimensions(all)
+}
@@ -58,6 +58,13 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				Exemplars: ExemplarsConfig{Enabled: false},
+				Events: EventsConfig{
+					Enabled: true,
+					Dimensions: []Dimension{
+						{Name: "exception.type", Default: (*string)(nil)},
+					},
+				},
 			},
 		},
 		{
@@ -72,6 +79,7 @@ func TestLoadConfig(t *testing.T) {
 						MaxSize: 10,
 					},
 				},
+				Exemplars: ExemplarsConfig{Enabled: true},
 			},
 		},
 		{
@@ -82,6 +90,10 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(typeStr, "invalid_histogram_unit"),
 			errorMessage: "unknown Unit \"h\"",
 		},
+		{
+			id:           component.NewIDWithName(typeStr, "invalid_event_dimensions"),
+			errorMessage: "duplicate dimension name http.method",
+		},
 	}
 
 	for _, tt := range tests {
@@ -158,3 +170,41 @@ func TestValidateDimensions(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateEventDimensions(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		dimensions      []Dimension
+		eventDimensions []Dimension
+		expectedErr     string
+	}{
+		{
+			name: "no additional dimensions",
+		},
+		{
+			name:            "no duplicate dimensions",
+			dimensions:      []Dimension{{Name: "http.method"}},
+			eventDimensions: []Dimension{{Name: "exception.type"}},
+		},
+		{
+			name:            "duplicate event name dimension",
+			eventDimensions: []Dimension{{Name: "event.name"}},
+			expectedErr:     "duplicate dimension name event.name",
+		},
+		{
+			name:            "duplicate span and event dimensions",
+			dimensions:      []Dimension{{Name: "exception.type"}},
+			eventDimensions: []Dimension{{Name: "exception.type"}},
+			expectedErr:     "duplicate dimension name exception.type",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateEventDimensions(tc.dimensions, tc.eventDimensions)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
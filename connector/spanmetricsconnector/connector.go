@@ -30,12 +30,14 @@ const (
 	spanNameKey        = "span.name"   // OpenTelemetry non-standard constant.
 	spanKindKey        = "span.kind"   // OpenTelemetry non-standard constant.
 	statusCodeKey      = "status.code" // OpenTelemetry non-standard constant.
+	eventNameKey       = "event.name"  // OpenTelemetry non-standard constant.
 	metricKeySeparator = string(byte(0))
 
 	defaultDimensionsCacheSize = 1000
 
 	metricNameDuration = "duration"
 	metricNameCalls    = "calls"
+	metricNameEvents   = "events"
 
 	defaultUnit = metrics.Milliseconds
 )
@@ -50,6 +52,9 @@ type connectorImp struct {
 	// Additional dimensions to add to metrics.
 	dimensions []dimension
 
+	// Additional dimensions to add to the events metric, fetched from the span events' attributes.
+	eDimensions []dimension
+
 	// The starting time of the data points.
 	startTimestamp pcommon.Timestamp
 
@@ -61,6 +66,10 @@ type connectorImp struct {
 	// e.g. { "foo/barOK": { "serviceName": "foo", "span.name": "/bar", "status_code": "OK" }}
 	metricKeyToDimensions *cache.Cache[metrics.Key, pcommon.Map]
 
+	// An LRU cache of the events metric dimension key-value maps. It is kept apart from
+	// metricKeyToDimensions so that event keys can never be mistaken for span keys.
+	eventKeyToDimensions *cache.Cache[metrics.Key, pcommon.Map]
+
 	ticker  *clock.Ticker
 	done    chan struct{}
 	started bool
@@ -71,6 +80,7 @@ type connectorImp struct {
 type resourceMetrics struct {
 	histograms metrics.HistogramMetrics
 	sums       metrics.SumMetrics
+	events     metrics.SumMetrics
 	attributes pcommon.Map
 }
 
@@ -103,14 +113,21 @@ func newConnector(logger *zap.Logger, config component.Config, ticker *clock.Tic
 		return nil, err
 	}
 
+	eventKeyToDimensionsCache, err := cache.NewCache[metrics.Key, pcommon.Map](cfg.DimensionsCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
 	return &connectorImp{
 		logger:                logger,
 		config:                *cfg,
 		startTimestamp:        pcommon.NewTimestampFromTime(time.Now()),
 		resourceMetrics:       make(map[resourceKey]*resourceMetrics),
 		dimensions:            newDimensions(cfg.Dimensions),
+		eDimensions:           newDimensions(cfg.Events.Dimensions),
 		keyBuf:                bytes.NewBuffer(make([]byte, 0, 1024)),
 		metricKeyToDimensions: metricKeyToDimensionsCache,
+		eventKeyToDimensions:  eventKeyToDimensionsCache,
 		ticker:                ticker,
 		done:                  make(chan struct{}),
 	}, nil
@@ -230,6 +247,13 @@ func (p *connectorImp) buildMetrics() pmetric.Metrics {
 		metric.SetName(buildMetricName(p.config.Namespace, metricNameDuration))
 		metric.SetUnit(p.config.Histogram.Unit.String())
 		histograms.BuildMetrics(metric, p.startTimestamp, p.config.GetAggregationTemporality())
+
+		if p.config.Events.Enabled {
+			events := rawMetrics.events
+			metric = sm.Metrics().AppendEmpty()
+			metric.SetName(buildMetricName(p.config.Namespace, metricNameEvents))
+			events.BuildMetrics(metric, p.startTimestamp, p.config.GetAggregationTemporality())
+		}
 	}
 
 	return m
@@ -240,8 +264,10 @@ func (p *connectorImp) resetState() {
 	if p.config.GetAggregationTemporality() == pmetric.AggregationTemporalityDelta {
 		p.resourceMetrics = make(map[resourceKey]*resourceMetrics)
 		p.metricKeyToDimensions.Purge()
+		p.eventKeyToDimensions.Purge()
 	} else {
 		p.metricKeyToDimensions.RemoveEvictedItems()
+		p.eventKeyToDimensions.RemoveEvictedItems()
 
 		// Exemplars are only relevant to this batch of traces, so must be cleared within the lock
 		for _, m := range p.resourceMetrics {
@@ -255,7 +281,8 @@ func (p *connectorImp) resetState() {
 // Metrics are grouped by resource attributes.
 // Each metric is identified by a key that is built from the service name
 // and span metadata such as name, kind, status_code and any additional
-// dimensions the user has configured.
+// dimensions the user has configured. When enabled, span events are counted
+// additionally keyed by the event name and the configured event dimensions.
 func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 	for i := 0; i < traces.ResourceSpans().Len(); i++ {
 		rspans := traces.ResourceSpans().At(i)
@@ -295,18 +322,41 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 				// aggregate histogram metrics
 				h := histograms.GetOrCreate(key, attributes)
 				h.Observe(duration)
-				if !span.TraceID().IsEmpty() {
+				if p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
 					h.AddExemplar(span.TraceID(), span.SpanID(), duration)
 				}
 
 				// aggregate sums metrics
 				s := sums.GetOrCreate(key, attributes)
 				s.Add(1)
+
+				// aggregate events metrics
+				if p.config.Events.Enabled {
+					p.aggregateEvents(serviceName, span, resourceAttr, &rm.events)
+				}
 			}
 		}
 	}
 }
 
+// aggregateEvents counts the span's events. Each event is identified by the span metric key
+// extended with the event name and the configured event dimensions.
+func (p *connectorImp) aggregateEvents(serviceName string, span ptrace.Span, resourceAttr pcommon.Map, events *metrics.SumMetrics) {
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		key := p.buildEventKey(serviceName, span, event, resourceAttr)
+
+		attributes, ok := p.eventKeyToDimensions.Get(key)
+		if !ok {
+			attributes = p.buildEventAttributes(serviceName, span, event, resourceAttr)
+			p.eventKeyToDimensions.Add(key, attributes)
+		}
+
+		e := events.GetOrCreate(key, attributes)
+		e.Add(1)
+	}
+}
+
 type resourceKey [16]byte
 
 func (p *connectorImp) getOrCreateResourceMetrics(attr pcommon.Map) *resourceMetrics {
@@ -316,6 +366,7 @@ func (p *connectorImp) getOrCreateResourceMetrics(attr pcommon.Map) *resourceMet
 		v = &resourceMetrics{
 			histograms: p.initHistogramMetrics(),
 			sums:       metrics.NewSumMetrics(),
+			events:     metrics.NewSumMetrics(),
 			attributes: attr,
 		}
 		p.resourceMetrics[key] = v
@@ -338,6 +389,17 @@ func (p *connectorImp) buildAttributes(serviceName string, span ptrace.Span, res
 	return attr
 }
 
+func (p *connectorImp) buildEventAttributes(serviceName string, span ptrace.Span, event ptrace.SpanEvent, resourceAttrs pcommon.Map) pcommon.Map {
+	attr := p.buildAttributes(serviceName, span, resourceAttrs)
+	attr.PutStr(eventNameKey, event.Name())
+	for _, d := range p.eDimensions {
+		if v, ok := getDimensionValue(d, event.Attributes()); ok {
+			v.CopyTo(attr.PutEmpty(d.name))
+		}
+	}
+	return attr
+}
+
 func concatDimensionValue(dest *bytes.Buffer, value string, prefixSep bool) {
 	if prefixSep {
 		dest.WriteString(metricKeySeparator)
@@ -366,20 +428,34 @@ func (p *connectorImp) buildKey(serviceName string, span ptrace.Span, optionalDi
 	return metrics.Key(p.keyBuf.String())
 }
 
+// buildEventKey builds the events metric key from the span metric key, the event name and
+// any event dimensions the user has configured that match the event's attributes.
+func (p *connectorImp) buildEventKey(serviceName string, span ptrace.Span, event ptrace.SpanEvent, resourceAttrs pcommon.Map) metrics.Key {
+	// buildKey leaves the span metric key in keyBuf, so the event values are appended to it.
+	p.buildKey(serviceName, span, p.dimensions, resourceAttrs)
+	concatDimensionValue(p.keyBuf, event.Name(), true)
+
+	for _, d := range p.eDimensions {
+		if v, ok := getDimensionValue(d, event.Attributes()); ok {
+			concatDimensionValue(p.keyBuf, v.AsString(), true)
+		}
+	}
+
+	return metrics.Key(p.keyBuf.String())
+}
+
 // getDimensionValue gets the dimension value for the given configured dimension.
-// It searches through the span's attributes first, being the more specific;
-// falling back to searching in resource attributes if it can't be found in the span.
+// It searches through the given attribute maps in order, so the more specific ones, such as
+// the span's attributes, should come before the resource attributes.
 // Finally, falls back to the configured default value if provided.
 //
 // The ok flag indicates if a dimension value was fetched in order to differentiate
 // an empty string value from a state where no value was found.
-func getDimensionValue(d dimension, spanAttr pcommon.Map, resourceAttr pcommon.Map) (v pcommon.Value, ok bool) {
-	// The more specific span attribute should take precedence.
-	if attr, exists := spanAttr.Get(d.name); exists {
-		return attr, true
-	}
-	if attr, exists := resourceAttr.Get(d.name); exists {
-		return attr, true
+func getDimensionValue(d dimension, attrs ...pcommon.Map) (v pcommon.Value, ok bool) {
+	for _, attr := range attrs {
+		if v, exists := attr.Get(d.name); exists {
+			return v, true
+		}
 	}
 	// Set the default if configured, otherwise this metric will have no value set for the dimension.
 	if d.value != nil {
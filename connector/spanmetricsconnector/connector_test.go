@@ -721,6 +721,124 @@ func TestConsumeTraces(t *testing.T) {
 	}
 }
 
+func TestExemplars(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		enabled       bool
+		wantExemplars int
+	}{
+		{name: "disabled", enabled: false, wantExemplars: 0},
+		{name: "enabled", enabled: true, wantExemplars: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newConnectorImp(t, nil, stringp("defaultNullValue"), explicitHistogramsConfig, cumulative, zaptest.NewLogger(t), nil)
+			p.config.Exemplars.Enabled = tc.enabled
+
+			traces := buildSampleTrace()
+			p.aggregateMetrics(traces)
+			m := p.buildMetrics()
+
+			for i := 0; i < m.ResourceMetrics().Len(); i++ {
+				metric := m.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics().At(1)
+				require.Equal(t, metricNameDuration, metric.Name())
+				dps := metric.Histogram().DataPoints()
+				for j := 0; j < dps.Len(); j++ {
+					exemplars := dps.At(j).Exemplars()
+					require.Equal(t, tc.wantExemplars, exemplars.Len())
+					for k := 0; k < exemplars.Len(); k++ {
+						assert.False(t, exemplars.At(k).TraceID().IsEmpty())
+						assert.False(t, exemplars.At(k).SpanID().IsEmpty())
+						assert.Equal(t, sampleDuration, exemplars.At(k).DoubleValue())
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestConsumeTracesWithEvents(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Events = EventsConfig{
+		Enabled: true,
+		Dimensions: []Dimension{
+			{Name: "exception.type"},
+			{Name: "exception.escaped", Default: stringp("false")},
+		},
+	}
+	p, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	require.NoError(t, err)
+
+	traces := buildSampleTrace()
+	span := traces.ResourceSpans().At(1).ScopeSpans().At(0).Spans().At(0)
+	for _, exceptionType := range []string{"NullPointerException", "NullPointerException", "IOException"} {
+		event := span.Events().AppendEmpty()
+		event.SetName("exception")
+		event.Attributes().PutStr("exception.type", exceptionType)
+	}
+	span.Events().AppendEmpty().SetName("message")
+
+	p.aggregateMetrics(traces)
+	m := p.buildMetrics()
+
+	var events pmetric.Metric
+	for i := 0; i < m.ResourceMetrics().Len(); i++ {
+		rm := m.ResourceMetrics().At(i)
+		ms := rm.ScopeMetrics().At(0).Metrics()
+		require.Equal(t, 3, ms.Len())
+		require.Equal(t, metricNameEvents, ms.At(2).Name())
+		if serviceName, _ := rm.Resource().Attributes().Get(serviceNameKey); serviceName.Str() == "service-b" {
+			events = ms.At(2)
+		} else {
+			assert.Equal(t, 0, ms.At(2).Sum().DataPoints().Len())
+		}
+	}
+
+	require.Equal(t, pmetric.MetricTypeSum, events.Type())
+	assert.True(t, events.Sum().IsMonotonic())
+	dps := events.Sum().DataPoints()
+	require.Equal(t, 3, dps.Len())
+
+	got := make(map[string]int64)
+	for i := 0; i < dps.Len(); i++ {
+		attrs := dps.At(i).Attributes()
+		spanName, _ := attrs.Get(spanNameKey)
+		assert.Equal(t, "/ping", spanName.Str())
+		escaped, _ := attrs.Get("exception.escaped")
+		assert.Equal(t, "false", escaped.Str())
+
+		eventName, _ := attrs.Get(eventNameKey)
+		key := eventName.Str()
+		if exceptionType, ok := attrs.Get("exception.type"); ok {
+			key += "/" + exceptionType.Str()
+		}
+		got[key] = dps.At(i).IntValue()
+	}
+	assert.Equal(t, map[string]int64{
+		"exception/NullPointerException": 2,
+		"exception/IOException":          1,
+		"message":                        1,
+	}, got)
+}
+
+func TestBuildEventKey(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Events = EventsConfig{
+		Enabled:    true,
+		Dimensions: []Dimension{{Name: "exception.type"}},
+	}
+	c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	require.NoError(t, err)
+
+	span := ptrace.NewSpan()
+	span.SetName("c")
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().PutStr("exception.type", "IOException")
+
+	key := c.buildEventKey("ab", span, event, pcommon.NewMap())
+	assert.Equal(t, metrics.Key("ab\u0000c\u0000SPAN_KIND_UNSPECIFIED\u0000STATUS_CODE_UNSET\u0000exception\u0000IOException"), key)
+}
+
 func TestMetricKeyCache(t *testing.T) {
 	mcon := &mocks.MetricsConsumer{}
 	mcon.On("ConsumeMetrics", mock.Anything, mock.Anything).Return(nil)
@@ -35,6 +35,7 @@ func createDefaultConfig() component.Config {
 		DimensionsCacheSize:    defaultDimensionsCacheSize,
 		MetricsFlushInterval:   15 * time.Second,
 		Histogram:              HistogramConfig{Unit: defaultUnit},
+		Exemplars:              ExemplarsConfig{Enabled: true},
 	}
 }
 
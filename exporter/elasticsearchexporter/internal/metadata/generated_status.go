@@ -7,7 +7,8 @@ import (
 )
 
 const (
-	Type            = "elasticsearch"
-	TracesStability = component.StabilityLevelBeta
-	LogsStability   = component.StabilityLevelBeta
+	Type             = "elasticsearch"
+	TracesStability  = component.StabilityLevelBeta
+	LogsStability    = component.StabilityLevelBeta
+	MetricsStability = component.StabilityLevelDevelopment
 )
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package elasticsearchexporter contains an opentelemetry-collector exporter
+// for Elasticsearch.
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+type elasticsearchMetricsExporter struct {
+	logger *zap.Logger
+
+	index        string
+	dynamicIndex bool
+	maxAttempts  int
+
+	client      *esClientCurrent
+	bulkIndexer esBulkIndexerCurrent
+	model       mappingModel
+}
+
+func newMetricsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchMetricsExporter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := newElasticsearchClient(logger, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	bulkIndexer, err := newBulkIndexer(logger, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	maxAttempts := 1
+	if cfg.Retry.Enabled {
+		maxAttempts = cfg.Retry.MaxRequests
+	}
+
+	model := &encodeModel{dedup: cfg.Mapping.Dedup, dedot: cfg.Mapping.Dedot}
+
+	return &elasticsearchMetricsExporter{
+		logger:      logger,
+		client:      client,
+		bulkIndexer: bulkIndexer,
+
+		index:        cfg.MetricsIndex,
+		dynamicIndex: cfg.MetricsDynamicIndex.Enabled,
+		maxAttempts:  maxAttempts,
+		model:        model,
+	}, nil
+}
+
+func (e *elasticsearchMetricsExporter) Shutdown(ctx context.Context) error {
+	return e.bulkIndexer.Close(ctx)
+}
+
+func (e *elasticsearchMetricsExporter) pushMetricsData(
+	ctx context.Context,
+	md pmetric.Metrics,
+) error {
+	var errs, invalidErrs []error
+	resourceMetrics := md.ResourceMetrics()
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		rm := resourceMetrics.At(i)
+		resource := rm.Resource()
+		groups, err := groupDataPoints(rm.ScopeMetrics())
+		if err != nil {
+			invalidErrs = append(invalidErrs, err)
+		}
+		for _, group := range groups {
+			if err := e.pushDataPointGroup(ctx, resource, group); err != nil {
+				if cerr := ctx.Err(); cerr != nil {
+					return cerr
+				}
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if len(invalidErrs) == 0 {
+		return multierr.Combine(errs...)
+	}
+	// Retrying would not make the invalid data points convertible, so they are
+	// dropped when the other data points need to be retried.
+	if len(errs) > 0 {
+		e.logger.Warn("Drop data points: failed to convert", zap.Error(multierr.Combine(invalidErrs...)))
+		return multierr.Combine(errs...)
+	}
+	return consumererror.NewPermanent(multierr.Combine(invalidErrs...))
+}
+
+func (e *elasticsearchMetricsExporter) pushDataPointGroup(ctx context.Context, resource pcommon.Resource, group *dataPointGroup) error {
+	fIndex := e.index
+	if e.dynamicIndex {
+		prefix := getFromBothResourceAndAttribute(indexPrefix, resource, group)
+		suffix := getFromBothResourceAndAttribute(indexSuffix, resource, group)
+
+		fIndex = fmt.Sprintf("%s%s%s", prefix, fIndex, suffix)
+	}
+
+	document, err := e.model.encodeDataPoints(resource, group)
+	if err != nil {
+		return fmt.Errorf("Failed to encode metric data points: %w", err)
+	}
+	return pushDocuments(ctx, e.logger, fIndex, document, e.bulkIndexer, e.maxAttempts)
+}
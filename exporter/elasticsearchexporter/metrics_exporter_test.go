@@ -0,0 +1,360 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package elasticsearchexporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMetricsExporter_New(t *testing.T) {
+	t.Setenv(defaultElasticsearchEnvName, "")
+
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withDefaultConfig())
+	require.ErrorIs(t, err, errConfigNoEndpoint)
+	require.Nil(t, exporter)
+
+	exporter, err = newMetricsExporter(zaptest.NewLogger(t), withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoints = []string{"test:9200"}
+		cfg.MetricsIndex = "my-metrics"
+	}))
+	require.NoError(t, err)
+	require.NotNil(t, exporter)
+	assert.Equal(t, "my-metrics", exporter.index)
+	require.NoError(t, exporter.Shutdown(context.TODO()))
+}
+
+func TestMetricsExporter_PushMetricsData(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/10178")
+	}
+
+	t.Run("group data points", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL)
+		md := pmetric.NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("host.name", "web-1")
+		metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+		addGauge(metrics, "system.memory.usage", 1000, "state", "used")
+		addGauge(metrics, "system.memory.limit", 2000, "state", "used")
+		addGauge(metrics, "system.memory.usage", 3000, "state", "free")
+		require.NoError(t, exporter.pushMetricsData(context.TODO(), md))
+
+		rec.WaitItems(2)
+		var docs []map[string]interface{}
+		for _, item := range rec.Items() {
+			var doc map[string]interface{}
+			require.NoError(t, json.Unmarshal(item.Document, &doc))
+			docs = append(docs, doc)
+		}
+		// Fields are dedotted into objects by default.
+		assert.ElementsMatch(t, []map[string]interface{}{
+			{
+				"@timestamp": "1970-01-01T00:00:01.000000000Z",
+				"Attributes": map[string]interface{}{"state": "used"},
+				"Resource":   map[string]interface{}{"host": map[string]interface{}{"name": "web-1"}},
+				"system": map[string]interface{}{"memory": map[string]interface{}{
+					"usage": float64(1000),
+					"limit": float64(2000),
+				}},
+			},
+			{
+				"@timestamp": "1970-01-01T00:00:01.000000000Z",
+				"Attributes": map[string]interface{}{"state": "free"},
+				"Resource":   map[string]interface{}{"host": map[string]interface{}{"name": "web-1"}},
+				"system": map[string]interface{}{"memory": map[string]interface{}{
+					"usage": float64(3000),
+				}},
+			},
+		}, docs)
+	})
+
+	t.Run("group data points by scope", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL, func(cfg *Config) {
+			cfg.Mapping.Dedot = false
+		})
+		md := pmetric.NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		for _, name := range []string{"io.opentelemetry.http", "io.opentelemetry.grpc"} {
+			sm := rm.ScopeMetrics().AppendEmpty()
+			sm.Scope().SetName(name)
+			addGauge(sm.Metrics(), "requests", 1)
+		}
+		require.NoError(t, exporter.pushMetricsData(context.TODO(), md))
+
+		rec.WaitItems(2)
+		var docs []map[string]interface{}
+		for _, item := range rec.Items() {
+			var doc map[string]interface{}
+			require.NoError(t, json.Unmarshal(item.Document, &doc))
+			docs = append(docs, doc)
+		}
+		assert.ElementsMatch(t, []map[string]interface{}{
+			{
+				"@timestamp": "1970-01-01T00:00:01.000000000Z",
+				"Scope.name": "io.opentelemetry.http",
+				"requests":   float64(1),
+			},
+			{
+				"@timestamp": "1970-01-01T00:00:01.000000000Z",
+				"Scope.name": "io.opentelemetry.grpc",
+				"requests":   float64(1),
+			},
+		}, docs)
+	})
+
+	t.Run("publish with dynamic index", func(t *testing.T) {
+		rec := newBulkRecorder()
+		var (
+			prefix = "resprefix-"
+			suffix = "-attrsuffix"
+			index  = "someindex"
+		)
+
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+
+			jsonVal := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(docs[0].Action, &jsonVal))
+
+			create := jsonVal["create"].(map[string]interface{})
+			expected := fmt.Sprintf("%s%s%s", prefix, index, suffix)
+			assert.Equal(t, expected, create["_index"].(string))
+
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL, func(cfg *Config) {
+			cfg.MetricsIndex = index
+			cfg.MetricsDynamicIndex.Enabled = true
+		})
+
+		md := pmetric.NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr(indexPrefix, prefix)
+		addGauge(rm.ScopeMetrics().AppendEmpty().Metrics(), "requests", 1, indexSuffix, suffix)
+		require.NoError(t, exporter.pushMetricsData(context.TODO(), md))
+
+		rec.WaitItems(1)
+	})
+
+	t.Run("report invalid data points", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL)
+		md := pmetric.NewMetrics()
+		metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+		addGauge(metrics, "requests", 1)
+		invalid := metrics.AppendEmpty()
+		invalid.SetName("latency")
+		dp := invalid.SetEmptyHistogram().DataPoints().AppendEmpty()
+		dp.ExplicitBounds().FromRaw([]float64{1, 2})
+		dp.BucketCounts().FromRaw([]uint64{1})
+
+		summary := metrics.AppendEmpty()
+		summary.SetName("rpc.duration")
+		summary.SetEmptySummary().DataPoints().AppendEmpty()
+
+		err := exporter.pushMetricsData(context.TODO(), md)
+		assert.ErrorIs(t, err, errInvalidHistogram)
+		assert.ErrorIs(t, err, errSummaryNotSupported)
+		assert.True(t, consumererror.IsPermanent(err), "Must not retry data points that can not be converted")
+		rec.WaitItems(1)
+	})
+
+	t.Run("retry valid data points along invalid ones", func(t *testing.T) {
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL)
+		exporter.bulkIndexer = &failingBulkIndexer{esBulkIndexerCurrent: exporter.bulkIndexer}
+		md := pmetric.NewMetrics()
+		metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+		addGauge(metrics, "requests", 1)
+		summary := metrics.AppendEmpty()
+		summary.SetName("rpc.duration")
+		summary.SetEmptySummary().DataPoints().AppendEmpty()
+
+		err := exporter.pushMetricsData(context.TODO(), md)
+		assert.ErrorIs(t, err, errBulkIndexerFull)
+		assert.NotErrorIs(t, err, errSummaryNotSupported)
+		assert.False(t, consumererror.IsPermanent(err), "Must retry data points that failed to be published")
+	})
+}
+
+var errBulkIndexerFull = errors.New("bulk indexer is full")
+
+// failingBulkIndexer fails to add any item.
+type failingBulkIndexer struct {
+	esBulkIndexerCurrent
+}
+
+func (*failingBulkIndexer) Add(context.Context, esBulkIndexerItem) error {
+	return errBulkIndexerFull
+}
+
+func TestHistogramValue(t *testing.T) {
+	tests := map[string]struct {
+		bounds []float64
+		counts []uint64
+		sum    float64
+		want   map[string]interface{}
+	}{
+		"explicit bounds": {
+			bounds: []float64{1, 5, 10},
+			counts: []uint64{2, 0, 3, 1},
+			want: map[string]interface{}{
+				"values": []interface{}{0.5, 7.5, float64(10)},
+				"counts": []interface{}{int64(2), int64(3), int64(1)},
+			},
+		},
+		"negative first bound": {
+			bounds: []float64{-1, 1},
+			counts: []uint64{1, 1, 0},
+			want: map[string]interface{}{
+				"values": []interface{}{float64(-1), float64(0)},
+				"counts": []interface{}{int64(1), int64(1)},
+			},
+		},
+		"single bucket": {
+			counts: []uint64{4},
+			sum:    10,
+			want: map[string]interface{}{
+				"values": []interface{}{2.5},
+				"counts": []interface{}{int64(4)},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dp := pmetric.NewHistogramDataPoint()
+			dp.ExplicitBounds().FromRaw(tt.bounds)
+			dp.BucketCounts().FromRaw(tt.counts)
+			var count uint64
+			for _, c := range tt.counts {
+				count += c
+			}
+			dp.SetCount(count)
+			dp.SetSum(tt.sum)
+
+			value, err := histogramValue(dp)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value.Map().AsRaw())
+		})
+	}
+
+	t.Run("bucket count mismatch", func(t *testing.T) {
+		dp := pmetric.NewHistogramDataPoint()
+		dp.ExplicitBounds().FromRaw([]float64{1, 2})
+		dp.BucketCounts().FromRaw([]uint64{1, 2})
+		_, err := histogramValue(dp)
+		assert.ErrorIs(t, err, errInvalidHistogram)
+	})
+}
+
+func TestExponentialHistogramValue(t *testing.T) {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(0)
+	dp.SetZeroCount(1)
+	dp.Positive().SetOffset(1)
+	dp.Positive().BucketCounts().FromRaw([]uint64{2, 0, 3})
+	dp.Negative().SetOffset(0)
+	dp.Negative().BucketCounts().FromRaw([]uint64{4, 5})
+
+	value := exponentialHistogramValue(dp)
+	assert.Equal(t, map[string]interface{}{
+		// Buckets with scale 0 are (2^i, 2^(i+1)].
+		"values": []interface{}{-3.0, -1.5, 0.0, 3.0, 12.0},
+		"counts": []interface{}{int64(5), int64(4), int64(1), int64(2), int64(3)},
+	}, value.Map().AsRaw())
+}
+
+func TestEncodeDataPoints(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+	addGauge(metrics, "queue.size", 3)
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sumDp := sum.SetEmptySum().DataPoints().AppendEmpty()
+	sumDp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1, 0)))
+	sumDp.SetDoubleValue(1.5)
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogramDp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	histogramDp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1, 0)))
+	histogramDp.ExplicitBounds().FromRaw([]float64{10})
+	histogramDp.BucketCounts().FromRaw([]uint64{1, 2})
+	summary := metrics.AppendEmpty()
+	summary.SetName("dropped")
+	summary.SetEmptySummary().DataPoints().AppendEmpty()
+
+	groups, err := groupDataPoints(rm.ScopeMetrics())
+	assert.ErrorIs(t, err, errSummaryNotSupported)
+	require.Len(t, groups, 1)
+
+	model := &encodeModel{dedup: true}
+	doc, err := model.encodeDataPoints(rm.Resource(), groups[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@timestamp": "1970-01-01T00:00:01.000000000Z",
+		"Resource.service.name": "checkout",
+		"latency": {"counts": [1, 2], "values": [5, 10]},
+		"queue.size": 3,
+		"requests": 1.5
+	}`, string(doc))
+}
+
+func newTestMetricsExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchMetricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestTracesExporterConfig(fns...)(url))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, exporter.Shutdown(context.TODO()))
+	})
+	return exporter
+}
+
+// addGauge adds a gauge with a single data point at timestamp 1s,
+// attrs are key value pairs set as data point attributes.
+func addGauge(metrics pmetric.MetricSlice, name string, value int64, attrs ...string) {
+	m := metrics.AppendEmpty()
+	m.SetName(name)
+	dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1, 0)))
+	dp.SetIntValue(value)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		dp.Attributes().PutStr(attrs[i], attrs[i+1])
+	}
+}
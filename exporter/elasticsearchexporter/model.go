@@ -6,18 +6,25 @@ package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
 )
 
 type mappingModel interface {
 	encodeLog(pcommon.Resource, plog.LogRecord) ([]byte, error)
 	encodeSpan(pcommon.Resource, ptrace.Span) ([]byte, error)
+	encodeDataPoints(pcommon.Resource, *dataPointGroup) ([]byte, error)
 }
 
 // encodeModel tries to keep the event as close to the original open telemetry semantics as is.
@@ -85,6 +92,32 @@ func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([
 	return buf.Bytes(), err
 }
 
+// encodeDataPoints encodes the data points of a group into a single document.
+// The attributes and resource attributes are the dimensions of the document,
+// every metric is added as a field named after the metric.
+func (m *encodeModel) encodeDataPoints(resource pcommon.Resource, group *dataPointGroup) ([]byte, error) {
+	var document objmodel.Document
+	document.AddTimestamp("@timestamp", group.timestamp)
+	document.AddAttributes("Attributes", group.attributes)
+	document.AddAttributes("Resource", resource.Attributes())
+	document.AddString("Scope.name", group.scope.Name())
+	document.AddString("Scope.version", group.scope.Version())
+	document.AddAttributes("Scope.Attributes", group.scope.Attributes())
+	for _, metric := range group.metrics {
+		document.Add(metric.name, objmodel.ValueFromAttribute(metric.value))
+	}
+
+	if m.dedup {
+		document.Dedup()
+	} else if m.dedot {
+		document.Sort()
+	}
+
+	var buf bytes.Buffer
+	err := document.Serialize(&buf, m.dedot)
+	return buf.Bytes(), err
+}
+
 func spanLinksToString(spanLinkSlice ptrace.SpanLinkSlice) string {
 	linkArray := make([]map[string]interface{}, 0, spanLinkSlice.Len())
 	for i := 0; i < spanLinkSlice.Len(); i++ {
@@ -98,3 +131,211 @@ func spanLinksToString(spanLinkSlice ptrace.SpanLinkSlice) string {
 	linkArrayBytes, _ := json.Marshal(&linkArray)
 	return string(linkArrayBytes)
 }
+
+// dataPointGroup holds the values of the data points of a resource sharing the
+// same instrumentation scope, timestamp and attributes. Indexing them as a single
+// document keeps the number of documents low and lets time series data streams use
+// the attributes as dimensions.
+type dataPointGroup struct {
+	scope      pcommon.InstrumentationScope
+	timestamp  pcommon.Timestamp
+	attributes pcommon.Map
+	metrics    []metricValue
+}
+
+type metricValue struct {
+	name  string
+	value pcommon.Value
+}
+
+// Attributes returns the attributes shared by the data points of the group.
+func (g *dataPointGroup) Attributes() pcommon.Map {
+	return g.attributes
+}
+
+type dataPointGroupKey struct {
+	scopeName       string
+	scopeVersion    string
+	scopeAttributes [16]byte
+	timestamp       pcommon.Timestamp
+	attributes      [16]byte
+}
+
+type dataPointGroups struct {
+	groups []*dataPointGroup
+	index  map[dataPointGroupKey]*dataPointGroup
+}
+
+func (g *dataPointGroups) add(scope pcommon.InstrumentationScope, name string, timestamp pcommon.Timestamp, attributes pcommon.Map, value pcommon.Value) {
+	key := dataPointGroupKey{
+		scopeName:       scope.Name(),
+		scopeVersion:    scope.Version(),
+		scopeAttributes: pdatautil.MapHash(scope.Attributes()),
+		timestamp:       timestamp,
+		attributes:      pdatautil.MapHash(attributes),
+	}
+	group, ok := g.index[key]
+	if !ok {
+		group = &dataPointGroup{scope: scope, timestamp: timestamp, attributes: attributes}
+		g.index[key] = group
+		g.groups = append(g.groups, group)
+	}
+	group.metrics = append(group.metrics, metricValue{name: name, value: value})
+}
+
+var errSummaryNotSupported = errors.New("summary data points are not supported")
+
+// groupDataPoints groups the data points of all gauges, sums, histograms and
+// exponential histograms by instrumentation scope, timestamp and attributes.
+// Data points that can not be converted, including all summary data points,
+// are reported in the returned error and left out of the groups.
+func groupDataPoints(scopeMetrics pmetric.ScopeMetricsSlice) ([]*dataPointGroup, error) {
+	groups := dataPointGroups{index: make(map[dataPointGroupKey]*dataPointGroup)}
+	var errs []error
+	for i := 0; i < scopeMetrics.Len(); i++ {
+		scope := scopeMetrics.At(i).Scope()
+		metrics := scopeMetrics.At(i).Metrics()
+		for j := 0; j < metrics.Len(); j++ {
+			metric := metrics.At(j)
+			name := metric.Name()
+			//exhaustive:enforce
+			switch metric.Type() {
+			case pmetric.MetricTypeGauge:
+				addNumberDataPoints(&groups, scope, name, metric.Gauge().DataPoints())
+			case pmetric.MetricTypeSum:
+				addNumberDataPoints(&groups, scope, name, metric.Sum().DataPoints())
+			case pmetric.MetricTypeHistogram:
+				dps := metric.Histogram().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					dp := dps.At(k)
+					value, err := histogramValue(dp)
+					if err != nil {
+						errs = append(errs, fmt.Errorf("metric %q: %w", name, err))
+						continue
+					}
+					groups.add(scope, name, dp.Timestamp(), dp.Attributes(), value)
+				}
+			case pmetric.MetricTypeExponentialHistogram:
+				dps := metric.ExponentialHistogram().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					dp := dps.At(k)
+					groups.add(scope, name, dp.Timestamp(), dp.Attributes(), exponentialHistogramValue(dp))
+				}
+			case pmetric.MetricTypeSummary:
+				// Summaries have no equivalent Elasticsearch field type.
+				if n := metric.Summary().DataPoints().Len(); n > 0 {
+					errs = append(errs, fmt.Errorf("metric %q: dropped %d data points: %w", name, n, errSummaryNotSupported))
+				}
+			case pmetric.MetricTypeEmpty:
+			}
+		}
+	}
+	return groups.groups, multierr.Combine(errs...)
+}
+
+func addNumberDataPoints(groups *dataPointGroups, scope pcommon.InstrumentationScope, name string, dps pmetric.NumberDataPointSlice) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		var value pcommon.Value
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			value = pcommon.NewValueInt(dp.IntValue())
+		case pmetric.NumberDataPointValueTypeDouble:
+			value = pcommon.NewValueDouble(dp.DoubleValue())
+		default:
+			continue
+		}
+		groups.add(scope, name, dp.Timestamp(), dp.Attributes(), value)
+	}
+}
+
+var errInvalidHistogram = errors.New("invalid histogram data point")
+
+// histogramValue converts the data point into the `values` and `counts` arrays of the
+// Elasticsearch histogram field type, every bucket is represented by its midpoint.
+//
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/histogram.html
+func histogramValue(dp pmetric.HistogramDataPoint) (pcommon.Value, error) {
+	bucketCounts := dp.BucketCounts()
+	explicitBounds := dp.ExplicitBounds()
+	histogram := newHistogramBuilder()
+
+	if explicitBounds.Len() == 0 {
+		// A single bucket holds all values, its best estimate is their mean.
+		if dp.Count() > 0 && dp.HasSum() {
+			histogram.add(dp.Sum()/float64(dp.Count()), dp.Count())
+			return histogram.value, nil
+		}
+		return pcommon.Value{}, errInvalidHistogram
+	}
+	if bucketCounts.Len() != explicitBounds.Len()+1 {
+		return pcommon.Value{}, errInvalidHistogram
+	}
+
+	for i := 0; i < bucketCounts.Len(); i++ {
+		var value float64
+		switch i {
+		// (-infinity, explicit_bounds[i]]
+		case 0:
+			value = explicitBounds.At(i)
+			if value > 0 {
+				value /= 2
+			}
+		// (explicit_bounds[i-1], +infinity)
+		case bucketCounts.Len() - 1:
+			value = explicitBounds.At(i - 1)
+		// (explicit_bounds[i-1], explicit_bounds[i]]
+		default:
+			value = explicitBounds.At(i-1) + (explicitBounds.At(i)-explicitBounds.At(i-1))/2
+		}
+		histogram.add(value, bucketCounts.At(i))
+	}
+	return histogram.value, nil
+}
+
+// exponentialHistogramValue converts the data point like histogramValue,
+// values are sorted in ascending order as required by Elasticsearch.
+func exponentialHistogramValue(dp pmetric.ExponentialHistogramDataPoint) pcommon.Value {
+	base := math.Pow(2, math.Pow(2, -float64(dp.Scale())))
+	// midpoint returns the middle of the bucket (base^index, base^(index+1)].
+	midpoint := func(index int) float64 {
+		lower := math.Pow(base, float64(index))
+		return lower + (lower*base-lower)/2
+	}
+	histogram := newHistogramBuilder()
+
+	negative := dp.Negative()
+	for i := negative.BucketCounts().Len() - 1; i >= 0; i-- {
+		histogram.add(-midpoint(int(negative.Offset())+i), negative.BucketCounts().At(i))
+	}
+	histogram.add(0, dp.ZeroCount())
+	positive := dp.Positive()
+	for i := 0; i < positive.BucketCounts().Len(); i++ {
+		histogram.add(midpoint(int(positive.Offset())+i), positive.BucketCounts().At(i))
+	}
+	return histogram.value
+}
+
+type histogramBuilder struct {
+	value  pcommon.Value
+	values pcommon.Slice
+	counts pcommon.Slice
+}
+
+func newHistogramBuilder() *histogramBuilder {
+	value := pcommon.NewValueMap()
+	return &histogramBuilder{
+		value:  value,
+		values: value.Map().PutEmptySlice("values"),
+		counts: value.Map().PutEmptySlice("counts"),
+	}
+}
+
+// add appends a bucket, empty buckets are left out.
+func (h *histogramBuilder) add(value float64, count uint64) {
+	if count == 0 {
+		return
+	}
+	h.values.AppendEmpty().SetDouble(value)
+	h.counts.AppendEmpty().SetInt(int64(count))
+}
@@ -25,9 +25,24 @@ type Config struct {
 	// The name of the kafka topic to export to (default otlp_spans for traces, otlp_metrics for metrics)
 	Topic string `mapstructure:"topic"`
 
+	// The resource attribute whose value is the name of the kafka topic to export to,
+	// Topic is used when the attribute is missing.
+	TopicFromAttribute string `mapstructure:"topic_from_attribute"`
+
 	// Encoding of messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
 
+	// Whether to key trace messages by trace ID, so that all the spans of a trace end up in the same partition.
+	PartitionTracesByID bool `mapstructure:"partition_traces_by_id"`
+
+	// Whether to key metric messages by series, the identity of a series being made of
+	// its resource attributes, its metric name and its data point attributes.
+	PartitionMetricsBySeries bool `mapstructure:"partition_metrics_by_series"`
+
+	// The resource attribute whose value is the key of the messages. It does not apply to
+	// traces partitioned by trace ID nor to metrics partitioned by series.
+	PartitionKeyAttribute string `mapstructure:"partition_key_attribute"`
+
 	// Metadata is the namespace for metadata management properties used by the
 	// Client, and shared by the Producer/Consumer.
 	Metadata Metadata `mapstructure:"metadata"`
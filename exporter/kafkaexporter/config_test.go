@@ -47,9 +47,13 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 2,
 					QueueSize:    10,
 				},
-				Topic:    "spans",
-				Encoding: "otlp_proto",
-				Brokers:  []string{"foo:123", "bar:456"},
+				Topic:                    "spans",
+				TopicFromAttribute:       "tenant",
+				Encoding:                 "otlp_proto",
+				PartitionTracesByID:      true,
+				PartitionMetricsBySeries: true,
+				PartitionKeyAttribute:    "host.name",
+				Brokers:                  []string{"foo:123", "bar:456"},
 				Authentication: Authentication{
 					PlainText: &PlainTextConfig{
 						Username: "jdoe",
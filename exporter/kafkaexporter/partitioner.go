@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"encoding/hex"
+	"hash/fnv"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+// partitioner splits the data to export into partitions, each of them
+// being marshaled into messages sharing the same topic and key.
+// The zero value sends everything to the default topic without a key.
+type partitioner struct {
+	topicAttribute  string
+	keyAttribute    string
+	tracesByID      bool
+	metricsBySeries bool
+}
+
+func newPartitioner(config Config) partitioner {
+	return partitioner{
+		topicAttribute:  config.TopicFromAttribute,
+		keyAttribute:    config.PartitionKeyAttribute,
+		tracesByID:      config.PartitionTracesByID,
+		metricsBySeries: config.PartitionMetricsBySeries,
+	}
+}
+
+// partition holds the data sent to a topic with a given key, a nil key
+// leaves the choice of the kafka partition to the producer.
+type partition[T any] struct {
+	topic string
+	key   []byte
+	data  T
+}
+
+// partitions keeps track of the partitions in the order they are created.
+type partitions[T any] struct {
+	list    []*partition[T]
+	index   map[[2]string]*partition[T]
+	newData func() T
+}
+
+func newPartitions[T any](newData func() T) *partitions[T] {
+	return &partitions[T]{
+		index:   make(map[[2]string]*partition[T]),
+		newData: newData,
+	}
+}
+
+func (ps *partitions[T]) get(topic string, key []byte) T {
+	id := [2]string{topic, string(key)}
+	p, ok := ps.index[id]
+	if !ok {
+		p = &partition[T]{topic: topic, key: key, data: ps.newData()}
+		ps.index[id] = p
+		ps.list = append(ps.list, p)
+	}
+	return p.data
+}
+
+// marshalPartitions marshals every partition into messages, keyed with the key of the
+// partition unless the marshaler already set one.
+func marshalPartitions[T any](list []*partition[T], marshal func(T, string) ([]*sarama.ProducerMessage, error)) ([]*sarama.ProducerMessage, error) {
+	var messages []*sarama.ProducerMessage
+	for _, p := range list {
+		msgs, err := marshal(p.data, p.topic)
+		if err != nil {
+			return nil, err
+		}
+		if p.key != nil {
+			for _, msg := range msgs {
+				if msg.Key == nil {
+					msg.Key = sarama.ByteEncoder(p.key)
+				}
+			}
+		}
+		messages = append(messages, msgs...)
+	}
+	return messages, nil
+}
+
+func (p partitioner) topicOf(res pcommon.Resource, defaultTopic string) string {
+	if p.topicAttribute == "" {
+		return defaultTopic
+	}
+	if topic, ok := res.Attributes().Get(p.topicAttribute); ok && topic.AsString() != "" {
+		return topic.AsString()
+	}
+	return defaultTopic
+}
+
+func (p partitioner) keyOf(res pcommon.Resource) []byte {
+	if p.keyAttribute == "" {
+		return nil
+	}
+	if key, ok := res.Attributes().Get(p.keyAttribute); ok {
+		return []byte(key.AsString())
+	}
+	return nil
+}
+
+func (p partitioner) splitsResources() bool {
+	return p.topicAttribute != "" || p.keyAttribute != ""
+}
+
+func (p partitioner) traces(td ptrace.Traces, topic string) []*partition[ptrace.Traces] {
+	if !p.tracesByID && !p.splitsResources() {
+		return []*partition[ptrace.Traces]{{topic: topic, data: td}}
+	}
+	ps := newPartitions(ptrace.NewTraces)
+	if p.tracesByID {
+		for _, trace := range batchpersignal.SplitTraces(td) {
+			rs := trace.ResourceSpans().At(0)
+			traceID := rs.ScopeSpans().At(0).Spans().At(0).TraceID()
+			key := []byte(hex.EncodeToString(traceID[:]))
+			rs.MoveTo(ps.get(p.topicOf(rs.Resource(), topic), key).ResourceSpans().AppendEmpty())
+		}
+		return ps.list
+	}
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		rs.CopyTo(ps.get(p.topicOf(rs.Resource(), topic), p.keyOf(rs.Resource())).ResourceSpans().AppendEmpty())
+	}
+	return ps.list
+}
+
+func (p partitioner) logs(ld plog.Logs, topic string) []*partition[plog.Logs] {
+	if !p.splitsResources() {
+		return []*partition[plog.Logs]{{topic: topic, data: ld}}
+	}
+	ps := newPartitions(plog.NewLogs)
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		rl.CopyTo(ps.get(p.topicOf(rl.Resource(), topic), p.keyOf(rl.Resource())).ResourceLogs().AppendEmpty())
+	}
+	return ps.list
+}
+
+func (p partitioner) metrics(md pmetric.Metrics, topic string) []*partition[pmetric.Metrics] {
+	if !p.metricsBySeries && !p.splitsResources() {
+		return []*partition[pmetric.Metrics]{{topic: topic, data: md}}
+	}
+	if !p.metricsBySeries {
+		ps := newPartitions(pmetric.NewMetrics)
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			rm := md.ResourceMetrics().At(i)
+			rm.CopyTo(ps.get(p.topicOf(rm.Resource(), topic), p.keyOf(rm.Resource())).ResourceMetrics().AppendEmpty())
+		}
+		return ps.list
+	}
+
+	ps := newPartitions(pdatautil.NewMetricsBatch)
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		rmTopic := p.topicOf(rm.Resource(), topic)
+		resourceHash := pdatautil.MapHash(rm.Resource().Attributes())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+				// the data points of a metric are grouped by series
+				var keys []string
+				dataPointsByKey := make(map[string]map[int]bool)
+				pdatautil.ForEachDataPointAttributes(m, func(idx int, attrs pcommon.Map) {
+					key := seriesKey(resourceHash, m.Name(), attrs)
+					if _, ok := dataPointsByKey[key]; !ok {
+						dataPointsByKey[key] = make(map[int]bool)
+						keys = append(keys, key)
+					}
+					dataPointsByKey[key][idx] = true
+				})
+				for _, key := range keys {
+					dest := ps.get(rmTopic, []byte(key)).AppendMetric(i, rm, j, sm)
+					m.CopyTo(dest)
+					keep := dataPointsByKey[key]
+					pdatautil.RemoveDataPointsIf(dest, func(idx int) bool {
+						return !keep[idx]
+					})
+				}
+			}
+		}
+	}
+
+	list := make([]*partition[pmetric.Metrics], 0, len(ps.list))
+	for _, batch := range ps.list {
+		list = append(list, &partition[pmetric.Metrics]{topic: batch.topic, key: batch.key, data: batch.data.Metrics()})
+	}
+	return list
+}
+
+// seriesKey identifies a series from the hash of its resource attributes,
+// its metric name and its data point attributes.
+func seriesKey(resourceHash [16]byte, name string, attrs pcommon.Map) string {
+	attrsHash := pdatautil.MapHash(attrs)
+	h := fnv.New128a()
+	_, _ = h.Write(resourceHash[:])
+	_, _ = h.Write([]byte(name))
+	_, _ = h.Write(attrsHash[:])
+	return hex.EncodeToString(h.Sum(nil))
+}
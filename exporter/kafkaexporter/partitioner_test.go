@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestPartitionerDisabled(t *testing.T) {
+	p := newPartitioner(Config{})
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+
+	parts := p.traces(td, "spans")
+	require.Len(t, parts, 1)
+	assert.Equal(t, "spans", parts[0].topic)
+	assert.Nil(t, parts[0].key)
+	assert.Equal(t, td, parts[0].data)
+}
+
+func TestPartitionTracesByID(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, tenant := range []string{"a", "b"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("tenant", tenant)
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for _, id := range []byte{1, 2} {
+			span := spans.AppendEmpty()
+			span.SetTraceID(pcommon.TraceID{id})
+			span.SetName(tenant)
+		}
+	}
+
+	p := newPartitioner(Config{PartitionTracesByID: true, TopicFromAttribute: "tenant"})
+	parts := p.traces(td, "spans")
+
+	require.Len(t, parts, 4)
+	var ids []string
+	for _, part := range parts {
+		assert.Equal(t, 1, part.data.SpanCount())
+		rs := part.data.ResourceSpans().At(0)
+		tenant, _ := rs.Resource().Attributes().Get("tenant")
+		assert.Equal(t, tenant.Str(), part.topic)
+		assert.Equal(t, tenant.Str(), rs.ScopeSpans().At(0).Spans().At(0).Name())
+		ids = append(ids, part.topic+":"+string(part.key))
+	}
+	assert.ElementsMatch(t, []string{
+		"a:01000000000000000000000000000000",
+		"a:02000000000000000000000000000000",
+		"b:01000000000000000000000000000000",
+		"b:02000000000000000000000000000000",
+	}, ids)
+	// the input is left untouched
+	assert.Equal(t, 4, td.SpanCount())
+}
+
+func TestPartitionLogsByAttribute(t *testing.T) {
+	ld := plog.NewLogs()
+	for _, host := range []string{"host-1", "host-2", "host-1", ""} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		if host != "" {
+			rl.Resource().Attributes().PutStr("host.name", host)
+		}
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	}
+
+	p := newPartitioner(Config{PartitionKeyAttribute: "host.name"})
+	parts := p.logs(ld, "logs")
+
+	require.Len(t, parts, 3)
+	assert.Equal(t, []byte("host-1"), parts[0].key)
+	assert.Equal(t, 2, parts[0].data.LogRecordCount())
+	assert.Equal(t, []byte("host-2"), parts[1].key)
+	assert.Equal(t, 1, parts[1].data.LogRecordCount())
+	assert.Nil(t, parts[2].key)
+	assert.Equal(t, 1, parts[2].data.LogRecordCount())
+	for _, part := range parts {
+		assert.Equal(t, "logs", part.topic)
+	}
+}
+
+func TestPartitionMetricsBySeries(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	dps := sm.Metrics().AppendEmpty()
+	dps.SetName("requests")
+	sum := dps.SetEmptySum()
+	for _, code := range []string{"200", "500", "200"} {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("code", code)
+	}
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("memory")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+
+	p := newPartitioner(Config{PartitionMetricsBySeries: true, PartitionKeyAttribute: "service.name"})
+	parts := p.metrics(md, "metrics")
+
+	require.Len(t, parts, 3)
+	assert.Equal(t, 2, parts[0].data.DataPointCount())
+	assert.Equal(t, 1, parts[1].data.DataPointCount())
+	assert.Equal(t, 1, parts[2].data.DataPointCount())
+	keys := map[string]bool{}
+	for _, part := range parts {
+		assert.Equal(t, "metrics", part.topic)
+		assert.Len(t, part.key, 32)
+		keys[string(part.key)] = true
+		rm := part.data.ResourceMetrics().At(0)
+		assert.Equal(t, 1, part.data.ResourceMetrics().Len())
+		assert.Equal(t, 1, rm.ScopeMetrics().Len())
+		assert.Equal(t, "scope", rm.ScopeMetrics().At(0).Scope().Name())
+	}
+	assert.Len(t, keys, 3)
+
+	// the key of a series does not depend on the other series of the batch
+	again := p.metrics(md, "metrics")
+	assert.Equal(t, parts[0].key, again[0].key)
+}
+
+func TestPartitionMetricsByAttribute(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, tenant := range []string{"a", "b"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("tenant", tenant)
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	}
+
+	p := newPartitioner(Config{TopicFromAttribute: "tenant", PartitionKeyAttribute: "tenant"})
+	parts := p.metrics(md, "metrics")
+
+	require.Len(t, parts, 2)
+	assert.Equal(t, "a", parts[0].topic)
+	assert.Equal(t, []byte("a"), parts[0].key)
+	assert.Equal(t, "b", parts[1].topic)
+	assert.Equal(t, []byte("b"), parts[1].key)
+}
+
+func TestMarshalPartitionsKeepsMarshalerKey(t *testing.T) {
+	parts := []*partition[ptrace.Traces]{{topic: "spans", key: []byte("key"), data: ptrace.NewTraces()}}
+	messages, err := marshalPartitions(parts, func(_ ptrace.Traces, topic string) ([]*sarama.ProducerMessage, error) {
+		return []*sarama.ProducerMessage{
+			{Topic: topic},
+			{Topic: topic, Key: sarama.StringEncoder("marshaler")},
+		}, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, sarama.ByteEncoder("key"), messages[0].Key)
+	assert.Equal(t, sarama.StringEncoder("marshaler"), messages[1].Key)
+}
+
+func TestTracesPusher_partitioned(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	for _, id := range []string{"01000000000000000000000000000000", "02000000000000000000000000000000"} {
+		key := id
+		producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+			assert.Equal(t, "spans", msg.Topic)
+			assert.Equal(t, sarama.ByteEncoder(key), msg.Key)
+			return nil
+		})
+	}
+
+	p := kafkaTracesProducer{
+		producer:    producer,
+		topic:       "spans",
+		partitioner: newPartitioner(Config{PartitionTracesByID: true}),
+		marshaler:   newPdataTracesMarshaler(&ptrace.ProtoMarshaler{}, defaultEncoding),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetTraceID(pcommon.TraceID{1})
+	spans.AppendEmpty().SetTraceID(pcommon.TraceID{2})
+	require.NoError(t, p.tracesPusher(context.Background(), td))
+}
@@ -4,9 +4,12 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 type routingKey int
@@ -14,13 +17,33 @@ type routingKey int
 const (
 	traceIDRouting routingKey = iota
 	svcRouting
+	resourceRouting
+	metricRouting
 )
 
 // Config defines configuration for the exporter.
 type Config struct {
-	Protocol   Protocol         `mapstructure:"protocol"`
-	Resolver   ResolverSettings `mapstructure:"resolver"`
-	RoutingKey string           `mapstructure:"routing_key"`
+	Protocol Protocol         `mapstructure:"protocol"`
+	Resolver ResolverSettings `mapstructure:"resolver"`
+	// RoutingKey is the routing key of traces, which can be "traceID" or "service".
+	RoutingKey string `mapstructure:"routing_key"`
+	// MetricsRoutingKey is the routing key of metrics, which can be "service", "resource" or "metric".
+	MetricsRoutingKey string `mapstructure:"metrics_routing_key"`
+}
+
+// Validate checks that the routing keys are supported by their signal.
+func (c *Config) Validate() error {
+	switch c.RoutingKey {
+	case "", "traceID", "service":
+	default:
+		return fmt.Errorf("unsupported routing_key: %s", c.RoutingKey)
+	}
+	switch c.MetricsRoutingKey {
+	case "", "service", "resource", "metric":
+	default:
+		return fmt.Errorf("unsupported metrics_routing_key: %s", c.MetricsRoutingKey)
+	}
+	return nil
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -32,6 +55,7 @@ type Protocol struct {
 type ResolverSettings struct {
 	Static *StaticResolver `mapstructure:"static"`
 	DNS    *DNSResolver    `mapstructure:"dns"`
+	K8s    *K8sResolver    `mapstructure:"k8s"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -46,3 +70,12 @@ type DNSResolver struct {
 	Interval time.Duration `mapstructure:"interval"`
 	Timeout  time.Duration `mapstructure:"timeout"`
 }
+
+// K8sResolver defines the configuration for the resolver watching the EndpointSlices of a Kubernetes service
+type K8sResolver struct {
+	AuthType  k8sconfig.AuthType `mapstructure:"auth_type"`
+	Service   string             `mapstructure:"service"`
+	Namespace string             `mapstructure:"namespace"`
+	Ports     []int32            `mapstructure:"ports"`
+	Timeout   time.Duration      `mapstructure:"timeout"`
+}
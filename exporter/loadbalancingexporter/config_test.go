@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -23,3 +24,55 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, component.UnmarshalConfig(sub, cfg))
 	require.NotNil(t, cfg)
 }
+
+func TestLoadK8sResolverConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(typeStr, "4").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	assert.Equal(t, &K8sResolver{
+		Service:   "lb-backends",
+		Namespace: "observability",
+		Ports:     []int32{4317, 55690},
+	}, cfg.(*Config).Resolver.K8s)
+}
+
+func TestConfigValidate(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    string
+	}{
+		{
+			desc:   "defaults",
+			config: &Config{},
+		},
+		{
+			desc:   "routing keys of both signals",
+			config: &Config{RoutingKey: "traceID", MetricsRoutingKey: "metric"},
+		},
+		{
+			desc:   "metrics routing key for traces",
+			config: &Config{RoutingKey: "resource"},
+			err:    "unsupported routing_key: resource",
+		},
+		{
+			desc:   "traces routing key for metrics",
+			config: &Config{MetricsRoutingKey: "traceID"},
+			err:    "unsupported metrics_routing_key: traceID",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := component.ValidateConfig(tt.config)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -13,6 +13,9 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
 )
 
 const (
@@ -38,8 +41,8 @@ type loadBalancerImp struct {
 	logger *zap.Logger
 	host   component.Host
 
-	res  resolver
-	ring *hashRing
+	res  loadbalancing.Resolver
+	ring *loadbalancing.HashRing
 
 	componentFactory componentFactory
 	exporters        map[string]component.Component
@@ -52,14 +55,20 @@ type loadBalancerImp struct {
 func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, factory componentFactory) (*loadBalancerImp, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Resolver.DNS != nil && oCfg.Resolver.Static != nil {
+	var resolvers int
+	for _, configured := range []bool{oCfg.Resolver.Static != nil, oCfg.Resolver.DNS != nil, oCfg.Resolver.K8s != nil} {
+		if configured {
+			resolvers++
+		}
+	}
+	if resolvers > 1 {
 		return nil, errMultipleResolversProvided
 	}
 
-	var res resolver
+	var res loadbalancing.Resolver
 	if oCfg.Resolver.Static != nil {
 		var err error
-		res, err = newStaticResolver(oCfg.Resolver.Static.Hostnames)
+		res, err = loadbalancing.NewStaticResolver(oCfg.Resolver.Static.Hostnames, loadbalancing.WithObserver(newResolverObserver("static")))
 		if err != nil {
 			return nil, err
 		}
@@ -68,7 +77,25 @@ func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, facto
 		dnsLogger := params.Logger.With(zap.String("resolver", "dns"))
 
 		var err error
-		res, err = newDNSResolver(dnsLogger, oCfg.Resolver.DNS.Hostname, oCfg.Resolver.DNS.Port, oCfg.Resolver.DNS.Interval, oCfg.Resolver.DNS.Timeout)
+		res, err = loadbalancing.NewDNSResolver(dnsLogger, oCfg.Resolver.DNS.Hostname, oCfg.Resolver.DNS.Port, oCfg.Resolver.DNS.Interval, oCfg.Resolver.DNS.Timeout,
+			loadbalancing.WithObserver(newResolverObserver("dns")))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if oCfg.Resolver.K8s != nil {
+		k8sLogger := params.Logger.With(zap.String("resolver", "k8s"))
+
+		authType := oCfg.Resolver.K8s.AuthType
+		if authType == "" {
+			authType = k8sconfig.AuthTypeServiceAccount
+		}
+		client, err := k8sconfig.MakeClient(k8sconfig.APIConfig{AuthType: authType})
+		if err != nil {
+			return nil, err
+		}
+		res, err = newK8sResolver(client, k8sLogger, oCfg.Resolver.K8s.Service, oCfg.Resolver.K8s.Namespace, oCfg.Resolver.K8s.Ports, oCfg.Resolver.K8s.Timeout)
 		if err != nil {
 			return nil, err
 		}
@@ -87,15 +114,15 @@ func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, facto
 }
 
 func (lb *loadBalancerImp) Start(ctx context.Context, host component.Host) error {
-	lb.res.onChange(lb.onBackendChanges)
+	lb.res.OnChange(lb.onBackendChanges)
 	lb.host = host
-	return lb.res.start(ctx)
+	return lb.res.Start(ctx)
 }
 
 func (lb *loadBalancerImp) onBackendChanges(resolved []string) {
-	newRing := newHashRing(resolved)
+	newRing := loadbalancing.NewHashRing(resolved)
 
-	if !newRing.equal(lb.ring) {
+	if !newRing.Equal(lb.ring) {
 		lb.updateLock.Lock()
 		defer lb.updateLock.Unlock()
 
@@ -169,7 +196,7 @@ func (lb *loadBalancerImp) Endpoint(identifier []byte) string {
 	lb.updateLock.RLock()
 	defer lb.updateLock.RUnlock()
 
-	return lb.ring.endpointFor(identifier)
+	return lb.ring.EndpointFor(identifier)
 }
 
 func (lb *loadBalancerImp) Exporter(endpoint string) (component.Component, error) {
@@ -15,6 +15,9 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
 )
 
 func TestNewLoadBalancerNoResolver(t *testing.T) {
@@ -42,7 +45,7 @@ func TestNewLoadBalancerInvalidStaticResolver(t *testing.T) {
 
 	// verify
 	require.Nil(t, p)
-	require.Equal(t, errNoEndpoints, err)
+	require.Equal(t, loadbalancing.ErrNoEndpoints, err)
 }
 
 func TestNewLoadBalancerInvalidDNSResolver(t *testing.T) {
@@ -60,7 +63,28 @@ func TestNewLoadBalancerInvalidDNSResolver(t *testing.T) {
 
 	// verify
 	require.Nil(t, p)
-	require.Equal(t, errNoHostname, err)
+	require.Equal(t, loadbalancing.ErrNoHostname, err)
+}
+
+func TestNewLoadBalancerInvalidK8sResolver(t *testing.T) {
+	// prepare
+	t.Setenv("KUBERNETES_SERVICE_HOST", "localhost")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "6443")
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8s: &K8sResolver{
+				AuthType: k8sconfig.AuthTypeNone,
+				Service:  "",
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, nil)
+
+	// verify
+	require.Nil(t, p)
+	require.Equal(t, errNoSvc, err)
 }
 
 func TestLoadBalancerStart(t *testing.T) {
@@ -92,12 +116,8 @@ func TestWithDNSResolver(t *testing.T) {
 	require.NotNil(t, p)
 	require.NoError(t, err)
 
-	// test
-	res, ok := p.res.(*dnsResolver)
-
 	// verify
-	assert.NotNil(t, res)
-	assert.True(t, ok)
+	assert.NotNil(t, p.res)
 }
 
 func TestWithDNSResolverNoEndpoints(t *testing.T) {
@@ -123,6 +143,33 @@ func TestWithDNSResolverNoEndpoints(t *testing.T) {
 	assert.Equal(t, "", e)
 }
 
+func TestWithK8sResolver(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "localhost")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "6443")
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8s: &K8sResolver{
+				AuthType:  k8sconfig.AuthTypeNone,
+				Service:   "lb",
+				Namespace: "observability",
+				Ports:     []int32{55690},
+			},
+		},
+	}
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, nil)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res, ok := p.res.(*k8sResolver)
+
+	// verify
+	assert.True(t, ok)
+	assert.Equal(t, "lb", res.service)
+	assert.Equal(t, "observability", res.namespace)
+	assert.Equal(t, []int32{55690}, res.ports)
+}
+
 func TestMultipleResolvers(t *testing.T) {
 	cfg := &Config{
 		Resolver: ResolverSettings{
@@ -143,6 +190,26 @@ func TestMultipleResolvers(t *testing.T) {
 	assert.Equal(t, errMultipleResolversProvided, err)
 }
 
+func TestMultipleResolversWithK8s(t *testing.T) {
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{
+				Hostnames: []string{"endpoint-1", "endpoint-2"},
+			},
+			K8s: &K8sResolver{
+				Service: "lb",
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, nil)
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errMultipleResolversProvided, err)
+}
+
 func TestStartFailureStaticResolver(t *testing.T) {
 	// prepare
 	cfg := simpleConfig()
@@ -190,14 +257,15 @@ func TestOnBackendChanges(t *testing.T) {
 
 	// test
 	p.onBackendChanges([]string{"endpoint-1"})
-	require.Len(t, p.ring.items, defaultWeight)
+	require.True(t, p.ring.Equal(loadbalancing.NewHashRing([]string{"endpoint-1"})))
 
 	// this should resolve to two endpoints
 	endpoints := []string{"endpoint-1", "endpoint-2"}
 	p.onBackendChanges(endpoints)
 
 	// verify
-	assert.Len(t, p.ring.items, 2*defaultWeight)
+	assert.True(t, p.ring.Equal(loadbalancing.NewHashRing(endpoints)))
+	assert.Len(t, p.exporters, 2)
 }
 
 func TestRemoveExtraExporters(t *testing.T) {
@@ -353,7 +421,9 @@ func TestFailedExporterInRing(t *testing.T) {
 	delete(p.exporters, endpointWithPort(resEndpoint))
 
 	// sanity check
-	require.Contains(t, p.res.(*staticResolver).endpoints, resEndpoint)
+	resolved, err := p.res.Resolve(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, resolved, resEndpoint)
 
 	// test
 	// this trace ID will reach the endpoint-2 -- see the consistent hashing tests for more info
@@ -4,9 +4,13 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"context"
+
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
 )
 
 var (
@@ -15,6 +19,7 @@ var (
 	mBackendLatency = stats.Int64("loadbalancer_backend_latency", "Response latency in ms for the backends", stats.UnitMilliseconds)
 
 	endpointTagKey      = tag.MustNewKey("endpoint")
+	resolverTagKey      = tag.MustNewKey("resolver")
 	successTrueMutator  = tag.Upsert(tag.MustNewKey("success"), "true")
 	successFalseMutator = tag.Upsert(tag.MustNewKey("success"), "false")
 )
@@ -28,7 +33,7 @@ func MetricViews() []*view.View {
 			Description: mNumResolutions.Description(),
 			Aggregation: view.Count(),
 			TagKeys: []tag.Key{
-				tag.MustNewKey("resolver"),
+				resolverTagKey,
 				tag.MustNewKey("success"),
 			},
 		},
@@ -38,7 +43,7 @@ func MetricViews() []*view.View {
 			Description: mNumBackends.Description(),
 			Aggregation: view.LastValue(),
 			TagKeys: []tag.Key{
-				tag.MustNewKey("resolver"),
+				resolverTagKey,
 			},
 		},
 		{
@@ -47,7 +52,7 @@ func MetricViews() []*view.View {
 			Description: "Number of times the list of backends was updated",
 			Aggregation: view.Count(),
 			TagKeys: []tag.Key{
-				tag.MustNewKey("resolver"),
+				resolverTagKey,
 			},
 		},
 		{
@@ -71,3 +76,32 @@ func MetricViews() []*view.View {
 		},
 	}
 }
+
+var _ loadbalancing.Observer = (*resolverObserver)(nil)
+
+// resolverObserver records the resolutions of a resolver and the number of backends
+// it resolved, tagged with the kind of the resolver.
+type resolverObserver struct {
+	successTrueMutators  []tag.Mutator
+	successFalseMutators []tag.Mutator
+}
+
+func newResolverObserver(resolver string) *resolverObserver {
+	resolverMutator := tag.Upsert(resolverTagKey, resolver)
+	return &resolverObserver{
+		successTrueMutators:  []tag.Mutator{resolverMutator, successTrueMutator},
+		successFalseMutators: []tag.Mutator{resolverMutator, successFalseMutator},
+	}
+}
+
+func (o *resolverObserver) OnResolution(ctx context.Context, err error) {
+	if err != nil {
+		_ = stats.RecordWithTags(ctx, o.successFalseMutators, mNumResolutions.M(1))
+		return
+	}
+	_ = stats.RecordWithTags(ctx, o.successTrueMutators, mNumResolutions.M(1))
+}
+
+func (o *resolverObserver) OnEndpointsChange(ctx context.Context, endpoints []string) {
+	_ = stats.RecordWithTags(ctx, o.successTrueMutators, mNumBackends.M(int64(len(endpoints))))
+}
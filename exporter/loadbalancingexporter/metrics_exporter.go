@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+var _ exporter.Metrics = (*metricExporterImp)(nil)
+
+type metricExporterImp struct {
+	loadBalancer loadBalancer
+	routingKey   routingKey
+
+	stopped    bool
+	shutdownWg sync.WaitGroup
+}
+
+// Create new metrics exporter
+func newMetricsExporter(params exporter.CreateSettings, cfg component.Config) (*metricExporterImp, error) {
+	exporterFactory := otlpexporter.NewFactory()
+
+	lb, err := newLoadBalancer(params, cfg, func(ctx context.Context, endpoint string) (component.Component, error) {
+		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
+		return exporterFactory.CreateMetricsExporter(ctx, params, &oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metricExporter := metricExporterImp{loadBalancer: lb, routingKey: svcRouting}
+
+	switch cfg.(*Config).MetricsRoutingKey {
+	case "service", "":
+	case "resource":
+		metricExporter.routingKey = resourceRouting
+	case "metric":
+		metricExporter.routingKey = metricRouting
+	default:
+		return nil, fmt.Errorf("unsupported metrics_routing_key: %s", cfg.(*Config).MetricsRoutingKey)
+	}
+	return &metricExporter, nil
+}
+
+func (e *metricExporterImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *metricExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.Start(ctx, host)
+}
+
+func (e *metricExporterImp) Shutdown(context.Context) error {
+	e.stopped = true
+	e.shutdownWg.Wait()
+	return nil
+}
+
+func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	batches, err := e.splitByEndpoint(md)
+	if err != nil {
+		return err
+	}
+
+	// Endpoints are sorted so that backends are always called in the same order.
+	endpoints := make([]string, 0, len(batches))
+	for endpoint := range batches {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	var errs error
+	for _, endpoint := range endpoints {
+		errs = multierr.Append(errs, e.consumeMetric(ctx, endpoint, batches[endpoint].Metrics()))
+	}
+	return errs
+}
+
+func (e *metricExporterImp) consumeMetric(ctx context.Context, endpoint string, md pmetric.Metrics) error {
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	me, ok := exp.(exporter.Metrics)
+	if !ok {
+		return fmt.Errorf("unable to export metrics, unexpected exporter type: expected exporter.Metrics but got %T", exp)
+	}
+
+	start := time.Now()
+	err = me.ConsumeMetrics(ctx, md)
+	duration := time.Since(start)
+
+	if err == nil {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successTrueMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successFalseMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	}
+	return err
+}
+
+// splitByEndpoint distributes the content of md among the endpoints
+// owning the routing identifiers of its resources or data points.
+func (e *metricExporterImp) splitByEndpoint(md pmetric.Metrics) (map[string]*pdatautil.MetricsBatch, error) {
+	rms := md.ResourceMetrics()
+	if rms.Len() == 0 {
+		return nil, errors.New("empty resource metrics")
+	}
+
+	batches := make(map[string]*pdatautil.MetricsBatch)
+	batchFor := func(endpoint string) *pdatautil.MetricsBatch {
+		b, ok := batches[endpoint]
+		if !ok {
+			b = pdatautil.NewMetricsBatch()
+			batches[endpoint] = b
+		}
+		return b
+	}
+
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if e.routingKey != metricRouting {
+			rid, err := routingIdentifierFromResource(rm.Resource(), e.routingKey)
+			if err != nil {
+				return nil, err
+			}
+			rm.CopyTo(batchFor(e.loadBalancer.Endpoint(rid)).Metrics().ResourceMetrics().AppendEmpty())
+			continue
+		}
+
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+				// Each data point is a distinct series, its endpoint is
+				// computed from the metric name and its attributes.
+				var endpoints []string
+				dataPointsByEndpoint := make(map[string]map[int]bool)
+				pdatautil.ForEachDataPointAttributes(m, func(idx int, attrs pcommon.Map) {
+					endpoint := e.loadBalancer.Endpoint(routingIdentifierFromSeries(m.Name(), attrs))
+					if _, ok := dataPointsByEndpoint[endpoint]; !ok {
+						dataPointsByEndpoint[endpoint] = make(map[int]bool)
+						endpoints = append(endpoints, endpoint)
+					}
+					dataPointsByEndpoint[endpoint][idx] = true
+				})
+				for _, endpoint := range endpoints {
+					dest := batchFor(endpoint).AppendMetric(i, rm, j, sm)
+					m.CopyTo(dest)
+					keep := dataPointsByEndpoint[endpoint]
+					pdatautil.RemoveDataPointsIf(dest, func(idx int) bool {
+						return !keep[idx]
+					})
+				}
+			}
+		}
+	}
+	return batches, nil
+}
+
+func routingIdentifierFromResource(res pcommon.Resource, key routingKey) ([]byte, error) {
+	if key == resourceRouting {
+		hash := pdatautil.MapHash(res.Attributes())
+		return hash[:], nil
+	}
+	svc, ok := res.Attributes().Get(conventions.AttributeServiceName)
+	if !ok {
+		return nil, errors.New("unable to get service name")
+	}
+	return []byte(svc.Str()), nil
+}
+
+func routingIdentifierFromSeries(name string, attrs pcommon.Map) []byte {
+	hash := pdatautil.MapHash(attrs)
+	return append([]byte(name), hash[:]...)
+}
@@ -0,0 +1,366 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
+)
+
+func TestNewMetricsExporter(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    error
+	}{
+		{
+			"simple",
+			simpleConfig(),
+			nil,
+		},
+		{
+			"empty",
+			&Config{},
+			errNoResolver,
+		},
+		{
+			"unsupported routing key",
+			&Config{
+				Resolver:          ResolverSettings{Static: &StaticResolver{Hostnames: []string{"endpoint-1"}}},
+				MetricsRoutingKey: "traceID",
+			},
+			errors.New("unsupported metrics_routing_key: traceID"),
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			_, err := newMetricsExporter(exportertest.NewNopCreateSettings(), tt.config)
+
+			// verify
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestMetricsExporterRoutingKey(t *testing.T) {
+	for _, tt := range []struct {
+		routingKey string
+		expected   routingKey
+	}{
+		{"", svcRouting},
+		{"service", svcRouting},
+		{"resource", resourceRouting},
+		{"metric", metricRouting},
+	} {
+		t.Run(tt.routingKey, func(t *testing.T) {
+			cfg := simpleConfig()
+			cfg.MetricsRoutingKey = tt.routingKey
+			p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, p.routingKey)
+		})
+	}
+}
+
+func TestMetricsExporterShutdown(t *testing.T) {
+	p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res := p.Shutdown(context.Background())
+
+	// verify
+	assert.Nil(t, res)
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	p, sink := newTestMetricsExporter(t, "service", []string{"endpoint-1"})
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetrics("svc-1", "svc-2"))
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, 2, sink.dataPointCount("endpoint-1:4317"))
+}
+
+func TestConsumeMetricsWithoutServiceName(t *testing.T) {
+	p, _ := newTestMetricsExporter(t, "service", []string{"endpoint-1"})
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), md)
+
+	// verify
+	assert.EqualError(t, res, "unable to get service name")
+}
+
+func TestConsumeMetricsUnexpectedExporterType(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetrics("svc-1"))
+
+	// verify
+	assert.EqualError(t, res, fmt.Sprintf("unable to export metrics, unexpected exporter type: expected exporter.Metrics but got %T", newNopMockExporter()))
+}
+
+func TestConsumeMetricsEmpty(t *testing.T) {
+	p, _ := newTestMetricsExporter(t, "service", []string{"endpoint-1"})
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), pmetric.NewMetrics())
+
+	// verify
+	assert.EqualError(t, res, "empty resource metrics")
+}
+
+func TestConsumeMetricsRoutesResourcesConsistently(t *testing.T) {
+	for _, routingKey := range []string{"service", "resource"} {
+		t.Run(routingKey, func(t *testing.T) {
+			endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3"}
+			p, sink := newTestMetricsExporter(t, routingKey, endpoints)
+
+			var services []string
+			for i := 0; i < 30; i++ {
+				services = append(services, fmt.Sprintf("svc-%d", i))
+			}
+			md := simpleMetrics(services...)
+
+			// test
+			require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+			require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+			// verify
+			owners := sink.resourceOwners()
+			assert.Len(t, owners, len(services))
+			for svc, endpoints := range owners {
+				assert.Len(t, endpoints, 1, "service %s was sent to more than one endpoint", svc)
+			}
+			assert.Greater(t, len(sink.endpoints()), 1)
+		})
+	}
+}
+
+func TestConsumeMetricsRoutesSeriesConsistently(t *testing.T) {
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3"}
+	p, sink := newTestMetricsExporter(t, "metric", endpoints)
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, "svc")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	for _, name := range []string{"requests", "errors"} {
+		m := sm.Metrics().AppendEmpty()
+		m.SetName(name)
+		sum := m.SetEmptySum()
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for i := 0; i < 20; i++ {
+			dp := sum.DataPoints().AppendEmpty()
+			dp.Attributes().PutInt("instance", int64(i))
+			dp.SetIntValue(int64(i))
+		}
+	}
+
+	// test
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+	// verify
+	owners := sink.seriesOwners()
+	assert.Len(t, owners, 40)
+	for series, endpoints := range owners {
+		assert.Len(t, endpoints, 1, "series %s was sent to more than one endpoint", series)
+	}
+	assert.Greater(t, len(sink.endpoints()), 1)
+
+	total := 0
+	for _, endpoint := range sink.endpoints() {
+		total += sink.dataPointCount(endpoint)
+		for _, batch := range sink.received[endpoint] {
+			// resources, scopes and metrics are not duplicated for every data point
+			require.Equal(t, 1, batch.ResourceMetrics().Len())
+			require.Equal(t, 1, batch.ResourceMetrics().At(0).ScopeMetrics().Len())
+			scope := batch.ResourceMetrics().At(0).ScopeMetrics().At(0)
+			assert.Equal(t, "scope", scope.Scope().Name())
+			assert.LessOrEqual(t, scope.Metrics().Len(), 2)
+		}
+	}
+	assert.Equal(t, 80, total)
+}
+
+func newTestMetricsExporter(t *testing.T, routingKey string, endpoints []string) (*metricExporterImp, *metricsSink) {
+	cfg := simpleConfig()
+	cfg.MetricsRoutingKey = routingKey
+
+	sink := &metricsSink{received: map[string][]pmetric.Metrics{}}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newMockMetricsExporter(func(ctx context.Context, md pmetric.Metrics) error {
+			sink.consume(endpoint, md)
+			return nil
+		}), nil
+	}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return endpoints, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	})
+	return p, sink
+}
+
+func simpleMetrics(services ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, svc := range services {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, svc)
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("requests")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	}
+	return md
+}
+
+type metricsSink struct {
+	mu       sync.Mutex
+	received map[string][]pmetric.Metrics
+}
+
+func (s *metricsSink) consume(endpoint string, md pmetric.Metrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.received[endpoint] = append(s.received[endpoint], md)
+}
+
+func (s *metricsSink) endpoints() []string {
+	var out []string
+	for endpoint := range s.received {
+		out = append(out, endpoint)
+	}
+	return out
+}
+
+func (s *metricsSink) dataPointCount(endpoint string) int {
+	count := 0
+	for _, md := range s.received[endpoint] {
+		count += md.DataPointCount()
+	}
+	return count
+}
+
+// resourceOwners returns the endpoints that received each service.
+func (s *metricsSink) resourceOwners() map[string]map[string]bool {
+	owners := map[string]map[string]bool{}
+	for endpoint, batches := range s.received {
+		for _, md := range batches {
+			for i := 0; i < md.ResourceMetrics().Len(); i++ {
+				svc, _ := md.ResourceMetrics().At(i).Resource().Attributes().Get(conventions.AttributeServiceName)
+				if owners[svc.Str()] == nil {
+					owners[svc.Str()] = map[string]bool{}
+				}
+				owners[svc.Str()][endpoint] = true
+			}
+		}
+	}
+	return owners
+}
+
+// seriesOwners returns the endpoints that received each series of sum data points.
+func (s *metricsSink) seriesOwners() map[string]map[string]bool {
+	owners := map[string]map[string]bool{}
+	for endpoint, batches := range s.received {
+		for _, md := range batches {
+			ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			for i := 0; i < ms.Len(); i++ {
+				dps := ms.At(i).Sum().DataPoints()
+				for j := 0; j < dps.Len(); j++ {
+					instance, _ := dps.At(j).Attributes().Get("instance")
+					series := fmt.Sprintf("%s/%d", ms.At(i).Name(), instance.Int())
+					if owners[series] == nil {
+						owners[series] = map[string]bool{}
+					}
+					owners[series][endpoint] = true
+				}
+			}
+		}
+	}
+	return owners
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error
+}
+
+func newMockMetricsExporter(consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error) exporter.Metrics {
+	return &mockMetricsExporter{
+		Component:        mockComponent{},
+		consumeMetricsFn: consumeMetricsFn,
+	}
+}
+
+func (e *mockMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.consumeMetricsFn == nil {
+		return nil
+	}
+	return e.consumeMetricsFn(ctx, md)
+}
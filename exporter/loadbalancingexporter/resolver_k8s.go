@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
+)
+
+var _ loadbalancing.Resolver = (*k8sResolver)(nil)
+
+const (
+	defaultK8sNamespace = "default"
+	defaultK8sPort      = 4317
+	defaultK8sTimeout   = time.Second
+)
+
+var (
+	errNoSvc = errors.New("no service specified to resolve the backends")
+)
+
+// k8sResolver watches the EndpointSlices of a Kubernetes service, the backends are
+// the addresses of its ready endpoints combined with each of the configured ports,
+// or with the ports of their EndpointSlice when no ports are configured.
+type k8sResolver struct {
+	logger *zap.Logger
+
+	client    kubernetes.Interface
+	service   string
+	namespace string
+	ports     []int32
+	timeout   time.Duration
+	observer  *resolverObserver
+
+	informer cache.SharedIndexInformer
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan struct{}
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+func newK8sResolver(client kubernetes.Interface, logger *zap.Logger, service string, namespace string, ports []int32, timeout time.Duration) (*k8sResolver, error) {
+	if len(service) == 0 {
+		return nil, errNoSvc
+	}
+	if namespace == "" {
+		namespace = defaultK8sNamespace
+	}
+	if timeout == 0 {
+		timeout = defaultK8sTimeout
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(client, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, service)
+		}),
+	)
+
+	return &k8sResolver{
+		logger:    logger,
+		client:    client,
+		service:   service,
+		namespace: namespace,
+		ports:     ports,
+		timeout:   timeout,
+		observer:  newResolverObserver("k8s"),
+		informer:  factory.Discovery().V1().EndpointSlices().Informer(),
+		stopCh:    make(chan struct{}),
+	}, nil
+}
+
+func (r *k8sResolver) Start(ctx context.Context) error {
+	onEvent := func(interface{}) {
+		if _, err := r.Resolve(context.Background()); err != nil {
+			r.logger.Warn("failed to resolve", zap.Error(err))
+		}
+	}
+	if _, err := r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    onEvent,
+		UpdateFunc: func(_, obj interface{}) { onEvent(obj) },
+		DeleteFunc: onEvent,
+	}); err != nil {
+		return err
+	}
+
+	r.shutdownWg.Add(1)
+	go func() {
+		defer r.shutdownWg.Done()
+		r.informer.Run(r.stopCh)
+	}()
+
+	// Backends found after the timeout are still added through the event handlers.
+	syncCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), r.informer.HasSynced) {
+		r.logger.Warn("timed out waiting for the endpoint slices to be synced",
+			zap.String("service", r.service), zap.String("namespace", r.namespace))
+	}
+	if _, err := r.Resolve(ctx); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+
+	r.logger.Debug("Kubernetes resolver started",
+		zap.String("service", r.service), zap.String("namespace", r.namespace),
+		zap.Int32s("ports", r.ports))
+	return nil
+}
+
+func (r *k8sResolver) Shutdown(ctx context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *k8sResolver) Resolve(ctx context.Context) ([]string, error) {
+	r.shutdownWg.Add(1)
+	defer r.shutdownWg.Done()
+
+	seen := map[string]bool{}
+	var backends []string
+	for _, obj := range r.informer.GetStore().List() {
+		slice, ok := obj.(*discoveryv1.EndpointSlice)
+		if !ok {
+			continue
+		}
+		ports := r.ports
+		if len(ports) == 0 {
+			ports = slicePorts(slice)
+		}
+		for _, endpoint := range slice.Endpoints {
+			// endpoints without a ready condition are considered ready
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			for _, address := range endpoint.Addresses {
+				for _, port := range ports {
+					backend := net.JoinHostPort(address, strconv.Itoa(int(port)))
+					if !seen[backend] {
+						seen[backend] = true
+						backends = append(backends, backend)
+					}
+				}
+			}
+		}
+	}
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	r.observer.OnResolution(ctx, nil)
+
+	r.updateLock.Lock()
+	if equalStringSlice(r.endpoints, backends) {
+		r.updateLock.Unlock()
+		return backends, nil
+	}
+
+	// the list has changed!
+	r.endpoints = backends
+	r.updateLock.Unlock()
+	r.observer.OnEndpointsChange(ctx, backends)
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(backends)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return backends, nil
+}
+
+// slicePorts returns the ports exposed by the endpoints of the slice, falling back
+// to the default port when the slice doesn't list any.
+func slicePorts(slice *discoveryv1.EndpointSlice) []int32 {
+	var ports []int32
+	for _, port := range slice.Ports {
+		if port.Port != nil {
+			ports = append(ports, *port.Port)
+		}
+	}
+	if len(ports) == 0 {
+		ports = []int32{defaultK8sPort}
+	}
+	return ports
+}
+
+func (r *k8sResolver) OnChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
+
+func equalStringSlice(source, candidate []string) bool {
+	if len(source) != len(candidate) {
+		return false
+	}
+	for i := range source {
+		if source[i] != candidate[i] {
+			return false
+		}
+	}
+	return true
+}
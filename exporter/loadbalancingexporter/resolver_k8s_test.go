@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewK8sResolver(t *testing.T) {
+	for _, tt := range []struct {
+		desc      string
+		service   string
+		namespace string
+		ports     []int32
+		expected  *k8sResolver
+		err       error
+	}{
+		{
+			desc:    "defaults",
+			service: "lb",
+			expected: &k8sResolver{
+				service:   "lb",
+				namespace: "default",
+				timeout:   defaultK8sTimeout,
+			},
+		},
+		{
+			desc:      "port overrides",
+			service:   "lb",
+			namespace: "observability",
+			ports:     []int32{4317, 55690},
+			expected: &k8sResolver{
+				service:   "lb",
+				namespace: "observability",
+				ports:     []int32{4317, 55690},
+				timeout:   defaultK8sTimeout,
+			},
+		},
+		{
+			desc: "no service",
+			err:  errNoSvc,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), tt.service, tt.namespace, tt.ports, 0)
+			require.Equal(t, tt.err, err)
+			if tt.expected == nil {
+				return
+			}
+			assert.Equal(t, tt.expected.service, res.service)
+			assert.Equal(t, tt.expected.namespace, res.namespace)
+			assert.Equal(t, tt.expected.ports, res.ports)
+			assert.Equal(t, tt.expected.timeout, res.timeout)
+		})
+	}
+}
+
+func TestK8sResolve(t *testing.T) {
+	ready, notReady := true, false
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lb-abcde",
+			Namespace: "observability",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "lb"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready}},
+			{Addresses: []string{"10.0.0.1"}},
+			{Addresses: []string{"10.0.0.3"}, Conditions: discoveryv1.EndpointConditions{Ready: &notReady}},
+		},
+	}
+	otherService := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-abcde",
+			Namespace: "observability",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "other"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"10.0.1.1"}}},
+	}
+	otherNamespace := otherService.DeepCopy()
+	otherNamespace.Name = "lb-fghij"
+	otherNamespace.Namespace = "default"
+	otherNamespace.Labels = map[string]string{discoveryv1.LabelServiceName: "lb"}
+
+	client := fake.NewSimpleClientset(slice, otherService, otherNamespace)
+	res, err := newK8sResolver(client, zap.NewNop(), "lb", "observability", []int32{4317, 55690}, time.Second)
+	require.NoError(t, err)
+
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+	res.OnChange(func(endpoints []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = endpoints
+	})
+	lastReceived := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return received
+	}
+
+	require.NoError(t, res.Start(context.Background()))
+	defer func() {
+		require.NoError(t, res.Shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Equal(t, []string{
+		"10.0.0.1:4317",
+		"10.0.0.1:55690",
+		"10.0.0.2:4317",
+		"10.0.0.2:55690",
+	}, lastReceived())
+
+	// a pod becomes ready, another one goes away
+	updated := slice.DeepCopy()
+	updated.Endpoints = []discoveryv1.Endpoint{
+		{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready}},
+		{Addresses: []string{"10.0.0.3"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready}},
+	}
+	_, err = client.DiscoveryV1().EndpointSlices("observability").Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{
+			"10.0.0.2:4317",
+			"10.0.0.2:55690",
+			"10.0.0.3:4317",
+			"10.0.0.3:55690",
+		}, lastReceived())
+	}, time.Second, 10*time.Millisecond)
+
+	// the service has no endpoint slices left
+	err = client.DiscoveryV1().EndpointSlices("observability").Delete(context.Background(), slice.Name, metav1.DeleteOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(lastReceived()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestK8sResolveIPv6(t *testing.T) {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lb-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "lb"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv6,
+		Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"2001:db8::1"}}},
+	}
+	res, err := newK8sResolver(fake.NewSimpleClientset(slice), zap.NewNop(), "lb", "", nil, time.Second)
+	require.NoError(t, err)
+
+	require.NoError(t, res.Start(context.Background()))
+	defer func() {
+		require.NoError(t, res.Shutdown(context.Background()))
+	}()
+
+	// test
+	resolved, err := res.Resolve(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"[2001:db8::1]:4317"}, resolved)
+}
+
+func TestK8sResolveSlicePorts(t *testing.T) {
+	otlp, otlpHTTP := int32(14317), int32(4318)
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lb-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "lb"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.1"}}},
+		Ports: []discoveryv1.EndpointPort{
+			{Port: &otlp},
+			{Port: &otlpHTTP},
+		},
+	}
+	for _, tt := range []struct {
+		desc     string
+		ports    []int32
+		expected []string
+	}{
+		{
+			desc:     "slice ports",
+			expected: []string{"10.0.0.1:14317", "10.0.0.1:4318"},
+		},
+		{
+			desc:     "configured ports",
+			ports:    []int32{55690},
+			expected: []string{"10.0.0.1:55690"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			res, err := newK8sResolver(fake.NewSimpleClientset(slice), zap.NewNop(), "lb", "", tt.ports, time.Second)
+			require.NoError(t, err)
+
+			require.NoError(t, res.Start(context.Background()))
+			defer func() {
+				require.NoError(t, res.Shutdown(context.Background()))
+			}()
+
+			// test
+			resolved, err := res.Resolve(context.Background())
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, resolved)
+		})
+	}
+}
+
+func TestK8sResolverShutdownClearsCallbacks(t *testing.T) {
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "lb", "", nil, time.Second)
+	require.NoError(t, err)
+	res.OnChange(func([]string) {})
+
+	require.NoError(t, res.Start(context.Background()))
+	require.NoError(t, res.Shutdown(context.Background()))
+
+	assert.Len(t, res.onChangeCallbacks, 0)
+}
@@ -3,7 +3,12 @@
 
 package loadbalancingexporter
 
-import "context"
+import (
+	"context"
+	"net"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
+)
 
 type mockResolver struct {
 	onStart           func(context.Context) error
@@ -13,14 +18,14 @@ type mockResolver struct {
 	triggerCallbacks  bool
 }
 
-func (m *mockResolver) start(ctx context.Context) error {
+func (m *mockResolver) Start(ctx context.Context) error {
 	if m.onStart != nil {
 		if err := m.onStart(ctx); err != nil {
 			return err
 		}
 	}
 
-	_, err := m.resolve(ctx)
+	_, err := m.Resolve(ctx)
 	if err != nil {
 		return err
 	}
@@ -28,14 +33,14 @@ func (m *mockResolver) start(ctx context.Context) error {
 	return nil
 }
 
-func (m *mockResolver) shutdown(ctx context.Context) error {
+func (m *mockResolver) Shutdown(ctx context.Context) error {
 	if m.onShutdown != nil {
 		return m.onShutdown(ctx)
 	}
 	return nil
 }
 
-func (m *mockResolver) resolve(ctx context.Context) ([]string, error) {
+func (m *mockResolver) Resolve(ctx context.Context) ([]string, error) {
 	var resolved []string
 	var err error
 	if m.onResolve != nil {
@@ -50,8 +55,22 @@ func (m *mockResolver) resolve(ctx context.Context) ([]string, error) {
 	return resolved, err
 }
 
-func (m *mockResolver) onChange(f func([]string)) {
+func (m *mockResolver) OnChange(f func([]string)) {
 	m.onChangeCallbacks = append(m.onChangeCallbacks, f)
 }
 
-var _ resolver = (*mockResolver)(nil)
+var _ loadbalancing.Resolver = (*mockResolver)(nil)
+
+var _ loadbalancing.NetResolver = (*mockDNSResolver)(nil)
+
+type mockDNSResolver struct {
+	net.Resolver
+	onLookupIPAddr func(context.Context, string) ([]net.IPAddr, error)
+}
+
+func (m *mockDNSResolver) LookupIPAddr(ctx context.Context, hostname string) ([]net.IPAddr, error) {
+	if m.onLookupIPAddr != nil {
+		return m.onLookupIPAddr(ctx, hostname)
+	}
+	return nil, nil
+}
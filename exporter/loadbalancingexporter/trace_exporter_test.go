@@ -29,6 +29,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
 )
 
 func TestNewTracesExporter(t *testing.T) {
@@ -397,16 +399,13 @@ func TestRollingUpdatesWhenConsumeTraces(t *testing.T) {
 
 	// simulate rolling updates, the dns resolver should resolve in the following order
 	// ["127.0.0.1"] -> ["127.0.0.1", "127.0.0.2"] -> ["127.0.0.2"]
-	res, err := newDNSResolver(zap.NewNop(), "service-1", "", 5*time.Second, 1*time.Second)
-	require.NoError(t, err)
-
 	mu := sync.Mutex{}
 	var lastResolved []string
-	res.onChange(func(s []string) {
+	onChange := func(s []string) {
 		mu.Lock()
 		lastResolved = s
 		mu.Unlock()
-	})
+	}
 
 	resolverCh := make(chan struct{}, 1)
 	counter := &atomic.Int64{}
@@ -420,7 +419,7 @@ func TestRollingUpdatesWhenConsumeTraces(t *testing.T) {
 			{IP: net.IPv4(127, 0, 0, 2)},
 		},
 	}
-	res.resolver = &mockDNSResolver{
+	netResolver := &mockDNSResolver{
 		onLookupIPAddr: func(context.Context, string) ([]net.IPAddr, error) {
 			defer func() {
 				counter.Add(1)
@@ -438,7 +437,10 @@ func TestRollingUpdatesWhenConsumeTraces(t *testing.T) {
 			return resolve[2], nil
 		},
 	}
-	res.resInterval = 10 * time.Millisecond
+	res, err := loadbalancing.NewDNSResolver(zap.NewNop(), "service-1", "", 10*time.Millisecond, 1*time.Second,
+		loadbalancing.WithNetResolver(netResolver))
+	require.NoError(t, err)
+	res.OnChange(onChange)
 
 	cfg := &Config{
 		Resolver: ResolverSettings{
@@ -486,7 +488,7 @@ func TestRollingUpdatesWhenConsumeTraces(t *testing.T) {
 	lb.updateLock.Lock()
 	lb.exporters = defaultExporters
 	lb.updateLock.Unlock()
-	lb.res.onChange(func(endpoints []string) {
+	lb.res.OnChange(func(endpoints []string) {
 		lb.updateLock.Lock()
 		lb.exporters = defaultExporters
 		lb.updateLock.Unlock()
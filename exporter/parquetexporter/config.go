@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	compressionNone   = "none"
+	compressionSnappy = "snappy"
+	compressionGzip   = "gzip"
+	compressionZstd   = "zstd"
+	compressionLz4    = "lz4"
+)
+
+// Config defines configuration for the Parquet exporter.
+type Config struct {
+	// Path is the directory Parquet files are written to. Each signal is
+	// written to its own subdirectory (traces, metrics, logs).
+	Path string `mapstructure:"path"`
+
+	// Compression is the codec used to compress Parquet column chunks.
+	// Supported values: none, snappy (default), gzip, zstd, lz4.
+	Compression string `mapstructure:"compression"`
+
+	// Rotation controls when the current file is closed and a new one is started.
+	Rotation Rotation `mapstructure:"rotation"`
+}
+
+// Rotation defines when Parquet files are rolled over.
+type Rotation struct {
+	// MaxMegabytes is the size in megabytes after which the current file is
+	// closed and a new one is started. Zero disables size based rotation.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// Interval is the maximum duration a file is kept open for writing.
+	// Zero disables time based rotation.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+	if _, ok := compressionCodecs[cfg.Compression]; !ok {
+		return fmt.Errorf("compression %q is not supported", cfg.Compression)
+	}
+	if cfg.Rotation.MaxMegabytes < 0 {
+		return errors.New("rotation max_megabytes must not be negative")
+	}
+	if cfg.Rotation.Interval < 0 {
+		return errors.New("rotation interval must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				Path:        "/var/output",
+				Compression: compressionSnappy,
+				Rotation: Rotation{
+					Interval: defaultRotationInterval,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				Path:        "/var/output/parquet",
+				Compression: compressionZstd,
+				Rotation: Rotation{
+					MaxMegabytes: 256,
+					Interval:     time.Hour,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_compression"),
+			errorMessage: `compression "brotli" is not supported`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  string
+	}{
+		{
+			name: "missing path",
+			cfg:  &Config{Compression: compressionSnappy},
+			err:  "path must be non-empty",
+		},
+		{
+			name: "negative size",
+			cfg:  &Config{Path: "out", Compression: compressionSnappy, Rotation: Rotation{MaxMegabytes: -1}},
+			err:  "rotation max_megabytes must not be negative",
+		},
+		{
+			name: "negative interval",
+			cfg:  &Config{Path: "out", Compression: compressionSnappy, Rotation: Rotation{Interval: -time.Second}},
+			err:  "rotation interval must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.cfg.Validate(), tt.err)
+		})
+	}
+}
@@ -5,33 +5,120 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
 )
 
+const (
+	tracesDir  = "traces"
+	metricsDir = "metrics"
+	logsDir    = "logs"
+)
+
+// fileWriter is implemented by rollingWriter regardless of its row type.
+type fileWriter interface {
+	rotateIfExpired() error
+	close() error
+}
+
 type parquetExporter struct {
-	path string
+	dir      string
+	interval time.Duration
+	logger   *zap.Logger
+
+	// Only the writer matching the signal of the pipeline is set.
+	writer        fileWriter
+	tracesWriter  *rollingWriter[spanRow]
+	metricsWriter *rollingWriter[metricRow]
+	logsWriter    *rollingWriter[logRow]
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
 }
 
-func (e parquetExporter) start(ctx context.Context, host component.Host) error {
-	return nil
+func newTracesExporter(cfg *Config, logger *zap.Logger) *parquetExporter {
+	dir := filepath.Join(cfg.Path, tracesDir)
+	e := newParquetExporter(dir, cfg, logger)
+	e.tracesWriter = newRollingWriter[spanRow](dir, tracesDir, cfg)
+	e.writer = e.tracesWriter
+	return e
 }
 
-func (e parquetExporter) shutdown(ctx context.Context) error {
-	return nil
+func newMetricsExporter(cfg *Config, logger *zap.Logger) *parquetExporter {
+	dir := filepath.Join(cfg.Path, metricsDir)
+	e := newParquetExporter(dir, cfg, logger)
+	e.metricsWriter = newRollingWriter[metricRow](dir, metricsDir, cfg)
+	e.writer = e.metricsWriter
+	return e
 }
 
-func (e parquetExporter) consumeMetrics(ctx context.Context, ld pmetric.Metrics) error {
-	return nil
+func newLogsExporter(cfg *Config, logger *zap.Logger) *parquetExporter {
+	dir := filepath.Join(cfg.Path, logsDir)
+	e := newParquetExporter(dir, cfg, logger)
+	e.logsWriter = newRollingWriter[logRow](dir, logsDir, cfg)
+	e.writer = e.logsWriter
+	return e
 }
 
-func (e parquetExporter) consumeTraces(ctx context.Context, ld ptrace.Traces) error {
-	return nil
+func newParquetExporter(dir string, cfg *Config, logger *zap.Logger) *parquetExporter {
+	return &parquetExporter{
+		dir:      dir,
+		interval: cfg.Rotation.Interval,
+		logger:   logger,
+		stopCh:   make(chan struct{}),
+	}
 }
 
-func (e parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
+func (e *parquetExporter) start(_ context.Context, _ component.Host) error {
+	if err := os.MkdirAll(e.dir, 0700); err != nil {
+		return err
+	}
+	if e.interval > 0 {
+		e.wg.Add(1)
+		go e.rotateLoop()
+	}
 	return nil
 }
+
+// rotateLoop closes files that exceeded the rotation interval while idle.
+func (e *parquetExporter) rotateLoop() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(e.interval / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.writer.rotateIfExpired(); err != nil {
+				e.logger.Error("failed to rotate parquet file", zap.Error(err))
+			}
+		case <-e.stopCh:
+			return
+		}
+	}
+}
+
+func (e *parquetExporter) shutdown(_ context.Context) error {
+	close(e.stopCh)
+	e.wg.Wait()
+	return e.writer.close()
+}
+
+func (e *parquetExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	return e.metricsWriter.write(metricsToRows(md))
+}
+
+func (e *parquetExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	return e.tracesWriter.write(tracesToRows(td))
+}
+
+func (e *parquetExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	return e.logsWriter.write(logsToRows(ld))
+}
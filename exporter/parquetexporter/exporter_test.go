@@ -0,0 +1,296 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetexporter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func TestConsumeTraces(t *testing.T) {
+	cfg := &Config{Path: t.TempDir(), Compression: compressionZstd}
+	exp, err := createTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("io.opentelemetry.test")
+	span := ss.Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetStartTimestamp(pcommon.Timestamp(1000))
+	span.SetEndTimestamp(pcommon.Timestamp(3000))
+	span.Attributes().PutInt("http.status_code", 200)
+	span.Status().SetCode(ptrace.StatusCodeOk)
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().PutStr("exception.type", "IOError")
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	rows := readRows[spanRow](t, filepath.Join(cfg.Path, tracesDir))
+	require.Len(t, rows, 1)
+	row := rows[0]
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", row.TraceID)
+	assert.Equal(t, "0102030405060708", row.SpanID)
+	assert.Equal(t, "", row.ParentSpanID)
+	assert.Equal(t, "GET /cart", row.Name)
+	assert.Equal(t, "Server", row.Kind)
+	assert.Equal(t, "Ok", row.StatusCode)
+	assert.Equal(t, int64(2000), row.DurationNanos)
+	assert.Equal(t, "checkout", row.ServiceName)
+	assert.Equal(t, "io.opentelemetry.test", row.ScopeName)
+	assert.Equal(t, map[string]string{"http.status_code": "200"}, row.Attributes)
+	require.Len(t, row.Events, 1)
+	assert.Equal(t, "exception", row.Events[0].Name)
+	assert.Equal(t, map[string]string{"exception.type": "IOError"}, row.Events[0].Attributes)
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	cfg := &Config{Path: t.TempDir(), Compression: compressionGzip}
+	exp, err := createMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("queue.length")
+	dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetIntValue(42)
+	dp.Attributes().PutStr("queue", "orders")
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().DataPoints().AppendEmpty().SetDoubleValue(1.5)
+
+	hist := metrics.AppendEmpty()
+	hist.SetName("latency")
+	hdp := hist.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(12)
+	hdp.ExplicitBounds().FromRaw([]float64{5})
+	hdp.BucketCounts().FromRaw([]uint64{1, 2})
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	rows := readRows[metricRow](t, filepath.Join(cfg.Path, metricsDir))
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, "queue.length", rows[0].MetricName)
+	assert.Equal(t, "Gauge", rows[0].MetricType)
+	require.NotNil(t, rows[0].ValueInt)
+	assert.Equal(t, int64(42), *rows[0].ValueInt)
+	assert.Nil(t, rows[0].ValueDouble)
+	assert.Equal(t, map[string]string{"queue": "orders"}, rows[0].Attributes)
+
+	assert.Equal(t, "requests", rows[1].MetricName)
+	assert.Equal(t, "Cumulative", rows[1].AggregationTemporality)
+	assert.True(t, rows[1].IsMonotonic)
+	require.NotNil(t, rows[1].ValueDouble)
+	assert.Equal(t, 1.5, *rows[1].ValueDouble)
+
+	assert.Equal(t, "latency", rows[2].MetricName)
+	require.NotNil(t, rows[2].Count)
+	assert.Equal(t, int64(3), *rows[2].Count)
+	require.NotNil(t, rows[2].Sum)
+	assert.Equal(t, 12.0, *rows[2].Sum)
+	assert.Nil(t, rows[2].Min)
+	assert.Equal(t, []int64{1, 2}, rows[2].BucketCounts)
+	assert.Equal(t, []float64{5}, rows[2].ExplicitBounds)
+}
+
+func TestConsumeLogs(t *testing.T) {
+	cfg := &Config{Path: t.TempDir(), Compression: compressionNone}
+	exp, err := createLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.Timestamp(1000))
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.SetSeverityText("ERROR")
+	lr.Body().SetStr("payment failed")
+	lr.Attributes().PutBool("retry", true)
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	rows := readRows[logRow](t, filepath.Join(cfg.Path, logsDir))
+	require.Len(t, rows, 1)
+	assert.Equal(t, int64(1000), rows[0].Timestamp)
+	assert.Equal(t, int32(plog.SeverityNumberError), rows[0].SeverityNumber)
+	assert.Equal(t, "ERROR", rows[0].SeverityText)
+	assert.Equal(t, "payment failed", rows[0].Body)
+	assert.Equal(t, "checkout", rows[0].ServiceName)
+	assert.Equal(t, map[string]string{"retry": "true"}, rows[0].Attributes)
+}
+
+func TestRotateBySize(t *testing.T) {
+	dir := t.TempDir()
+	w := newRollingWriter[logRow](dir, logsDir, &Config{Compression: compressionSnappy})
+	// Any non-empty file exceeds a single byte.
+	w.maxBytes = 1
+
+	require.NoError(t, w.write([]logRow{{Body: "first"}}))
+	require.NoError(t, w.write([]logRow{{Body: "second"}}))
+	require.NoError(t, w.close())
+
+	files := listFiles(t, dir)
+	require.Len(t, files, 2)
+	for _, f := range files {
+		assert.Equal(t, fileExtension, filepath.Ext(f))
+	}
+	assert.Len(t, readRows[logRow](t, dir), 2)
+}
+
+func TestRotateByInterval(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	w := newRollingWriter[logRow](dir, logsDir, &Config{Compression: compressionSnappy, Rotation: Rotation{Interval: time.Minute}})
+	w.now = func() time.Time { return now }
+
+	require.NoError(t, w.write([]logRow{{Body: "first"}}))
+	files := listFiles(t, dir)
+	require.Len(t, files, 1)
+	assert.Equal(t, partialExtension, filepath.Ext(files[0]), "open file must keep its temporary name")
+
+	require.NoError(t, w.rotateIfExpired())
+	assert.Equal(t, partialExtension, filepath.Ext(listFiles(t, dir)[0]))
+
+	now = now.Add(time.Minute)
+	require.NoError(t, w.rotateIfExpired())
+	files = listFiles(t, dir)
+	require.Len(t, files, 1)
+	assert.Equal(t, "logs-20230501T000000Z-1.parquet", files[0])
+
+	require.NoError(t, w.write([]logRow{{Body: "second"}}))
+	require.NoError(t, w.close())
+	assert.Len(t, listFiles(t, dir), 2)
+}
+
+func TestDiscardFileOnWriteError(t *testing.T) {
+	dir := t.TempDir()
+	w := newRollingWriter[logRow](dir, logsDir, &Config{Compression: compressionSnappy})
+
+	// The first batch fails, so the file holds no acknowledged rows.
+	require.NoError(t, w.open())
+	w.counter.w = &failingWriter{w: w.file}
+	require.Error(t, w.write([]logRow{{Body: "first"}}))
+	assert.Empty(t, listFiles(t, dir), "partially written file must be removed")
+
+	require.NoError(t, w.write([]logRow{{Body: "second"}}))
+	require.NoError(t, w.close())
+	rows := readRows[logRow](t, dir)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "second", rows[0].Body)
+}
+
+func TestKeepFlushedBatchesOnWriteError(t *testing.T) {
+	dir := t.TempDir()
+	w := newRollingWriter[logRow](dir, logsDir, &Config{Compression: compressionSnappy})
+
+	require.NoError(t, w.write([]logRow{{Body: "first"}}))
+	require.NoError(t, w.write([]logRow{{Body: "second"}}))
+	// The next flush writes part of its row group before failing.
+	w.counter.w = &failingWriter{w: w.file}
+	require.Error(t, w.write([]logRow{{Body: "failed"}}))
+	files := listFiles(t, dir)
+	require.Len(t, files, 1)
+	assert.True(t, strings.HasSuffix(files[0], fileExtension), "file must be finalized")
+
+	require.NoError(t, w.write([]logRow{{Body: "third"}}))
+	require.NoError(t, w.close())
+	assert.Len(t, listFiles(t, dir), 2)
+	var bodies []string
+	for _, row := range readRows[logRow](t, dir) {
+		bodies = append(bodies, row.Body)
+	}
+	assert.ElementsMatch(t, []string{"first", "second", "third"}, bodies)
+}
+
+// failingWriter writes half of the first buffer it is given and fails,
+// then writes the following buffers.
+type failingWriter struct {
+	w      io.Writer
+	failed bool
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	if f.failed {
+		return f.w.Write(p)
+	}
+	f.failed = true
+	n, _ := f.w.Write(p[:len(p)/2])
+	return n, errors.New("disk full")
+}
+
+func TestShutdownWithoutData(t *testing.T) {
+	cfg := &Config{Path: t.TempDir(), Compression: compressionSnappy, Rotation: Rotation{Interval: time.Second}}
+	exp := newLogsExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.shutdown(context.Background()))
+	assert.Empty(t, listFiles(t, filepath.Join(cfg.Path, logsDir)))
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func readRows[T any](t *testing.T, dir string) []T {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+fileExtension))
+	require.NoError(t, err)
+	var rows []T
+	for _, f := range files {
+		pf, err := local.NewLocalFileReader(f)
+		require.NoError(t, err)
+		pr, err := reader.NewParquetReader(pf, new(T), 1)
+		require.NoError(t, err)
+		fileRows := make([]T, pr.GetNumRows())
+		require.NoError(t, pr.Read(&fileRows))
+		pr.ReadStop()
+		require.NoError(t, pf.Close())
+		rows = append(rows, fileRows...)
+	}
+	return rows
+}
@@ -5,6 +5,7 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
@@ -13,10 +14,6 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter/internal/metadata"
 )
 
-type Config struct {
-	Path string `mapstructure:"path"`
-}
-
 // NewFactory creates a factory for the Parquet exporter.
 func NewFactory() exporter.Factory {
 	return exporter.NewFactory(
@@ -27,8 +24,17 @@ func NewFactory() exporter.Factory {
 		exporter.WithLogs(createLogsExporter, metadata.LogsStability))
 }
 
+// defaultRotationInterval bounds how long data stays in an unfinished file,
+// which cannot be read back if the collector stops without shutting down.
+const defaultRotationInterval = 5 * time.Minute
+
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		Compression: compressionSnappy,
+		Rotation: Rotation{
+			Interval: defaultRotationInterval,
+		},
+	}
 }
 
 func createTracesExporter(
@@ -36,7 +42,7 @@ func createTracesExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Traces, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newTracesExporter(cfg.(*Config), set.Logger)
 	return exporterhelper.NewTracesExporter(
 		ctx,
 		set,
@@ -52,7 +58,7 @@ func createMetricsExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Metrics, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newMetricsExporter(cfg.(*Config), set.Logger)
 	return exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
@@ -68,7 +74,7 @@ func createLogsExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Logs, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newLogsExporter(cfg.(*Config), set.Logger)
 	return exporterhelper.NewLogsExporter(
 		ctx,
 		set,
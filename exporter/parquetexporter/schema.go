@@ -0,0 +1,374 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+// spanRow is the Parquet schema used for spans. One row is written per span.
+type spanRow struct {
+	Timestamp          int64             `parquet:"name=timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	EndTimestamp       int64             `parquet:"name=end_timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	DurationNanos      int64             `parquet:"name=duration_nanos, type=INT64"`
+	TraceID            string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID             string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ParentSpanID       string            `parquet:"name=parent_span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState         string            `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Name               string            `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Kind               string            `parquet:"name=kind, type=BYTE_ARRAY, convertedtype=UTF8"`
+	StatusCode         string            `parquet:"name=status_code, type=BYTE_ARRAY, convertedtype=UTF8"`
+	StatusMessage      string            `parquet:"name=status_message, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ServiceName        string            `parquet:"name=service_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ResourceAttributes map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName          string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion       string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes         map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	Events             []spanEventRow    `parquet:"name=events, type=LIST"`
+	Links              []spanLinkRow     `parquet:"name=links, type=LIST"`
+}
+
+type spanEventRow struct {
+	Timestamp  int64             `parquet:"name=timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Name       string            `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+}
+
+type spanLinkRow struct {
+	TraceID    string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID     string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState string            `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+}
+
+// metricRow is the Parquet schema used for metrics. One row is written per
+// data point; columns that do not apply to the metric type are left null.
+type metricRow struct {
+	Timestamp              int64             `parquet:"name=timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	StartTimestamp         int64             `parquet:"name=start_timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	ServiceName            string            `parquet:"name=service_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricName             string            `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricDescription      string            `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricUnit             string            `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricType             string            `parquet:"name=metric_type, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AggregationTemporality string            `parquet:"name=aggregation_temporality, type=BYTE_ARRAY, convertedtype=UTF8"`
+	IsMonotonic            bool              `parquet:"name=is_monotonic, type=BOOLEAN"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	Flags                  int64             `parquet:"name=flags, type=INT64"`
+	ValueInt               *int64            `parquet:"name=value_int, type=INT64, repetitiontype=OPTIONAL"`
+	ValueDouble            *float64          `parquet:"name=value_double, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Count                  *int64            `parquet:"name=count, type=INT64, repetitiontype=OPTIONAL"`
+	Sum                    *float64          `parquet:"name=sum, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Min                    *float64          `parquet:"name=min, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Max                    *float64          `parquet:"name=max, type=DOUBLE, repetitiontype=OPTIONAL"`
+	BucketCounts           []int64           `parquet:"name=bucket_counts, type=LIST, valuetype=INT64"`
+	ExplicitBounds         []float64         `parquet:"name=explicit_bounds, type=LIST, valuetype=DOUBLE"`
+	Scale                  *int32            `parquet:"name=scale, type=INT32, repetitiontype=OPTIONAL"`
+	ZeroCount              *int64            `parquet:"name=zero_count, type=INT64, repetitiontype=OPTIONAL"`
+	PositiveOffset         *int32            `parquet:"name=positive_offset, type=INT32, repetitiontype=OPTIONAL"`
+	PositiveBucketCounts   []int64           `parquet:"name=positive_bucket_counts, type=LIST, valuetype=INT64"`
+	NegativeOffset         *int32            `parquet:"name=negative_offset, type=INT32, repetitiontype=OPTIONAL"`
+	NegativeBucketCounts   []int64           `parquet:"name=negative_bucket_counts, type=LIST, valuetype=INT64"`
+	Quantiles              []float64         `parquet:"name=quantiles, type=LIST, valuetype=DOUBLE"`
+	QuantileValues         []float64         `parquet:"name=quantile_values, type=LIST, valuetype=DOUBLE"`
+}
+
+// logRow is the Parquet schema used for logs. One row is written per log record.
+type logRow struct {
+	Timestamp          int64             `parquet:"name=timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	ObservedTimestamp  int64             `parquet:"name=observed_timestamp, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	TraceID            string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID             string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Flags              int64             `parquet:"name=flags, type=INT64"`
+	SeverityNumber     int32             `parquet:"name=severity_number, type=INT32"`
+	SeverityText       string            `parquet:"name=severity_text, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Body               string            `parquet:"name=body, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ServiceName        string            `parquet:"name=service_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ResourceAttributes map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName          string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion       string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes         map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+}
+
+func tracesToRows(td ptrace.Traces) []spanRow {
+	rows := make([]spanRow, 0, td.SpanCount())
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resAttrs := attributesToMap(rs.Resource().Attributes())
+		serviceName := resAttrs[conventions.AttributeServiceName]
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				rows = append(rows, spanRow{
+					Timestamp:          int64(span.StartTimestamp()),
+					EndTimestamp:       int64(span.EndTimestamp()),
+					DurationNanos:      int64(span.EndTimestamp()) - int64(span.StartTimestamp()),
+					TraceID:            span.TraceID().String(),
+					SpanID:             span.SpanID().String(),
+					ParentSpanID:       span.ParentSpanID().String(),
+					TraceState:         span.TraceState().AsRaw(),
+					Name:               span.Name(),
+					Kind:               span.Kind().String(),
+					StatusCode:         span.Status().Code().String(),
+					StatusMessage:      span.Status().Message(),
+					ServiceName:        serviceName,
+					ResourceAttributes: resAttrs,
+					ScopeName:          ss.Scope().Name(),
+					ScopeVersion:       ss.Scope().Version(),
+					Attributes:         attributesToMap(span.Attributes()),
+					Events:             spanEventsToRows(span.Events()),
+					Links:              spanLinksToRows(span.Links()),
+				})
+			}
+		}
+	}
+	return rows
+}
+
+func spanEventsToRows(events ptrace.SpanEventSlice) []spanEventRow {
+	if events.Len() == 0 {
+		return nil
+	}
+	rows := make([]spanEventRow, 0, events.Len())
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		rows = append(rows, spanEventRow{
+			Timestamp:  int64(event.Timestamp()),
+			Name:       event.Name(),
+			Attributes: attributesToMap(event.Attributes()),
+		})
+	}
+	return rows
+}
+
+func spanLinksToRows(links ptrace.SpanLinkSlice) []spanLinkRow {
+	if links.Len() == 0 {
+		return nil
+	}
+	rows := make([]spanLinkRow, 0, links.Len())
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		rows = append(rows, spanLinkRow{
+			TraceID:    link.TraceID().String(),
+			SpanID:     link.SpanID().String(),
+			TraceState: link.TraceState().AsRaw(),
+			Attributes: attributesToMap(link.Attributes()),
+		})
+	}
+	return rows
+}
+
+func metricsToRows(md pmetric.Metrics) []metricRow {
+	rows := make([]metricRow, 0, md.DataPointCount())
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resAttrs := attributesToMap(rm.Resource().Attributes())
+		serviceName := resAttrs[conventions.AttributeServiceName]
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				base := metricRow{
+					ServiceName:        serviceName,
+					ResourceAttributes: resAttrs,
+					ScopeName:          sm.Scope().Name(),
+					ScopeVersion:       sm.Scope().Version(),
+					MetricName:         metric.Name(),
+					MetricDescription:  metric.Description(),
+					MetricUnit:         metric.Unit(),
+					MetricType:         metric.Type().String(),
+				}
+				rows = appendMetricRows(rows, base, metric)
+			}
+		}
+	}
+	return rows
+}
+
+func appendMetricRows(rows []metricRow, base metricRow, metric pmetric.Metric) []metricRow {
+	//exhaustive:enforce
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		rows = appendNumberDataPointRows(rows, base, metric.Gauge().DataPoints())
+	case pmetric.MetricTypeSum:
+		base.AggregationTemporality = metric.Sum().AggregationTemporality().String()
+		base.IsMonotonic = metric.Sum().IsMonotonic()
+		rows = appendNumberDataPointRows(rows, base, metric.Sum().DataPoints())
+	case pmetric.MetricTypeHistogram:
+		base.AggregationTemporality = metric.Histogram().AggregationTemporality().String()
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := base
+			row.Timestamp = int64(dp.Timestamp())
+			row.StartTimestamp = int64(dp.StartTimestamp())
+			row.Attributes = attributesToMap(dp.Attributes())
+			row.Flags = int64(dp.Flags())
+			row.Count = newInt64(int64(dp.Count()))
+			if dp.HasSum() {
+				row.Sum = newFloat64(dp.Sum())
+			}
+			if dp.HasMin() {
+				row.Min = newFloat64(dp.Min())
+			}
+			if dp.HasMax() {
+				row.Max = newFloat64(dp.Max())
+			}
+			row.BucketCounts = bucketCountsToInt64(dp.BucketCounts())
+			row.ExplicitBounds = dp.ExplicitBounds().AsRaw()
+			rows = append(rows, row)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		base.AggregationTemporality = metric.ExponentialHistogram().AggregationTemporality().String()
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := base
+			row.Timestamp = int64(dp.Timestamp())
+			row.StartTimestamp = int64(dp.StartTimestamp())
+			row.Attributes = attributesToMap(dp.Attributes())
+			row.Flags = int64(dp.Flags())
+			row.Count = newInt64(int64(dp.Count()))
+			if dp.HasSum() {
+				row.Sum = newFloat64(dp.Sum())
+			}
+			if dp.HasMin() {
+				row.Min = newFloat64(dp.Min())
+			}
+			if dp.HasMax() {
+				row.Max = newFloat64(dp.Max())
+			}
+			row.Scale = newInt32(dp.Scale())
+			row.ZeroCount = newInt64(int64(dp.ZeroCount()))
+			row.PositiveOffset = newInt32(dp.Positive().Offset())
+			row.PositiveBucketCounts = bucketCountsToInt64(dp.Positive().BucketCounts())
+			row.NegativeOffset = newInt32(dp.Negative().Offset())
+			row.NegativeBucketCounts = bucketCountsToInt64(dp.Negative().BucketCounts())
+			rows = append(rows, row)
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := base
+			row.Timestamp = int64(dp.Timestamp())
+			row.StartTimestamp = int64(dp.StartTimestamp())
+			row.Attributes = attributesToMap(dp.Attributes())
+			row.Flags = int64(dp.Flags())
+			row.Count = newInt64(int64(dp.Count()))
+			row.Sum = newFloat64(dp.Sum())
+			qvs := dp.QuantileValues()
+			row.Quantiles = make([]float64, 0, qvs.Len())
+			row.QuantileValues = make([]float64, 0, qvs.Len())
+			for q := 0; q < qvs.Len(); q++ {
+				row.Quantiles = append(row.Quantiles, qvs.At(q).Quantile())
+				row.QuantileValues = append(row.QuantileValues, qvs.At(q).Value())
+			}
+			rows = append(rows, row)
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+	return rows
+}
+
+func appendNumberDataPointRows(rows []metricRow, base metricRow, dps pmetric.NumberDataPointSlice) []metricRow {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		row := base
+		row.Timestamp = int64(dp.Timestamp())
+		row.StartTimestamp = int64(dp.StartTimestamp())
+		row.Attributes = attributesToMap(dp.Attributes())
+		row.Flags = int64(dp.Flags())
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			row.ValueInt = newInt64(dp.IntValue())
+		case pmetric.NumberDataPointValueTypeDouble:
+			row.ValueDouble = newFloat64(dp.DoubleValue())
+		case pmetric.NumberDataPointValueTypeEmpty:
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func logsToRows(ld plog.Logs) []logRow {
+	rows := make([]logRow, 0, ld.LogRecordCount())
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resAttrs := attributesToMap(rl.Resource().Attributes())
+		serviceName := resAttrs[conventions.AttributeServiceName]
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				rows = append(rows, logRow{
+					Timestamp:          int64(lr.Timestamp()),
+					ObservedTimestamp:  int64(lr.ObservedTimestamp()),
+					TraceID:            lr.TraceID().String(),
+					SpanID:             lr.SpanID().String(),
+					Flags:              int64(lr.Flags()),
+					SeverityNumber:     int32(lr.SeverityNumber()),
+					SeverityText:       lr.SeverityText(),
+					Body:               lr.Body().AsString(),
+					ServiceName:        serviceName,
+					ResourceAttributes: resAttrs,
+					ScopeName:          sl.Scope().Name(),
+					ScopeVersion:       sl.Scope().Version(),
+					Attributes:         attributesToMap(lr.Attributes()),
+				})
+			}
+		}
+	}
+	return rows
+}
+
+// attributesToMap flattens attribute values to their string representation,
+// complex values (maps, slices) are encoded as JSON.
+func attributesToMap(attrs pcommon.Map) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		m[k] = v.AsString()
+		return true
+	})
+	return m
+}
+
+func newFloat64(v float64) *float64 {
+	return &v
+}
+
+func newInt64(v int64) *int64 {
+	return &v
+}
+
+func newInt32(v int32) *int32 {
+	return &v
+}
+
+// bucketCountsToInt64 converts bucket counts to the signed integers supported
+// by the Parquet INT64 physical type.
+func bucketCountsToInt64(counts pcommon.UInt64Slice) []int64 {
+	out := make([]int64, counts.Len())
+	for i := 0; i < counts.Len(); i++ {
+		out[i] = int64(counts.At(i))
+	}
+	return out
+}
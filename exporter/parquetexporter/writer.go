@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/xitongsys/parquet-go/layout"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/multierr"
+)
+
+const (
+	fileExtension     = ".parquet"
+	partialExtension  = ".tmp"
+	fileNameTimestamp = "20060102T150405Z"
+
+	// writerParallelism is the number of goroutines used to marshal rows.
+	writerParallelism = 1
+)
+
+var compressionCodecs = map[string]parquet.CompressionCodec{
+	compressionNone:   parquet.CompressionCodec_UNCOMPRESSED,
+	compressionSnappy: parquet.CompressionCodec_SNAPPY,
+	compressionGzip:   parquet.CompressionCodec_GZIP,
+	compressionZstd:   parquet.CompressionCodec_ZSTD,
+	compressionLz4:    parquet.CompressionCodec_LZ4,
+}
+
+// rollingWriter writes rows of type T to Parquet files in a directory.
+// Every call to write produces one row group. Files are written under a
+// temporary name and renamed once they are complete, so readers globbing
+// for *.parquet never observe a partially written file.
+type rollingWriter[T any] struct {
+	dir      string
+	prefix   string
+	codec    parquet.CompressionCodec
+	maxBytes int64
+	maxAge   time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	file     *os.File
+	counter  *countingWriter
+	writer   *writer.ParquetWriter
+	openedAt time.Time
+	sequence int
+	// flushed is the state of the current file after the last successful batch.
+	flushed checkpoint
+}
+
+// checkpoint records the state of a file after a successful batch, so that
+// the file can be finalized without the rows of a batch that failed later.
+type checkpoint struct {
+	offset        int64
+	numRows       int64
+	rowGroups     int
+	columnIndexes int
+	offsetIndexes int
+}
+
+func newRollingWriter[T any](dir, prefix string, cfg *Config) *rollingWriter[T] {
+	return &rollingWriter[T]{
+		dir:      dir,
+		prefix:   prefix,
+		codec:    compressionCodecs[cfg.Compression],
+		maxBytes: int64(cfg.Rotation.MaxMegabytes) * 1024 * 1024,
+		maxAge:   cfg.Rotation.Interval,
+		now:      time.Now,
+	}
+}
+
+// write appends rows to the current file, opening a new file if needed and
+// rotating it afterwards if it exceeds the configured size or age.
+func (w *rollingWriter[T]) write(rows []T) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.writer == nil {
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+	for i := range rows {
+		if err := w.writer.Write(rows[i]); err != nil {
+			return w.rotateAfterFailedWrite(err)
+		}
+	}
+	if err := w.writer.Flush(true); err != nil {
+		return w.rotateAfterFailedWrite(err)
+	}
+	w.flushed = checkpoint{
+		offset:        w.writer.Offset,
+		numRows:       w.writer.Footer.NumRows,
+		rowGroups:     len(w.writer.Footer.RowGroups),
+		columnIndexes: len(w.writer.ColumnIndexes),
+		offsetIndexes: len(w.writer.OffsetIndexes),
+	}
+	if w.shouldRotate() {
+		return w.closeFile()
+	}
+	return nil
+}
+
+// rotateIfExpired closes the current file if it is older than the configured
+// rotation interval. It is called periodically so that data becomes readable
+// even when no new rows arrive.
+func (w *rollingWriter[T]) rotateIfExpired() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.writer == nil || w.maxAge <= 0 || w.now().Sub(w.openedAt) < w.maxAge {
+		return nil
+	}
+	return w.closeFile()
+}
+
+// close finalizes the current file, if any.
+func (w *rollingWriter[T]) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.writer == nil {
+		return nil
+	}
+	return w.closeFile()
+}
+
+func (w *rollingWriter[T]) shouldRotate() bool {
+	if w.maxBytes > 0 && w.counter.n >= w.maxBytes {
+		return true
+	}
+	return w.maxAge > 0 && w.now().Sub(w.openedAt) >= w.maxAge
+}
+
+func (w *rollingWriter[T]) open() error {
+	w.openedAt = w.now()
+	w.sequence++
+	name := filepath.Join(w.dir, fmt.Sprintf("%s-%s-%d%s%s",
+		w.prefix, w.openedAt.UTC().Format(fileNameTimestamp), w.sequence, fileExtension, partialExtension))
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	counter := &countingWriter{w: f}
+	pw, err := writer.NewParquetWriterFromWriter(counter, new(T), writerParallelism)
+	if err != nil {
+		return multierr.Append(err, f.Close())
+	}
+	pw.CompressionType = w.codec
+	w.file = f
+	w.counter = counter
+	w.writer = pw
+	w.flushed = checkpoint{offset: pw.Offset}
+	return nil
+}
+
+func (w *rollingWriter[T]) closeFile() error {
+	name := w.file.Name()
+	err := multierr.Append(w.writer.WriteStop(), w.file.Close())
+	w.reset()
+	if err != nil {
+		return err
+	}
+	return os.Rename(name, name[:len(name)-len(partialExtension)])
+}
+
+// rotateAfterFailedWrite rejects the batch of a failed write. The rows of the
+// previous batches were already acknowledged, so the file is brought back to its
+// last complete row group and finalized with them. A file without any complete
+// row group is removed.
+func (w *rollingWriter[T]) rotateAfterFailedWrite(err error) error {
+	name := filepath.Base(w.file.Name())
+	if w.flushed.rowGroups == 0 {
+		return w.discardFile(err)
+	}
+	if rewindErr := w.rewind(); rewindErr != nil {
+		// Keep the file under its temporary name rather than losing the acknowledged rows.
+		closeErr := w.file.Close()
+		w.reset()
+		return fmt.Errorf("failed to finalize %s after failed write: %w", name, multierr.Combine(err, rewindErr, closeErr))
+	}
+	if closeErr := w.closeFile(); closeErr != nil {
+		return fmt.Errorf("failed to finalize %s after failed write: %w", name, multierr.Append(err, closeErr))
+	}
+	return fmt.Errorf("rotated %s after failed write: %w", name, err)
+}
+
+// rewind drops the rows of the failed batch from the Parquet writer and
+// truncates the file to the end of the last complete row group.
+func (w *rollingWriter[T]) rewind() error {
+	pw := w.writer
+	pw.Objs = pw.Objs[:0]
+	pw.ObjsSize = 0
+	pw.PagesMapBuf = make(map[string][]*layout.Page)
+	pw.DictRecs = make(map[string]*layout.DictRecType)
+	pw.Size = 0
+	pw.NumRows = 0
+	pw.Offset = w.flushed.offset
+	pw.Footer.NumRows = w.flushed.numRows
+	pw.Footer.RowGroups = pw.Footer.RowGroups[:w.flushed.rowGroups]
+	pw.ColumnIndexes = pw.ColumnIndexes[:w.flushed.columnIndexes]
+	pw.OffsetIndexes = pw.OffsetIndexes[:w.flushed.offsetIndexes]
+
+	if err := w.file.Truncate(w.flushed.offset); err != nil {
+		return err
+	}
+	if _, err := w.file.Seek(w.flushed.offset, io.SeekStart); err != nil {
+		return err
+	}
+	w.counter.n = w.flushed.offset
+	return nil
+}
+
+// discardFile closes and removes the current file after a failed write. It is
+// only used when the file holds no complete row group.
+func (w *rollingWriter[T]) discardFile(err error) error {
+	name := w.file.Name()
+	err = multierr.Combine(err, w.file.Close(), os.Remove(name))
+	w.reset()
+	return fmt.Errorf("discarded %s after failed write: %w", filepath.Base(name), err)
+}
+
+func (w *rollingWriter[T]) reset() {
+	w.writer = nil
+	w.file = nil
+	w.counter = nil
+	w.flushed = checkpoint{}
+}
+
+// countingWriter keeps track of the number of bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -25,6 +25,10 @@ const (
 	PodType EndpointType = "pod"
 	// K8sNodeType is a Kubernetes Node endpoint.
 	K8sNodeType EndpointType = "k8s.node"
+	// K8sServiceType is a Kubernetes Service port endpoint.
+	K8sServiceType EndpointType = "k8s.service"
+	// K8sIngressType is a Kubernetes Ingress rule endpoint.
+	K8sIngressType EndpointType = "k8s.ingress"
 	// HostPortType is a hostport endpoint.
 	HostPortType EndpointType = "hostport"
 	// ContainerType is a container endpoint.
@@ -35,6 +39,8 @@ var (
 	_ EndpointDetails = (*Pod)(nil)
 	_ EndpointDetails = (*Port)(nil)
 	_ EndpointDetails = (*K8sNode)(nil)
+	_ EndpointDetails = (*K8sService)(nil)
+	_ EndpointDetails = (*K8sIngress)(nil)
 	_ EndpointDetails = (*HostPort)(nil)
 	_ EndpointDetails = (*Container)(nil)
 )
@@ -262,3 +268,83 @@ func (n *K8sNode) Env() EndpointEnv {
 func (n *K8sNode) Type() EndpointType {
 	return K8sNodeType
 }
+
+// K8sService represents a port of a Kubernetes Service object.
+type K8sService struct {
+	// Name of the service.
+	Name string
+	// UID is the unique ID in the cluster for the service.
+	UID string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata.
+	Annotations map[string]string
+	// Namespace must be unique for services with same name.
+	Namespace string
+	// ServiceType is the type of the service, e.g. ClusterIP, NodePort, LoadBalancer or ExternalName.
+	ServiceType string
+	// ClusterIP is the IP address of the service, or "None" for headless services.
+	ClusterIP string
+	// PortName is the name of the service port.
+	PortName string
+	// Port number of the service port.
+	Port uint16
+	// Transport is the transport protocol used by the service port. (TCP or UDP).
+	Transport Transport
+}
+
+func (s *K8sService) Env() EndpointEnv {
+	return map[string]interface{}{
+		"name":         s.Name,
+		"uid":          s.UID,
+		"labels":       s.Labels,
+		"annotations":  s.Annotations,
+		"namespace":    s.Namespace,
+		"service_type": s.ServiceType,
+		"cluster_ip":   s.ClusterIP,
+		"port_name":    s.PortName,
+		"port":         s.Port,
+		"transport":    s.Transport,
+	}
+}
+
+func (s *K8sService) Type() EndpointType {
+	return K8sServiceType
+}
+
+// K8sIngress represents a rule path of a Kubernetes Ingress object.
+type K8sIngress struct {
+	// Name of the ingress.
+	Name string
+	// UID is the unique ID in the cluster for the ingress.
+	UID string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata.
+	Annotations map[string]string
+	// Namespace must be unique for ingresses with same name.
+	Namespace string
+	// Scheme is "https" if the rule host is covered by the ingress TLS configuration, "http" otherwise.
+	Scheme string
+	// Host is the rule host, or the load-balancer address for rules without a host.
+	Host string
+	// Path is the rule path.
+	Path string
+}
+
+func (i *K8sIngress) Env() EndpointEnv {
+	return map[string]interface{}{
+		"name":        i.Name,
+		"uid":         i.UID,
+		"labels":      i.Labels,
+		"annotations": i.Annotations,
+		"namespace":   i.Namespace,
+		"scheme":      i.Scheme,
+		"host":        i.Host,
+		"path":        i.Path,
+	}
+}
+
+func (i *K8sIngress) Type() EndpointType {
+	return K8sIngressType
+}
@@ -194,6 +194,86 @@ func TestEndpointEnv(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Kubernetes Service",
+			endpoint: Endpoint{
+				ID:     EndpointID("k8s_service_endpoint_id"),
+				Target: "10.0.0.1:80",
+				Details: &K8sService{
+					Name:        "a-k8s-service",
+					UID:         "a-k8s-service-uid",
+					Namespace:   "default",
+					ServiceType: "ClusterIP",
+					ClusterIP:   "10.0.0.1",
+					PortName:    "http",
+					Port:        80,
+					Transport:   ProtocolTCP,
+					Annotations: map[string]string{
+						"annotation_key": "annotation_val",
+					},
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+				},
+			},
+			want: EndpointEnv{
+				"type":         "k8s.service",
+				"id":           "k8s_service_endpoint_id",
+				"endpoint":     "10.0.0.1:80",
+				"name":         "a-k8s-service",
+				"uid":          "a-k8s-service-uid",
+				"namespace":    "default",
+				"service_type": "ClusterIP",
+				"cluster_ip":   "10.0.0.1",
+				"port_name":    "http",
+				"port":         uint16(80),
+				"transport":    ProtocolTCP,
+				"annotations": map[string]string{
+					"annotation_key": "annotation_val",
+				},
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+			},
+		},
+		{
+			name: "Kubernetes Ingress",
+			endpoint: Endpoint{
+				ID:     EndpointID("k8s_ingress_endpoint_id"),
+				Target: "https://host.example.com/api",
+				Details: &K8sIngress{
+					Name:      "a-k8s-ingress",
+					UID:       "a-k8s-ingress-uid",
+					Namespace: "default",
+					Scheme:    "https",
+					Host:      "host.example.com",
+					Path:      "/api",
+					Annotations: map[string]string{
+						"annotation_key": "annotation_val",
+					},
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+				},
+			},
+			want: EndpointEnv{
+				"type":      "k8s.ingress",
+				"id":        "k8s_ingress_endpoint_id",
+				"endpoint":  "https://host.example.com/api",
+				"name":      "a-k8s-ingress",
+				"uid":       "a-k8s-ingress-uid",
+				"namespace": "default",
+				"scheme":    "https",
+				"host":      "host.example.com",
+				"path":      "/api",
+				"annotations": map[string]string{
+					"annotation_key": "annotation_val",
+				},
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
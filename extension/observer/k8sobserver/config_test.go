@@ -41,8 +41,17 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				Node:         "",
 				APIConfig:    k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeNone},
-				ObservePods:  true,
-				ObserveNodes: true,
+				ObservePods:      true,
+				ObserveNodes:     true,
+				ObserveServices:  true,
+				ObserveIngresses: true,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "services-only"),
+			expected: &Config{
+				APIConfig:       k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				ObserveServices: true,
 			},
 		},
 		{
@@ -51,7 +60,7 @@ func TestLoadConfig(t *testing.T) {
 		},
 		{
 			id:          component.NewIDWithName(metadata.Type, "invalid_no_observing"),
-			expectedErr: "one of observe_pods and observe_nodes must be true",
+			expectedErr: "one of observe_pods, observe_nodes, observe_services and observe_ingresses must be true",
 		},
 	}
 	for _, tt := range tests {
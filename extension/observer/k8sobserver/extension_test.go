@@ -219,3 +219,48 @@ func TestExtensionObserveNodes(t *testing.T) {
 
 	require.NoError(t, ext.Shutdown(context.Background()))
 }
+
+func TestExtensionObserveServicesAndIngresses(t *testing.T) {
+	factory := NewFactory()
+	config := factory.CreateDefaultConfig().(*Config)
+	mockServiceHost(t, config)
+
+	set := extensiontest.NewNopCreateSettings()
+	set.ID = component.NewID(metadata.Type)
+	ext, err := newObserver(config, set)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	obs := ext.(*k8sObserver)
+	serviceListerWatcher := framework.NewFakeControllerSource()
+	obs.serviceListerWatcher = serviceListerWatcher
+	ingressListerWatcher := framework.NewFakeControllerSource()
+	obs.ingressListerWatcher = ingressListerWatcher
+
+	serviceListerWatcher.Add(service1V1)
+	ingressListerWatcher.Add(ingress1V1)
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	obs.ListAndWatch(sink)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.added) == 4
+	})
+
+	var expected []observer.Endpoint
+	expected = append(expected, convertServiceToEndpoints("k8s_observer", service1V1)...)
+	expected = append(expected, convertIngressToEndpoints("k8s_observer", ingress1V1)...)
+	assert.ElementsMatch(t, expected, sink.added)
+
+	serviceListerWatcher.Delete(service1V1)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.removed) == 2
+	})
+
+	assert.ElementsMatch(t, convertServiceToEndpoints("k8s_observer", service1V1), sink.removed)
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
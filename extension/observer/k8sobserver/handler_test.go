@@ -192,3 +192,59 @@ func TestNodeEndpointsChanged(t *testing.T) {
 		},
 	}, th.ListEndpoints())
 }
+
+func TestServiceEndpointsAdded(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1, true)
+	assert.ElementsMatch(t, convertServiceToEndpoints("test-1", service1V1), th.ListEndpoints())
+	assert.Len(t, th.ListEndpoints(), 2)
+}
+
+func TestServiceEndpointsRemoved(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1, true)
+	th.OnDelete(service1V1)
+	assert.Empty(t, th.ListEndpoints())
+}
+
+func TestServiceEndpointsChanged(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1, true)
+
+	// Labels changed.
+	th.OnUpdate(service1V1, service1V2)
+	assert.ElementsMatch(t, convertServiceToEndpoints("test-1", service1V2), th.ListEndpoints())
+
+	// Port removed.
+	noDNS := service1V2.DeepCopy()
+	noDNS.Spec.Ports = noDNS.Spec.Ports[:1]
+	th.OnUpdate(service1V2, noDNS)
+	assert.ElementsMatch(t, []observer.Endpoint{
+		{
+			ID:     "test-1/service1-UID/http(80)",
+			Target: "10.0.0.1:80",
+			Details: &observer.K8sService{
+				Name:        "service1",
+				UID:         "service1-UID",
+				Labels:      map[string]string{"env": "prod", "service-version": "2"},
+				Annotations: map[string]string{"annotation-key": "annotation-value"},
+				Namespace:   "default",
+				ServiceType: "ClusterIP",
+				ClusterIP:   "10.0.0.1",
+				PortName:    "http",
+				Port:        80,
+				Transport:   observer.ProtocolTCP,
+			},
+		},
+	}, th.ListEndpoints())
+}
+
+func TestIngressEndpointsAddedAndRemoved(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress1V1, true)
+	assert.ElementsMatch(t, convertIngressToEndpoints("test-1", ingress1V1), th.ListEndpoints())
+	assert.Len(t, th.ListEndpoints(), 2)
+
+	th.OnDelete(ingress1V1)
+	assert.Empty(t, th.ListEndpoints())
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// convertIngressToEndpoints converts an ingress instance into a slice of k8s.ingress endpoints, one
+// for each HTTP path of the ingress rules. Rules without a host use the first load-balancer address
+// reported in the ingress status, and are skipped if there is none. The Target is the URL of the path.
+func convertIngressToEndpoints(idNamespace string, ingress *networkingv1.Ingress) []observer.Endpoint {
+	ingressID := observer.EndpointID(fmt.Sprintf("%s/%s", idNamespace, ingress.UID))
+
+	var loadBalancerHost string
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.Hostname != "" {
+			loadBalancerHost = lb.Hostname
+			break
+		}
+		if lb.IP != "" {
+			loadBalancerHost = lb.IP
+			break
+		}
+	}
+
+	var endpoints []observer.Endpoint
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		host := rule.Host
+		if host == "" {
+			host = loadBalancerHost
+		}
+		if host == "" {
+			continue
+		}
+		scheme := getScheme(ingress.Spec.TLS, rule.Host)
+
+		for _, path := range rule.HTTP.Paths {
+			p := path.Path
+			if p == "" {
+				p = "/"
+			}
+			endpoints = append(endpoints, observer.Endpoint{
+				ID:     observer.EndpointID(fmt.Sprintf("%s/%s%s", ingressID, host, p)),
+				Target: fmt.Sprintf("%s://%s%s", scheme, host, p),
+				Details: &observer.K8sIngress{
+					Name:        ingress.Name,
+					UID:         string(ingress.UID),
+					Labels:      ingress.Labels,
+					Annotations: ingress.Annotations,
+					Namespace:   ingress.Namespace,
+					Scheme:      scheme,
+					Host:        host,
+					Path:        p,
+				},
+			})
+		}
+	}
+
+	return endpoints
+}
+
+// getScheme returns "https" if the host is listed in one of the ingress TLS entries, "http" otherwise.
+func getScheme(tls []networkingv1.IngressTLS, host string) string {
+	for _, t := range tls {
+		for _, h := range t.Hosts {
+			if h == host {
+				return "https"
+			}
+		}
+	}
+	return "http"
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestIngressObjectToK8sIngressEndpoints(t *testing.T) {
+	ingressDetails := func(scheme, host, path string) *observer.K8sIngress {
+		return &observer.K8sIngress{
+			Name:        "ingress1",
+			UID:         "ingress1-UID",
+			Labels:      map[string]string{"env": "prod"},
+			Annotations: map[string]string{"annotation-key": "annotation-value"},
+			Namespace:   "default",
+			Scheme:      scheme,
+			Host:        host,
+			Path:        path,
+		}
+	}
+
+	endpoints := convertIngressToEndpoints("namespace", NewIngress("ingress1", "host.example.com"))
+	require.Equal(t, []observer.Endpoint{
+		{
+			ID:      "namespace/ingress1-UID/host.example.com/api",
+			Target:  "https://host.example.com/api",
+			Details: ingressDetails("https", "host.example.com", "/api"),
+		},
+		{
+			ID:      "namespace/ingress1-UID/1.2.3.4/",
+			Target:  "http://1.2.3.4/",
+			Details: ingressDetails("http", "1.2.3.4", "/"),
+		},
+	}, endpoints)
+
+	// Rules without a host are skipped if the load-balancer address isn't known.
+	pending := NewIngress("ingress1", "host.example.com")
+	pending.Spec.TLS = nil
+	pending.Status = networkingv1.IngressStatus{}
+	endpoints = convertIngressToEndpoints("namespace", pending)
+	require.Equal(t, []observer.Endpoint{
+		{
+			ID:      "namespace/ingress1-UID/host.example.com/api",
+			Target:  "http://host.example.com/api",
+			Details: ingressDetails("http", "host.example.com", "/api"),
+		},
+	}, endpoints)
+}
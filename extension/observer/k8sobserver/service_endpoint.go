@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// convertServiceToEndpoints converts a service instance into a slice of k8s.service endpoints, one
+// for each service port. The Target is the cluster IP and port, or the service DNS name and port
+// for headless and ExternalName services.
+func convertServiceToEndpoints(idNamespace string, service *v1.Service) []observer.Endpoint {
+	serviceID := observer.EndpointID(fmt.Sprintf("%s/%s", idNamespace, service.UID))
+
+	host := service.Spec.ClusterIP
+	if host == "" || host == v1.ClusterIPNone {
+		host = fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+	}
+
+	var endpoints []observer.Endpoint
+	for _, port := range service.Spec.Ports {
+		endpoints = append(endpoints, observer.Endpoint{
+			ID:     observer.EndpointID(fmt.Sprintf("%s/%s(%d)", serviceID, port.Name, port.Port)),
+			Target: fmt.Sprintf("%s:%d", host, port.Port),
+			Details: &observer.K8sService{
+				Name:        service.Name,
+				UID:         string(service.UID),
+				Labels:      service.Labels,
+				Annotations: service.Annotations,
+				Namespace:   service.Namespace,
+				ServiceType: string(service.Spec.Type),
+				ClusterIP:   service.Spec.ClusterIP,
+				PortName:    port.Name,
+				Port:        uint16(port.Port),
+				Transport:   getTransport(port.Protocol),
+			},
+		})
+	}
+
+	return endpoints
+}
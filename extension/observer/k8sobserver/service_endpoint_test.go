@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestServiceObjectToK8sServiceEndpoints(t *testing.T) {
+	serviceDetails := func(clusterIP, portName string, port uint16, transport observer.Transport) *observer.K8sService {
+		return &observer.K8sService{
+			Name:        "service1",
+			UID:         "service1-UID",
+			Labels:      map[string]string{"env": "prod"},
+			Annotations: map[string]string{"annotation-key": "annotation-value"},
+			Namespace:   "default",
+			ServiceType: "ClusterIP",
+			ClusterIP:   clusterIP,
+			PortName:    portName,
+			Port:        port,
+			Transport:   transport,
+		}
+	}
+
+	endpoints := convertServiceToEndpoints("namespace", NewService("service1", "10.0.0.1"))
+	require.Equal(t, []observer.Endpoint{
+		{
+			ID:      "namespace/service1-UID/http(80)",
+			Target:  "10.0.0.1:80",
+			Details: serviceDetails("10.0.0.1", "http", 80, observer.ProtocolTCP),
+		},
+		{
+			ID:      "namespace/service1-UID/dns(53)",
+			Target:  "10.0.0.1:53",
+			Details: serviceDetails("10.0.0.1", "dns", 53, observer.ProtocolUDP),
+		},
+	}, endpoints)
+
+	headless := NewService("service1", v1.ClusterIPNone)
+	headless.Spec.Ports = headless.Spec.Ports[:1]
+	endpoints = convertServiceToEndpoints("namespace", headless)
+	require.Equal(t, []observer.Endpoint{
+		{
+			ID:      "namespace/service1-UID/http(80)",
+			Target:  "service1.default.svc:80",
+			Details: serviceDetails(v1.ClusterIPNone, "http", 80, observer.ProtocolTCP),
+		},
+	}, endpoints)
+}
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package xmlutil decodes XML documents for the OTTL ParseXML converter
+// and the stanza xml_parser operator.
+package xmlutil // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/xmlutil"
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// TagKey is the key of the name of an element.
+	TagKey = "tag"
+	// AttributesKey is the key of the map of the attributes of an element.
+	AttributesKey = "attributes"
+	// ContentKey is the key of the text of an element.
+	ContentKey = "content"
+	// ChildrenKey is the key of the list of the child elements of an element.
+	ChildrenKey = "children"
+)
+
+// Element is a decoded XML element.
+type Element struct {
+	// Tag is the name of the element.
+	Tag string
+	// Attributes are the attributes of the element, in the order of the document.
+	Attributes []Attribute
+	// Content is the text of the element without the surrounding whitespace.
+	Content string
+	// Children are the child elements of the element.
+	Children []*Element
+}
+
+// Attribute is an attribute of a decoded XML element.
+type Attribute struct {
+	Name  string
+	Value string
+}
+
+// Parse decodes a document with a single root element and returns its root element.
+// Namespace prefixes are removed from the names and namespace declarations are dropped,
+// unless keepPrefix is set, which keeps the names and declarations as written in the document.
+func Parse(document string, keepPrefix bool) (*Element, error) {
+	d := &decoder{decoder: xml.NewDecoder(strings.NewReader(document)), keepPrefix: keepPrefix}
+	root, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+	return d.toElement(root), nil
+}
+
+// Decode decodes a document with a single root element and returns the map of the root element.
+// Each element is converted into a map holding:
+//
+//	tag        -> the name of the element
+//	attributes -> a map of the attributes of the element, if any
+//	content    -> the text of the element without the surrounding whitespace, if any
+//	children   -> a list of the maps of the child elements, if any
+//
+// Namespaces are handled as by Parse.
+func Decode(document string, keepPrefix bool) (map[string]interface{}, error) {
+	root, err := Parse(document, keepPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return root.ToMap(), nil
+}
+
+// ToMap converts the element into the map layout described by Decode.
+func (e *Element) ToMap() map[string]interface{} {
+	m := map[string]interface{}{TagKey: e.Tag}
+
+	if len(e.Attributes) > 0 {
+		attributes := make(map[string]interface{}, len(e.Attributes))
+		for _, attr := range e.Attributes {
+			attributes[attr.Name] = attr.Value
+		}
+		m[AttributesKey] = attributes
+	}
+
+	if e.Content != "" {
+		m[ContentKey] = e.Content
+	}
+
+	if len(e.Children) > 0 {
+		children := make([]interface{}, 0, len(e.Children))
+		for _, child := range e.Children {
+			children = append(children, child.ToMap())
+		}
+		m[ChildrenKey] = children
+	}
+	return m
+}
+
+type element struct {
+	tag        string
+	attributes []xml.Attr
+	content    strings.Builder
+	children   []*element
+}
+type decoder struct {
+	decoder    *xml.Decoder
+	keepPrefix bool
+}
+
+// decode decodes the tokens of the document into a tree of elements,
+// the document must have a single root element.
+func (d *decoder) decode() (*element, error) {
+	var root *element
+	var stack []*element
+	for {
+		token, err := d.nextToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			el := &element{tag: d.name(t.Name), attributes: t.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, el)
+			} else if root != nil {
+				return nil, errors.New("the document has more than one root element")
+			} else {
+				root = el
+			}
+			stack = append(stack, el)
+		case xml.EndElement:
+			if len(stack) == 0 || stack[len(stack)-1].tag != d.name(t.Name) {
+				return nil, fmt.Errorf("unexpected end element </%s>", d.name(t.Name))
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].content.Write(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("the document has no root element")
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("element <%s> is not closed", stack[len(stack)-1].tag)
+	}
+	return root, nil
+}
+
+// nextToken returns the next token of the document. Namespace prefixes are only
+// preserved by raw tokens, which are not checked for matching start and end elements.
+func (d *decoder) nextToken() (xml.Token, error) {
+	if d.keepPrefix {
+		return d.decoder.RawToken()
+	}
+	return d.decoder.Token()
+}
+
+func (d *decoder) name(n xml.Name) string {
+	if d.keepPrefix && n.Space != "" {
+		return n.Space + ":" + n.Local
+	}
+	return n.Local
+}
+
+func (d *decoder) toElement(el *element) *Element {
+	e := &Element{
+		Tag:     el.tag,
+		Content: strings.TrimSpace(el.content.String()),
+	}
+	for _, attr := range el.attributes {
+		if !d.keepPrefix && isNamespaceDeclaration(attr.Name) {
+			continue
+		}
+		e.Attributes = append(e.Attributes, Attribute{Name: d.name(attr.Name), Value: attr.Value})
+	}
+	for _, child := range el.children {
+		e.Children = append(e.Children, d.toElement(child))
+	}
+	return e
+}
+
+// isNamespaceDeclaration reports whether the attribute declares a namespace.
+// Once resolved by the decoder, declarations of prefixes are in the xmlns space.
+func isNamespaceDeclaration(n xml.Name) bool {
+	return n.Space == "xmlns" || (n.Space == "" && n.Local == "xmlns")
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xmlutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		document   string
+		keepPrefix bool
+		expected   map[string]interface{}
+	}{
+		{
+			name:     "text only",
+			document: `<message> hello world </message>`,
+			expected: map[string]interface{}{
+				"tag":     "message",
+				"content": "hello world",
+			},
+		},
+		{
+			name:     "attributes and children",
+			document: `<event id="42"><source host="app-1">billing</source><line>one</line><line>two</line><empty/></event>`,
+			expected: map[string]interface{}{
+				"tag":        "event",
+				"attributes": map[string]interface{}{"id": "42"},
+				"children": []interface{}{
+					map[string]interface{}{
+						"tag":        "source",
+						"attributes": map[string]interface{}{"host": "app-1"},
+						"content":    "billing",
+					},
+					map[string]interface{}{"tag": "line", "content": "one"},
+					map[string]interface{}{"tag": "line", "content": "two"},
+					map[string]interface{}{"tag": "empty"},
+				},
+			},
+		},
+		{
+			name:     "namespaces stripped",
+			document: `<e:Event xmlns:e="urn:events" xmlns="urn:default"><e:EventID e:Qualifiers="0">4624</e:EventID></e:Event>`,
+			expected: map[string]interface{}{
+				"tag": "Event",
+				"children": []interface{}{
+					map[string]interface{}{
+						"tag":        "EventID",
+						"attributes": map[string]interface{}{"Qualifiers": "0"},
+						"content":    "4624",
+					},
+				},
+			},
+		},
+		{
+			name:       "namespace prefixes kept",
+			document:   `<e:Event xmlns:e="urn:events"><e:EventID e:Qualifiers="0">4624</e:EventID></e:Event>`,
+			keepPrefix: true,
+			expected: map[string]interface{}{
+				"tag":        "e:Event",
+				"attributes": map[string]interface{}{"xmlns:e": "urn:events"},
+				"children": []interface{}{
+					map[string]interface{}{
+						"tag":        "e:EventID",
+						"attributes": map[string]interface{}{"e:Qualifiers": "0"},
+						"content":    "4624",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Decode(tt.document, tt.keepPrefix)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, m)
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		document   string
+		keepPrefix bool
+		err        string
+	}{
+		{"empty", "", false, "the document has no root element"},
+		{"multiple roots", "<a/><b/>", false, "the document has more than one root element"},
+		{"unclosed", "<a><b></b>", false, "XML syntax error"},
+		{"unclosed with prefixes", "<a><b></b>", true, "element <a> is not closed"},
+		{"mismatched with prefixes", "<a><b></a>", true, "unexpected end element </a>"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.document, tt.keepPrefix)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	root, err := Parse(`<event id="42"> <line>one</line> <line>two</line> </event>`, false)
+	require.NoError(t, err)
+	assert.Equal(t, &Element{
+		Tag:        "event",
+		Attributes: []Attribute{{Name: "id", Value: "42"}},
+		Children: []*Element{
+			{Tag: "line", Content: "one"},
+			{Tag: "line", Content: "two"},
+		},
+	}, root)
+}
@@ -164,6 +164,33 @@ func standardFuncs[K any]() map[string]ottl.Factory[K] {
 		ottlfuncs.NewLogFactory[K](),
 		ottlfuncs.NewUUIDFactory[K](),
 		ottlfuncs.NewParseJSONFactory[K](),
+		ottlfuncs.NewTimeFactory[K](),
+		ottlfuncs.NewNowFactory[K](),
+		ottlfuncs.NewDurationFactory[K](),
+		ottlfuncs.NewUnixNanoFactory[K](),
+		ottlfuncs.NewUnixSecondsFactory[K](),
+		ottlfuncs.NewFormatTimeFactory[K](),
+		ottlfuncs.NewParseKeyValueFactory[K](),
+		ottlfuncs.NewExtractPatternsFactory[K](),
+		ottlfuncs.NewParseXMLFactory[K](),
+		ottlfuncs.NewSHA256Factory[K](),
+		ottlfuncs.NewSHA1Factory[K](),
+		ottlfuncs.NewMD5Factory[K](),
+		ottlfuncs.NewFNVFactory[K](),
+		ottlfuncs.NewBase64EncodeFactory[K](),
+		ottlfuncs.NewBase64DecodeFactory[K](),
+		ottlfuncs.NewHexFactory[K](),
+		ottlfuncs.NewIsStringFactory[K](),
+		ottlfuncs.NewIsMapFactory[K](),
+		ottlfuncs.NewIsListFactory[K](),
+		ottlfuncs.NewIsIntFactory[K](),
+		ottlfuncs.NewIsDoubleFactory[K](),
+		ottlfuncs.NewIsBoolFactory[K](),
+		ottlfuncs.NewLenFactory[K](),
+		ottlfuncs.NewKeysFactory[K](),
+		ottlfuncs.NewValuesFactory[K](),
+		ottlfuncs.NewDoubleFactory[K](),
+		ottlfuncs.NewStringFactory[K](),
 		newDropFactory[K](),
 	)
 }
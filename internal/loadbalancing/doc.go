@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package loadbalancing resolves the endpoints of a set of backends and assigns
+// keys to them using consistent hashing. It is shared by the load balancing exporter
+// and the tail sampling processor, so both spread the same keys over the same endpoints.
+package loadbalancing // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
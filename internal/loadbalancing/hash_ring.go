@@ -1,7 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+package loadbalancing // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
 
 import (
 	"hash/crc32"
@@ -21,22 +21,23 @@ type ringItem struct {
 	endpoint string
 }
 
-// hashRing is a consistent hash ring following Karger et al.
-type hashRing struct {
+// HashRing is a consistent hash ring following Karger et al.
+type HashRing struct {
 	// ringItems holds all the positions, used for the lookup the position for the closest next ring item
 	items []ringItem
 }
 
-// newHashRing builds a new immutable consistent hash ring based on the given endpoints.
-func newHashRing(endpoints []string) *hashRing {
+// NewHashRing builds a new immutable consistent hash ring based on the given endpoints.
+func NewHashRing(endpoints []string) *HashRing {
 	items := positionsForEndpoints(endpoints, defaultWeight)
-	return &hashRing{
+	return &HashRing{
 		items: items,
 	}
 }
 
-// endpointFor calculates which backend is responsible for the given traceID
-func (h *hashRing) endpointFor(identifier []byte) string {
+// EndpointFor calculates which endpoint is responsible for the given identifier,
+// or returns an empty string when the ring has no endpoints.
+func (h *HashRing) EndpointFor(identifier []byte) string {
 	if h == nil {
 		// perhaps the ring itself couldn't get initialized yet?
 		return ""
@@ -50,7 +51,7 @@ func (h *hashRing) endpointFor(identifier []byte) string {
 }
 
 // findEndpoint returns the "next" endpoint starting from the given position, or an empty string in case no endpoints are available
-func (h *hashRing) findEndpoint(pos position) string {
+func (h *HashRing) findEndpoint(pos position) string {
 	ringSize := len(h.items)
 	if ringSize == 0 {
 		return ""
@@ -141,9 +142,10 @@ func positionsForEndpoints(endpoints []string, weight int) []ringItem {
 	return items
 }
 
-func (h *hashRing) equal(candidate *hashRing) bool {
-	if candidate == nil {
-		return false
+// Equal returns whether both rings have the same endpoints at the same positions.
+func (h *HashRing) Equal(candidate *HashRing) bool {
+	if h == nil || candidate == nil {
+		return h == candidate
 	}
 
 	if len(h.items) != len(candidate.items) {
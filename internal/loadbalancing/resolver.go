@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancing // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
+
+import (
+	"context"
+	"net"
+)
+
+// Resolver determines the contract for sources of endpoint information.
+type Resolver interface {
+	// Resolve returns the current list of endpoints.
+	// returns either a non-nil error and a nil list of endpoints, or a non-nil list of endpoints and nil error.
+	Resolve(context.Context) ([]string, error)
+
+	// Start signals the resolver to start its work, the callbacks registered
+	// with OnChange are invoked with the initial list of endpoints.
+	Start(context.Context) error
+
+	// Shutdown signals the resolver to finish its work. This should block until the current resolutions are finished.
+	// Once this is invoked, callbacks will not be triggered anymore and will need to be registered again in case the consumer
+	// decides to restart the resolver.
+	Shutdown(context.Context) error
+
+	// OnChange registers a function to call back whenever the list of endpoints is updated.
+	// Make sure to register the callbacks before starting the resolver.
+	OnChange(func([]string))
+}
+
+// Observer is notified of the resolutions of a Resolver, e.g. to record metrics about them.
+type Observer interface {
+	// OnResolution is called after every resolution, with the error of the failed ones.
+	OnResolution(ctx context.Context, err error)
+
+	// OnEndpointsChange is called whenever the list of resolved endpoints changes.
+	OnEndpointsChange(ctx context.Context, endpoints []string)
+}
+
+// NetResolver looks up the IP addresses of a host, as net.Resolver does.
+type NetResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// Option configures a Resolver.
+type Option func(*options)
+
+type options struct {
+	observer    Observer
+	netResolver NetResolver
+}
+
+// WithObserver notifies the given observer of the resolutions of the resolver.
+func WithObserver(observer Observer) Option {
+	return func(o *options) {
+		o.observer = observer
+	}
+}
+
+// WithNetResolver makes the DNS resolver look up the IP addresses of its hostname
+// with the given resolver instead of the one of the net package.
+func WithNetResolver(resolver NetResolver) Option {
+	return func(o *options) {
+		o.netResolver = resolver
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		observer:    nopObserver{},
+		netResolver: &net.Resolver{},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+type nopObserver struct{}
+
+func (nopObserver) OnResolution(context.Context, error) {}
+
+func (nopObserver) OnEndpointsChange(context.Context, []string) {}
@@ -1,63 +1,57 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+package loadbalancing // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
 
 import (
 	"context"
 	"errors"
 	"fmt"
-	"net"
 	"sort"
 	"sync"
 	"time"
 
-	"go.opencensus.io/stats"
-	"go.opencensus.io/tag"
 	"go.uber.org/zap"
 )
 
-var _ resolver = (*dnsResolver)(nil)
+var _ Resolver = (*dnsResolver)(nil)
 
 const (
 	defaultResInterval = 5 * time.Second
 	defaultResTimeout  = time.Second
 )
 
-var (
-	errNoHostname = errors.New("no hostname specified to resolve the backends")
-
-	resolverMutator = tag.Upsert(tag.MustNewKey("resolver"), "dns")
-
-	resolverSuccessTrueMutators  = []tag.Mutator{resolverMutator, successTrueMutator}
-	resolverSuccessFalseMutators = []tag.Mutator{resolverMutator, successFalseMutator}
-)
+// ErrNoHostname occurs when the DNS resolver is given no hostname.
+var ErrNoHostname = errors.New("no hostname specified to resolve the endpoints")
 
 type dnsResolver struct {
 	logger *zap.Logger
 
 	hostname    string
 	port        string
-	resolver    netResolver
+	resolver    NetResolver
+	observer    Observer
 	resInterval time.Duration
 	resTimeout  time.Duration
 
 	endpoints         []string
 	onChangeCallbacks []func([]string)
 
-	stopCh             chan (struct{})
+	stopCh             chan struct{}
 	updateLock         sync.Mutex
 	shutdownWg         sync.WaitGroup
 	changeCallbackLock sync.RWMutex
 }
 
-type netResolver interface {
-	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+// NewDNSResolver returns a Resolver periodically resolving the IP addresses of the given hostname,
+// the port being appended to each of them when set.
+func NewDNSResolver(logger *zap.Logger, hostname string, port string, interval time.Duration, timeout time.Duration, opts ...Option) (Resolver, error) {
+	return newDNSResolver(logger, hostname, port, interval, timeout, opts...)
 }
 
-func newDNSResolver(logger *zap.Logger, hostname string, port string, interval time.Duration, timeout time.Duration) (*dnsResolver, error) {
+func newDNSResolver(logger *zap.Logger, hostname string, port string, interval time.Duration, timeout time.Duration, opts ...Option) (*dnsResolver, error) {
 	if len(hostname) == 0 {
-		return nil, errNoHostname
+		return nil, ErrNoHostname
 	}
 	if interval == 0 {
 		interval = defaultResInterval
@@ -66,22 +60,25 @@ func newDNSResolver(logger *zap.Logger, hostname string, port string, interval t
 		timeout = defaultResTimeout
 	}
 
+	o := newOptions(opts)
 	return &dnsResolver{
 		logger:      logger,
 		hostname:    hostname,
 		port:        port,
-		resolver:    &net.Resolver{},
+		resolver:    o.netResolver,
+		observer:    o.observer,
 		resInterval: interval,
 		resTimeout:  timeout,
 		stopCh:      make(chan struct{}),
 	}, nil
 }
 
-func (r *dnsResolver) start(ctx context.Context) error {
-	if _, err := r.resolve(ctx); err != nil {
+func (r *dnsResolver) Start(ctx context.Context) error {
+	if _, err := r.Resolve(ctx); err != nil {
 		r.logger.Warn("failed to resolve", zap.Error(err))
 	}
 
+	r.shutdownWg.Add(1)
 	go r.periodicallyResolve()
 
 	r.logger.Debug("DNS resolver started",
@@ -90,7 +87,7 @@ func (r *dnsResolver) start(ctx context.Context) error {
 	return nil
 }
 
-func (r *dnsResolver) shutdown(ctx context.Context) error {
+func (r *dnsResolver) Shutdown(context.Context) error {
 	r.changeCallbackLock.Lock()
 	r.onChangeCallbacks = nil
 	r.changeCallbackLock.Unlock()
@@ -101,13 +98,15 @@ func (r *dnsResolver) shutdown(ctx context.Context) error {
 }
 
 func (r *dnsResolver) periodicallyResolve() {
+	defer r.shutdownWg.Done()
 	ticker := time.NewTicker(r.resInterval)
+	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
 			ctx, cancel := context.WithTimeout(context.Background(), r.resTimeout)
-			if _, err := r.resolve(ctx); err != nil {
+			if _, err := r.Resolve(ctx); err != nil {
 				r.logger.Warn("failed to resolve", zap.Error(err))
 			} else {
 				r.logger.Debug("resolved successfully")
@@ -119,48 +118,43 @@ func (r *dnsResolver) periodicallyResolve() {
 	}
 }
 
-func (r *dnsResolver) resolve(ctx context.Context) ([]string, error) {
-	r.shutdownWg.Add(1)
-	defer r.shutdownWg.Done()
-
+func (r *dnsResolver) Resolve(ctx context.Context) ([]string, error) {
 	addrs, err := r.resolver.LookupIPAddr(ctx, r.hostname)
+	r.observer.OnResolution(ctx, err)
 	if err != nil {
-		_ = stats.RecordWithTags(ctx, resolverSuccessFalseMutators, mNumResolutions.M(1))
 		return nil, err
 	}
 
-	_ = stats.RecordWithTags(ctx, resolverSuccessTrueMutators, mNumResolutions.M(1))
-
-	var backends []string
+	var endpoints []string
 	for _, ip := range addrs {
-		var backend string
+		var endpoint string
 		if ip.IP.To4() != nil {
-			backend = ip.String()
+			endpoint = ip.String()
 		} else {
 			// it's an IPv6 address
-			backend = fmt.Sprintf("[%s]", ip.String())
+			endpoint = fmt.Sprintf("[%s]", ip.String())
 		}
 
 		// if a port is specified in the configuration, add it
 		if r.port != "" {
-			backend = fmt.Sprintf("%s:%s", backend, r.port)
+			endpoint = fmt.Sprintf("%s:%s", endpoint, r.port)
 		}
 
-		backends = append(backends, backend)
+		endpoints = append(endpoints, endpoint)
 	}
 
 	// keep it always in the same order
-	sort.Strings(backends)
+	sort.Strings(endpoints)
 
-	if equalStringSlice(r.endpoints, backends) {
+	r.updateLock.Lock()
+	defer r.updateLock.Unlock()
+	if equalStringSlice(r.endpoints, endpoints) {
 		return r.endpoints, nil
 	}
 
 	// the list has changed!
-	r.updateLock.Lock()
-	r.endpoints = backends
-	r.updateLock.Unlock()
-	_ = stats.RecordWithTags(ctx, resolverSuccessTrueMutators, mNumBackends.M(int64(len(backends))))
+	r.endpoints = endpoints
+	r.observer.OnEndpointsChange(ctx, endpoints)
 
 	// propagate the change
 	r.changeCallbackLock.RLock()
@@ -172,7 +166,7 @@ func (r *dnsResolver) resolve(ctx context.Context) ([]string, error) {
 	return r.endpoints, nil
 }
 
-func (r *dnsResolver) onChange(f func([]string)) {
+func (r *dnsResolver) OnChange(f func([]string)) {
 	r.changeCallbackLock.Lock()
 	defer r.changeCallbackLock.Unlock()
 	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
@@ -187,6 +181,5 @@ func equalStringSlice(source, candidate []string) bool {
 			return false
 		}
 	}
-
 	return true
 }
@@ -1,7 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package loadbalancingexporter
+package loadbalancing
 
 import (
 	"context"
@@ -34,12 +34,12 @@ func TestInitialDNSResolution(t *testing.T) {
 
 	// test
 	var resolved []string
-	res.onChange(func(endpoints []string) {
+	res.OnChange(func(endpoints []string) {
 		resolved = endpoints
 	})
-	require.NoError(t, res.start(context.Background()))
+	require.NoError(t, res.Start(context.Background()))
 	defer func() {
-		require.NoError(t, res.shutdown(context.Background()))
+		require.NoError(t, res.Shutdown(context.Background()))
 	}()
 
 	// verify
@@ -66,12 +66,12 @@ func TestInitialDNSResolutionWithPort(t *testing.T) {
 
 	// test
 	var resolved []string
-	res.onChange(func(endpoints []string) {
+	res.OnChange(func(endpoints []string) {
 		resolved = endpoints
 	})
-	require.NoError(t, res.start(context.Background()))
+	require.NoError(t, res.Start(context.Background()))
 	defer func() {
-		require.NoError(t, res.shutdown(context.Background()))
+		require.NoError(t, res.Shutdown(context.Background()))
 	}()
 
 	// verify
@@ -87,7 +87,7 @@ func TestErrNoHostname(t *testing.T) {
 
 	// verify
 	assert.Nil(t, res)
-	assert.Equal(t, errNoHostname, err)
+	assert.Equal(t, ErrNoHostname, err)
 }
 
 func TestCantResolve(t *testing.T) {
@@ -103,7 +103,7 @@ func TestCantResolve(t *testing.T) {
 	}
 
 	// test
-	require.NoError(t, res.start(context.Background()))
+	require.NoError(t, res.Start(context.Background()))
 
 	// verify
 	assert.NoError(t, err)
@@ -125,17 +125,17 @@ func TestOnChange(t *testing.T) {
 
 	// test
 	counter := &atomic.Int64{}
-	res.onChange(func(endpoints []string) {
+	res.OnChange(func(endpoints []string) {
 		counter.Add(1)
 	})
-	require.NoError(t, res.start(context.Background()))
+	require.NoError(t, res.Start(context.Background()))
 	defer func() {
-		require.NoError(t, res.shutdown(context.Background()))
+		require.NoError(t, res.Shutdown(context.Background()))
 	}()
 	require.Equal(t, int64(1), counter.Load())
 
 	// now, we run it with the same IPs being resolved, which shouldn't trigger a onChange call
-	_, err = res.resolve(context.Background())
+	_, err = res.Resolve(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, int64(1), counter.Load())
 
@@ -144,7 +144,7 @@ func TestOnChange(t *testing.T) {
 		{IP: net.IPv4(127, 0, 0, 2)},
 		{IP: net.IPv4(127, 0, 0, 3)},
 	}
-	_, err = res.resolve(context.Background())
+	_, err = res.Resolve(context.Background())
 	require.NoError(t, err)
 	assert.Equal(t, int64(2), counter.Load())
 }
@@ -215,15 +215,15 @@ func TestPeriodicallyResolve(t *testing.T) {
 	}
 
 	wg := sync.WaitGroup{}
-	res.onChange(func(backends []string) {
+	res.OnChange(func(backends []string) {
 		wg.Done()
 	})
 
 	// test
 	wg.Add(3)
-	require.NoError(t, res.start(context.Background()))
+	require.NoError(t, res.Start(context.Background()))
 	defer func() {
-		require.NoError(t, res.shutdown(context.Background()))
+		require.NoError(t, res.Shutdown(context.Background()))
 	}()
 
 	// wait for three resolutions: from the start, and two periodic resolutions
@@ -264,9 +264,9 @@ func TestPeriodicallyResolveFailure(t *testing.T) {
 
 	// test
 	wg.Add(2)
-	require.NoError(t, res.start(context.Background()))
+	require.NoError(t, res.Start(context.Background()))
 	defer func() {
-		require.NoError(t, res.shutdown(context.Background()))
+		require.NoError(t, res.Shutdown(context.Background()))
 	}()
 
 	// wait for two resolutions: from the start, and one periodic
@@ -283,25 +283,25 @@ func TestShutdownClearsCallbacks(t *testing.T) {
 	require.NoError(t, err)
 
 	res.resolver = &mockDNSResolver{}
-	res.onChange(func(s []string) {})
-	require.NoError(t, res.start(context.Background()))
+	res.OnChange(func(s []string) {})
+	require.NoError(t, res.Start(context.Background()))
 
 	// sanity check
 	require.Len(t, res.onChangeCallbacks, 1)
 
 	// test
-	err = res.shutdown(context.Background())
+	err = res.Shutdown(context.Background())
 
 	// verify
 	assert.NoError(t, err)
 	assert.Len(t, res.onChangeCallbacks, 0)
 
 	// check that we can add a new onChange before a new start
-	res.onChange(func(s []string) {})
+	res.OnChange(func(s []string) {})
 	assert.Len(t, res.onChangeCallbacks, 1)
 }
 
-var _ netResolver = (*mockDNSResolver)(nil)
+var _ NetResolver = (*mockDNSResolver)(nil)
 
 type mockDNSResolver struct {
 	net.Resolver
@@ -314,3 +314,48 @@ func (m *mockDNSResolver) LookupIPAddr(ctx context.Context, hostname string) ([]
 	}
 	return nil, nil
 }
+
+func TestDNSResolverObserver(t *testing.T) {
+	// prepare
+	expectedErr := errors.New("some expected error")
+	resolve := []net.IPAddr{{IP: net.IPv4(127, 0, 0, 1)}}
+	var lookupErr error
+	observer := &mockObserver{}
+	res, err := newDNSResolver(zap.NewNop(), "service-1", "", 5*time.Second, 1*time.Second,
+		WithObserver(observer),
+		WithNetResolver(&mockDNSResolver{
+			onLookupIPAddr: func(context.Context, string) ([]net.IPAddr, error) {
+				if lookupErr != nil {
+					return nil, lookupErr
+				}
+				return resolve, nil
+			},
+		}))
+	require.NoError(t, err)
+
+	// test
+	_, err = res.Resolve(context.Background())
+	require.NoError(t, err)
+	_, err = res.Resolve(context.Background())
+	require.NoError(t, err)
+	lookupErr = expectedErr
+	_, err = res.Resolve(context.Background())
+	require.Equal(t, expectedErr, err)
+
+	// verify
+	assert.Equal(t, []error{nil, nil, expectedErr}, observer.resolutions)
+	assert.Equal(t, [][]string{{"127.0.0.1"}}, observer.changes)
+}
+
+type mockObserver struct {
+	resolutions []error
+	changes     [][]string
+}
+
+func (m *mockObserver) OnResolution(_ context.Context, err error) {
+	m.resolutions = append(m.resolutions, err)
+}
+
+func (m *mockObserver) OnEndpointsChange(_ context.Context, endpoints []string) {
+	m.changes = append(m.changes, endpoints)
+}
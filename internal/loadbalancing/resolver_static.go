@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancing // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+var _ Resolver = (*staticResolver)(nil)
+
+// ErrNoEndpoints occurs when the static resolver is given no endpoints.
+var ErrNoEndpoints = errors.New("no endpoints specified for the static resolver")
+
+type staticResolver struct {
+	endpoints         []string
+	observer          Observer
+	onChangeCallbacks []func([]string)
+	once              sync.Once // we trigger the onChange only once
+}
+
+// NewStaticResolver returns a Resolver providing a fixed list of endpoints.
+func NewStaticResolver(endpoints []string, opts ...Option) (Resolver, error) {
+	return newStaticResolver(endpoints, opts...)
+}
+
+func newStaticResolver(endpoints []string, opts ...Option) (*staticResolver, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	// make sure we won't change the provided slice
+	endpointsCopy := make([]string, len(endpoints))
+	copy(endpointsCopy, endpoints)
+
+	// sort is a guarantee that the order of endpoints doesn't matter
+	sort.Strings(endpointsCopy)
+
+	return &staticResolver{
+		endpoints: endpointsCopy,
+		observer:  newOptions(opts).observer,
+	}, nil
+}
+
+func (r *staticResolver) Start(ctx context.Context) error {
+	_, err := r.Resolve(ctx) // right now, this can't fail
+	return err
+}
+
+func (r *staticResolver) Shutdown(context.Context) error {
+	r.onChangeCallbacks = nil
+	return nil
+}
+
+func (r *staticResolver) Resolve(ctx context.Context) ([]string, error) {
+	r.observer.OnResolution(ctx, nil)
+
+	r.once.Do(func() {
+		r.observer.OnEndpointsChange(ctx, r.endpoints)
+
+		for _, callback := range r.onChangeCallbacks {
+			callback(r.endpoints)
+		}
+	})
+	return r.endpoints, nil
+}
+
+func (r *staticResolver) OnChange(f func([]string)) {
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
@@ -1,7 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package loadbalancingexporter
+package loadbalancing
 
 import (
 	"context"
@@ -19,12 +19,12 @@ func TestInitialResolution(t *testing.T) {
 
 	// test
 	var resolved []string
-	res.onChange(func(endpoints []string) {
+	res.OnChange(func(endpoints []string) {
 		resolved = endpoints
 	})
-	require.NoError(t, res.start(context.Background()))
+	require.NoError(t, res.Start(context.Background()))
 	defer func() {
-		require.NoError(t, res.shutdown(context.Background()))
+		require.NoError(t, res.Shutdown(context.Background()))
 	}()
 
 	// verify
@@ -39,16 +39,16 @@ func TestResolvedOnlyOnce(t *testing.T) {
 	require.NoError(t, err)
 
 	counter := 0
-	res.onChange(func(endpoints []string) {
+	res.OnChange(func(endpoints []string) {
 		counter++
 	})
 
 	// test
-	require.NoError(t, res.start(context.Background()))
+	require.NoError(t, res.Start(context.Background()))
 	defer func() {
-		require.NoError(t, res.shutdown(context.Background()))
+		require.NoError(t, res.Shutdown(context.Background()))
 	}()
-	resolved, err := res.resolve(context.Background()) // second resolution, should be noop
+	resolved, err := res.Resolve(context.Background()) // second resolution, should be noop
 
 	// verify
 	assert.NoError(t, err)
@@ -64,6 +64,22 @@ func TestFailOnMissingEndpoints(t *testing.T) {
 	res, err := newStaticResolver(expected)
 
 	// verify
-	assert.Equal(t, errNoEndpoints, err)
+	assert.Equal(t, ErrNoEndpoints, err)
 	assert.Nil(t, res)
 }
+
+func TestStaticResolverObserver(t *testing.T) {
+	// prepare
+	observer := &mockObserver{}
+	res, err := newStaticResolver([]string{"endpoint-2", "endpoint-1"}, WithObserver(observer))
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, res.Start(context.Background()))
+	_, err = res.Resolve(context.Background())
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, []error{nil, nil}, observer.resolutions)
+	assert.Equal(t, [][]string{{"endpoint-1", "endpoint-2"}}, observer.changes)
+}
@@ -5,6 +5,7 @@ package ottl // import "github.com/open-telemetry/opentelemetry-collector-contri
 
 import (
 	"bytes"
+	"time"
 
 	"go.uber.org/zap"
 	"golang.org/x/exp/constraints"
@@ -12,7 +13,7 @@ import (
 
 // The functions in this file implement a general-purpose comparison of two
 // values of type any, which for the purposes of OTTL mean values that are one of
-// int, float, string, bool, or pointers to those, or []byte, or nil.
+// int, float, string, bool, or pointers to those, or []byte, time.Time, time.Duration, or nil.
 
 // invalidComparison returns false for everything except NE (where it returns true to indicate that the
 // objects were definitely not equivalent).
@@ -135,6 +136,43 @@ func (p *Parser[K]) compareFloat64(a float64, b any, op compareOp) bool {
 	}
 }
 
+func compareTimes(a time.Time, b time.Time, op compareOp) bool {
+	switch op {
+	case EQ:
+		return a.Equal(b)
+	case NE:
+		return !a.Equal(b)
+	case LT:
+		return a.Before(b)
+	case LTE:
+		return a.Before(b) || a.Equal(b)
+	case GTE:
+		return a.After(b) || a.Equal(b)
+	case GT:
+		return a.After(b)
+	default:
+		return false
+	}
+}
+
+func (p *Parser[K]) compareTime(a time.Time, b any, op compareOp) bool {
+	switch v := b.(type) {
+	case time.Time:
+		return compareTimes(a, v, op)
+	default:
+		return p.invalidComparison("time to non-time value", op)
+	}
+}
+
+func (p *Parser[K]) compareDuration(a time.Duration, b any, op compareOp) bool {
+	switch v := b.(type) {
+	case time.Duration:
+		return comparePrimitives(a, v, op)
+	default:
+		return p.invalidComparison("duration to non-duration value", op)
+	}
+}
+
 // a and b are the return values from a Getter; we try to compare them
 // according to the given operator.
 func (p *Parser[K]) compare(a any, b any, op compareOp) bool {
@@ -157,6 +195,10 @@ func (p *Parser[K]) compare(a any, b any, op compareOp) bool {
 		return p.compareFloat64(v, b, op)
 	case string:
 		return p.compareString(v, b, op)
+	case time.Time:
+		return p.compareTime(v, b, op)
+	case time.Duration:
+		return p.compareDuration(v, b, op)
 	case []byte:
 		if v == nil {
 			return p.compare(b, nil, op)
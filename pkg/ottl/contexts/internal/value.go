@@ -55,6 +55,8 @@ func SetValue(value pcommon.Value, val interface{}) error {
 		}
 	case pcommon.Map:
 		v.CopyTo(value.SetEmptyMap())
+	case pcommon.Slice:
+		v.CopyTo(value.SetEmptySlice())
 	case map[string]interface{}:
 		value.SetEmptyMap()
 		for mk, mv := range v {
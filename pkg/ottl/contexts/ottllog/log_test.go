@@ -75,6 +75,32 @@ func Test_newPathGetSetter(t *testing.T) {
 				log.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
 			},
 		},
+		{
+			name: "time",
+			path: []ottl.Field{
+				{
+					Name: "time",
+				},
+			},
+			orig:   time.UnixMilli(100).UTC(),
+			newVal: time.UnixMilli(200).UTC(),
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource, cache pcommon.Map) {
+				log.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "observed_time",
+			path: []ottl.Field{
+				{
+					Name: "observed_time",
+				},
+			},
+			orig:   time.UnixMilli(500).UTC(),
+			newVal: time.UnixMilli(200).UTC(),
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource, cache pcommon.Map) {
+				log.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
 		{
 			name: "severity_number",
 			path: []ottl.Field{
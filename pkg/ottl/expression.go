@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strconv"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -101,20 +102,36 @@ func (g exprGetter[K]) Get(ctx context.Context, tCtx K) (interface{}, error) {
 				}
 				result = ottlcommon.GetValue(r.At(int(*k.Int)))
 			case []interface{}:
-				if int(*k.Int) >= len(r) || int(*k.Int) < 0 {
-					return nil, fmt.Errorf("index %v out of bounds", *k.Int)
-				}
-				result = r[*k.Int]
+				result, err = getSliceIndex(r, *k.Int)
+			case []string:
+				result, err = getSliceIndex(r, *k.Int)
+			case []int64:
+				result, err = getSliceIndex(r, *k.Int)
+			case []float64:
+				result, err = getSliceIndex(r, *k.Int)
+			case []bool:
+				result, err = getSliceIndex(r, *k.Int)
 			default:
 				return nil, fmt.Errorf("type, %T, does not support int indexing", result)
 			}
 		default:
 			return nil, fmt.Errorf("neither map nor slice index were set; this is an error in OTTL")
 		}
+		if err != nil {
+			return nil, err
+		}
 	}
 	return result, nil
 }
 
+// getSliceIndex returns the item at the index of a slice returned by a converter.
+func getSliceIndex[T any](s []T, index int64) (interface{}, error) {
+	if int(index) >= len(s) || int(index) < 0 {
+		return nil, fmt.Errorf("index %v out of bounds", index)
+	}
+	return s[index], nil
+}
+
 type listGetter[K any] struct {
 	slice []Getter[K]
 }
@@ -133,6 +150,49 @@ func (l *listGetter[K]) Get(ctx context.Context, tCtx K) (interface{}, error) {
 	return evaluated, nil
 }
 
+type mapGetter[K any] struct {
+	keys   []string
+	values []Getter[K]
+}
+
+func (m *mapGetter[K]) Get(ctx context.Context, tCtx K) (interface{}, error) {
+	result := pcommon.NewMap()
+	result.EnsureCapacity(len(m.keys))
+
+	// The entries are added in the order of the literal, a duplicated key keeping its last value.
+	for i, k := range m.keys {
+		val, err := m.values[i].Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if err = result.PutEmpty(k).FromRaw(asRaw(val)); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// asRaw converts the pdata values nested in the evaluated literals to their raw
+// representation, so that they can be stored in a pcommon.Map.
+func asRaw(val any) any {
+	switch v := val.(type) {
+	case pcommon.Map:
+		return v.AsRaw()
+	case pcommon.Slice:
+		return v.AsRaw()
+	case pcommon.Value:
+		return v.AsRaw()
+	case []any:
+		raw := make([]any, len(v))
+		for i, item := range v {
+			raw[i] = asRaw(item)
+		}
+		return raw
+	default:
+		return v
+	}
+}
+
 // StringGetter is a Getter that must return a string.
 type StringGetter[K any] interface {
 	// Get retrieves a string value.  If the value is not a string, an error is returned.
@@ -151,6 +211,16 @@ type PMapGetter[K any] interface {
 	Get(ctx context.Context, tCtx K) (pcommon.Map, error)
 }
 
+// TimeGetter is a Getter that must return a time.Time.
+type TimeGetter[K any] interface {
+	Get(ctx context.Context, tCtx K) (time.Time, error)
+}
+
+// DurationGetter is a Getter that must return a time.Duration.
+type DurationGetter[K any] interface {
+	Get(ctx context.Context, tCtx K) (time.Duration, error)
+}
+
 type StandardTypeGetter[K any, T any] struct {
 	Getter func(ctx context.Context, tCtx K) (interface{}, error)
 }
@@ -333,6 +403,22 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 		return &lg, nil
 	}
 
+	if val.Map != nil {
+		mg := mapGetter[K]{
+			keys:   make([]string, len(val.Map.Values)),
+			values: make([]Getter[K], len(val.Map.Values)),
+		}
+		for i, item := range val.Map.Values {
+			getter, err := p.newGetter(*item.Value)
+			if err != nil {
+				return nil, err
+			}
+			mg.keys[i] = *item.Key
+			mg.values[i] = getter
+		}
+		return &mg, nil
+	}
+
 	if val.MathExpression == nil {
 		// In practice, can't happen since the DSL grammar guarantees one is set
 		return nil, fmt.Errorf("no value field set. This is a bug in the OpenTelemetry Transformation Language")
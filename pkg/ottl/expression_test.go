@@ -57,6 +57,12 @@ func basicSlice() (ExprFunc[any], error) {
 	}, nil
 }
 
+func stringSlice() (ExprFunc[any], error) {
+	return func(ctx context.Context, tCtx any) (interface{}, error) {
+		return []string{"fail", "pass"}, nil
+	}, nil
+}
+
 func Test_newGetter(t *testing.T) {
 	tests := []struct {
 		name string
@@ -235,6 +241,22 @@ func Test_newGetter(t *testing.T) {
 			},
 			want: "pass",
 		},
+		{
+			name: "function call typed slice",
+			val: value{
+				Literal: &mathExprLiteral{
+					Converter: &converter{
+						Function: "StringSlice",
+						Keys: []Key{
+							{
+								Int: ottltest.Intp(1),
+							},
+						},
+					},
+				},
+			},
+			want: "pass",
+		},
 		{
 			name: "enum",
 			val: value{
@@ -412,6 +434,91 @@ func Test_newGetter(t *testing.T) {
 			},
 			want: []any{"test0", int64(1)},
 		},
+		{
+			name: "map",
+			val: value{
+				Map: &mapValue{
+					Values: []mapItem{
+						{
+							Key: ottltest.Strp("string"),
+							Value: &value{
+								String: ottltest.Strp("str"),
+							},
+						},
+						{
+							Key: ottltest.Strp("int"),
+							Value: &value{
+								Literal: &mathExprLiteral{
+									Int: ottltest.Intp(1),
+								},
+							},
+						},
+						{
+							Key: ottltest.Strp("list"),
+							Value: &value{
+								List: &list{
+									Values: []value{
+										{
+											String: ottltest.Strp("test0"),
+										},
+										{
+											Literal: &mathExprLiteral{
+												Converter: &converter{
+													Function: "PMap",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Key: ottltest.Strp("map"),
+							Value: &value{
+								Map: &mapValue{
+									Values: []mapItem{
+										{
+											Key: ottltest.Strp("bool"),
+											Value: &value{
+												Bool: (*boolean)(ottltest.Boolp(true)),
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Key: ottltest.Strp("converter"),
+							Value: &value{
+								Literal: &mathExprLiteral{
+									Converter: &converter{
+										Function: "PMap",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: func() pcommon.Map {
+				m := pcommon.NewMap()
+				m.PutStr("string", "str")
+				m.PutInt("int", 1)
+				l := m.PutEmptySlice("list")
+				l.AppendEmpty().SetStr("test0")
+				l.AppendEmpty().SetEmptyMap().PutEmptyMap("foo").PutStr("bar", "pass")
+				m.PutEmptyMap("map").PutBool("bool", true)
+				m.PutEmptyMap("converter").PutEmptyMap("foo").PutStr("bar", "pass")
+				return m
+			}(),
+		},
+		{
+			name: "empty map",
+			val: value{
+				Map: &mapValue{},
+			},
+			want: pcommon.NewMap(),
+		},
 	}
 
 	functions := CreateFactoryMap(
@@ -420,6 +527,7 @@ func Test_newGetter(t *testing.T) {
 		createFactory("Map", &struct{}{}, basicMap),
 		createFactory("PSlice", &struct{}{}, pslice),
 		createFactory("Slice", &struct{}{}, basicSlice),
+		createFactory("StringSlice", &struct{}{}, stringSlice),
 	)
 
 	p, _ := NewParser[any](
@@ -553,6 +661,22 @@ func Test_exprGetter_Get_Invalid(t *testing.T) {
 			},
 			err: fmt.Errorf("index -1 out of bounds"),
 		},
+		{
+			name: "index too large for typed Go slice",
+			val: value{
+				Literal: &mathExprLiteral{
+					Converter: &converter{
+						Function: "StringSlice",
+						Keys: []Key{
+							{
+								Int: ottltest.Intp(2),
+							},
+						},
+					},
+				},
+			},
+			err: fmt.Errorf("index 2 out of bounds"),
+		},
 		{
 			name: "invalid int indexing type",
 			val: value{
@@ -593,6 +717,7 @@ func Test_exprGetter_Get_Invalid(t *testing.T) {
 		createFactory("Map", &struct{}{}, basicMap),
 		createFactory("PSlice", &struct{}{}, pslice),
 		createFactory("Slice", &struct{}{}, basicSlice),
+		createFactory("StringSlice", &struct{}{}, stringSlice),
 	)
 
 	p, _ := NewParser[any](
@@ -9,7 +9,9 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/iancoleman/strcase"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
@@ -19,6 +21,55 @@ type EnumParser func(*EnumSymbol) (*Enum, error)
 
 type Enum int64
 
+// Optional is used to represent an optional argument of an OTTL function.
+// An Optional argument must follow all the required arguments of the function,
+// and can be given either by position or by name.
+type Optional[T any] struct {
+	val      T
+	hasValue bool
+}
+
+// IsEmpty returns true if the argument was not given in the statement.
+func (o Optional[T]) IsEmpty() bool {
+	return !o.hasValue
+}
+
+// Get returns the value of the argument, or the zero value of T if the argument was not given.
+func (o Optional[T]) Get() T {
+	return o.val
+}
+
+// NewTestingOptional creates an Optional holding the given value, for use in tests.
+func NewTestingOptional[T any](val T) Optional[T] {
+	return Optional[T]{
+		val:      val,
+		hasValue: true,
+	}
+}
+
+// optionalManager allows the parser to build the Optional arguments through reflection.
+type optionalManager interface {
+	// set returns an Optional holding the given value.
+	set(val any) reflect.Value
+	// unwrap returns the type of the value held by the Optional.
+	unwrap() reflect.Type
+}
+
+func (o Optional[T]) set(val any) reflect.Value {
+	return reflect.ValueOf(Optional[T]{
+		val:      val.(T),
+		hasValue: true,
+	})
+}
+
+func (o Optional[T]) unwrap() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func isOptional(t reflect.Type) bool {
+	return t.Implements(reflect.TypeOf((*optionalManager)(nil)).Elem())
+}
+
 func (p *Parser[K]) newFunctionCall(ed editor) (Expr[K], error) {
 	f, ok := p.functions[ed.Function]
 	if !ok {
@@ -50,8 +101,35 @@ func (p *Parser[K]) newFunctionCall(ed editor) (Expr[K], error) {
 }
 
 func (p *Parser[K]) buildArgs(ed editor, argsVal reflect.Value) error {
-	if len(ed.Arguments) != argsVal.NumField() {
-		return fmt.Errorf("incorrect number of arguments. Expected: %d Received: %d", argsVal.NumField(), len(ed.Arguments))
+	requiredArgs := 0
+	for i := 0; i < argsVal.NumField(); i++ {
+		if !isOptional(argsVal.Field(i).Type()) {
+			requiredArgs++
+		}
+	}
+
+	if len(ed.Arguments) < requiredArgs || len(ed.Arguments) > argsVal.NumField() {
+		if requiredArgs == argsVal.NumField() {
+			return fmt.Errorf("incorrect number of arguments. Expected: %d Received: %d", argsVal.NumField(), len(ed.Arguments))
+		}
+		return fmt.Errorf("incorrect number of arguments. Expected: between %d and %d Received: %d", requiredArgs, argsVal.NumField(), len(ed.Arguments))
+	}
+
+	// Positional arguments come first, the named arguments follow them in any order.
+	positionalArgs := 0
+	namedArgs := map[string]value{}
+	for i, arg := range ed.Arguments {
+		if arg.Name == "" {
+			if len(namedArgs) > 0 {
+				return fmt.Errorf("unnamed argument at position %v used after named arguments", i)
+			}
+			positionalArgs++
+			continue
+		}
+		if _, ok := namedArgs[arg.Name]; ok {
+			return fmt.Errorf("duplicate named argument '%s'", arg.Name)
+		}
+		namedArgs[arg.Name] = arg.Value
 	}
 
 	argsType := argsVal.Type()
@@ -72,23 +150,66 @@ func (p *Parser[K]) buildArgs(ed editor, argsVal reflect.Value) error {
 			return fmt.Errorf("ottlarg struct tag on field '%s' is not a valid integer: %w", argsType.Field(i).Name, err)
 		}
 
-		if argNum < 0 || argNum >= len(ed.Arguments) {
-			return fmt.Errorf("ottlarg struct tag on field '%s' has value %d, but must be between 0 and %d", argsType.Field(i).Name, argNum, len(ed.Arguments))
+		if argNum < 0 || argNum >= argsVal.NumField() {
+			return fmt.Errorf("ottlarg struct tag on field '%s' has value %d, but must be between 0 and %d", argsType.Field(i).Name, argNum, argsVal.NumField()-1)
+		}
+
+		optional := isOptional(fieldType)
+		if optional && argNum < requiredArgs {
+			return fmt.Errorf("optional argument '%s' must be placed after the required arguments", argsType.Field(i).Name)
 		}
 
-		argVal := ed.Arguments[argNum]
+		// Named arguments use the snake case name of their field.
+		argName := strcase.ToSnake(argsType.Field(i).Name)
+		namedVal, named := namedArgs[argName]
+		delete(namedArgs, argName)
+
+		var argVal value
+		switch {
+		case argNum < positionalArgs && named:
+			return fmt.Errorf("argument '%s' given both by position and by name", argName)
+		case argNum < positionalArgs:
+			argVal = ed.Arguments[argNum].Value
+		case named:
+			argVal = namedVal
+		case optional:
+			// The Arguments value is reused by the factory, so clear the previous value.
+			field.Set(reflect.Zero(fieldType))
+			continue
+		default:
+			return fmt.Errorf("missing required argument '%s' at position %v", argName, argNum)
+		}
+
+		argType := fieldType
+		if optional {
+			argType = field.Interface().(optionalManager).unwrap()
+		}
 
 		var val any
-		if fieldType.Kind() == reflect.Slice {
-			val, err = p.buildSliceArg(argVal, fieldType)
+		if argType.Kind() == reflect.Slice {
+			val, err = p.buildSliceArg(argVal, argType)
 		} else {
-			val, err = p.buildArg(argVal, fieldType)
+			val, err = p.buildArg(argVal, argType)
 		}
 
 		if err != nil {
+			if named {
+				return fmt.Errorf("invalid argument '%s': %w", argName, err)
+			}
 			return fmt.Errorf("invalid argument at position %v: %w", i, err)
 		}
-		field.Set(reflect.ValueOf(val))
+		if optional {
+			field.Set(field.Interface().(optionalManager).set(val))
+		} else {
+			field.Set(reflect.ValueOf(val))
+		}
+	}
+
+	// Only the names of unknown arguments remain.
+	for _, arg := range ed.Arguments {
+		if _, ok := namedArgs[arg.Name]; ok {
+			return fmt.Errorf("no argument named '%s'", arg.Name)
+		}
 	}
 
 	return nil
@@ -218,6 +339,18 @@ func (p *Parser[K]) buildArg(argVal value, argType reflect.Type) (any, error) {
 			return nil, err
 		}
 		return StandardTypeGetter[K, pcommon.Map]{Getter: arg.Get}, nil
+	case strings.HasPrefix(name, "TimeGetter"):
+		arg, err := p.newGetter(argVal)
+		if err != nil {
+			return nil, err
+		}
+		return StandardTypeGetter[K, time.Time]{Getter: arg.Get}, nil
+	case strings.HasPrefix(name, "DurationGetter"):
+		arg, err := p.newGetter(argVal)
+		if err != nil {
+			return nil, err
+		}
+		return StandardTypeGetter[K, time.Duration]{Getter: arg.Get}, nil
 	case name == "Enum":
 		arg, err := p.enumParser(argVal.Enum)
 		if err != nil {
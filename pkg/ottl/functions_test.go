@@ -88,6 +88,16 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			&outOfBoundsStructTagFunctionArguments{},
 			functionThatHasAnError,
 		),
+		createFactory[any](
+			"testing_optional_args",
+			&optionalArgsArguments{},
+			functionWithOptionalArgs,
+		),
+		createFactory(
+			"optional_before_required",
+			&optionalBeforeRequiredArguments{},
+			functionThatHasAnError,
+		),
 	)
 
 	p, _ := NewParser(
@@ -105,16 +115,18 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "unknown function",
 			inv: editor{
 				Function:  "unknownfunc",
-				Arguments: []value{},
+				Arguments: []argument{},
 			},
 		},
 		{
 			name: "not accessor",
 			inv: editor{
 				Function: "testing_getsetter",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						String: ottltest.Strp("not path"),
+						Value: value{
+							String: ottltest.Strp("not path"),
+						},
 					},
 				},
 			},
@@ -123,11 +135,13 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "not reader (invalid function)",
 			inv: editor{
 				Function: "testing_getter",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Literal: &mathExprLiteral{
-							Converter: &converter{
-								Function: "Unknownfunc",
+						Value: value{
+							Literal: &mathExprLiteral{
+								Converter: &converter{
+									Function: "Unknownfunc",
+								},
 							},
 						},
 					},
@@ -138,20 +152,24 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "not enough args",
 			inv: editor{
 				Function: "testing_multiple_args",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Literal: &mathExprLiteral{
-							Path: &Path{
-								Fields: []Field{
-									{
-										Name: "name",
+						Value: value{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "name",
+										},
 									},
 								},
 							},
 						},
 					},
 					{
-						String: ottltest.Strp("test"),
+						Value: value{
+							String: ottltest.Strp("test"),
+						},
 					},
 				},
 			},
@@ -160,23 +178,29 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "too many args",
 			inv: editor{
 				Function: "testing_multiple_args",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Literal: &mathExprLiteral{
-							Path: &Path{
-								Fields: []Field{
-									{
-										Name: "name",
+						Value: value{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "name",
+										},
 									},
 								},
 							},
 						},
 					},
 					{
-						String: ottltest.Strp("test"),
+						Value: value{
+							String: ottltest.Strp("test"),
+						},
 					},
 					{
-						String: ottltest.Strp("test"),
+						Value: value{
+							String: ottltest.Strp("test"),
+						},
 					},
 				},
 			},
@@ -185,15 +209,19 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "not enough args with telemetrySettings",
 			inv: editor{
 				Function: "testing_telemetry_settings_first",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						String: ottltest.Strp("test"),
+						Value: value{
+							String: ottltest.Strp("test"),
+						},
 					},
 					{
-						List: &list{
-							Values: []value{
-								{
-									String: ottltest.Strp("test"),
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										String: ottltest.Strp("test"),
+									},
 								},
 							},
 						},
@@ -205,27 +233,35 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "too many args with telemetrySettings",
 			inv: editor{
 				Function: "testing_telemetry_settings_first",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						String: ottltest.Strp("test"),
+						Value: value{
+							String: ottltest.Strp("test"),
+						},
 					},
 					{
-						List: &list{
-							Values: []value{
-								{
-									String: ottltest.Strp("test"),
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										String: ottltest.Strp("test"),
+									},
 								},
 							},
 						},
 					},
 					{
-						Literal: &mathExprLiteral{
-							Int: ottltest.Intp(10),
+						Value: value{
+							Literal: &mathExprLiteral{
+								Int: ottltest.Intp(10),
+							},
 						},
 					},
 					{
-						Literal: &mathExprLiteral{
-							Int: ottltest.Intp(10),
+						Value: value{
+							Literal: &mathExprLiteral{
+								Int: ottltest.Intp(10),
+							},
 						},
 					},
 				},
@@ -235,10 +271,12 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "not matching arg type",
 			inv: editor{
 				Function: "testing_string",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Literal: &mathExprLiteral{
-							Int: ottltest.Intp(10),
+						Value: value{
+							Literal: &mathExprLiteral{
+								Int: ottltest.Intp(10),
+							},
 						},
 					},
 				},
@@ -248,15 +286,21 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "not matching arg type when byte slice",
 			inv: editor{
 				Function: "testing_byte_slice",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						String: ottltest.Strp("test"),
+						Value: value{
+							String: ottltest.Strp("test"),
+						},
 					},
 					{
-						String: ottltest.Strp("test"),
+						Value: value{
+							String: ottltest.Strp("test"),
+						},
 					},
 					{
-						String: ottltest.Strp("test"),
+						Value: value{
+							String: ottltest.Strp("test"),
+						},
 					},
 				},
 			},
@@ -265,16 +309,18 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "mismatching slice element type",
 			inv: editor{
 				Function: "testing_string_slice",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						List: &list{
-							Values: []value{
-								{
-									String: ottltest.Strp("test"),
-								},
-								{
-									Literal: &mathExprLiteral{
-										Int: ottltest.Intp(10),
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										String: ottltest.Strp("test"),
+									},
+									{
+										Literal: &mathExprLiteral{
+											Int: ottltest.Intp(10),
+										},
 									},
 								},
 							},
@@ -287,9 +333,11 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "mismatching slice argument type",
 			inv: editor{
 				Function: "testing_string_slice",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						String: ottltest.Strp("test"),
+						Value: value{
+							String: ottltest.Strp("test"),
+						},
 					},
 				},
 			},
@@ -304,9 +352,11 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "Enum not found",
 			inv: editor{
 				Function: "testing_enum",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Enum: (*EnumSymbol)(ottltest.Strp("SYMBOL_NOT_FOUND")),
+						Value: value{
+							Enum: (*EnumSymbol)(ottltest.Strp("SYMBOL_NOT_FOUND")),
+						},
 					},
 				},
 			},
@@ -321,9 +371,11 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "no struct tags",
 			inv: editor{
 				Function: "no_struct_tag",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						String: ottltest.Strp("str"),
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
 					},
 				},
 			},
@@ -332,9 +384,11 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "using the wrong struct tag",
 			inv: editor{
 				Function: "wrong_struct_tag",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						String: ottltest.Strp("str"),
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
 					},
 				},
 			},
@@ -343,9 +397,11 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "non-integer struct tags",
 			inv: editor{
 				Function: "bad_struct_tag",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						String: ottltest.Strp("str"),
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
 					},
 				},
 			},
@@ -354,9 +410,11 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "struct tag index too low",
 			inv: editor{
 				Function: "negative_struct_tag",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						String: ottltest.Strp("str"),
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
 					},
 				},
 			},
@@ -365,9 +423,152 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 			name: "struct tag index too high",
 			inv: editor{
 				Function: "out_of_bounds_struct_tag",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						String: ottltest.Strp("str"),
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "optional argument before required arguments",
+			inv: editor{
+				Function: "optional_before_required",
+				Arguments: []argument{
+					{
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "missing required argument",
+			inv: editor{
+				Function: "testing_optional_args",
+				Arguments: []argument{
+					{
+						Name: "optional_string_arg",
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "too many arguments with optional arguments",
+			inv: editor{
+				Function: "testing_optional_args",
+				Arguments: []argument{
+					{Value: value{String: ottltest.Strp("str")}},
+					{Value: value{String: ottltest.Strp("str")}},
+					{Value: value{String: ottltest.Strp("str")}},
+					{Value: value{List: &list{}}},
+					{Value: value{String: ottltest.Strp("str")}},
+				},
+			},
+		},
+		{
+			name: "positional argument after named argument",
+			inv: editor{
+				Function: "testing_optional_args",
+				Arguments: []argument{
+					{
+						Name: "string_arg",
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+					{
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "duplicate named argument",
+			inv: editor{
+				Function: "testing_optional_args",
+				Arguments: []argument{
+					{
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+					{
+						Name: "optional_string_arg",
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+					{
+						Name: "optional_string_arg",
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "argument given by position and by name",
+			inv: editor{
+				Function: "testing_optional_args",
+				Arguments: []argument{
+					{
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+					{
+						Name: "string_arg",
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unknown named argument",
+			inv: editor{
+				Function: "testing_optional_args",
+				Arguments: []argument{
+					{
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+					{
+						Name: "unknown_arg",
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid optional argument",
+			inv: editor{
+				Function: "testing_optional_args",
+				Arguments: []argument{
+					{
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+					{
+						Name: "optional_list_arg",
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
 					},
 				},
 			},
@@ -400,10 +601,12 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "no arguments",
 			inv: editor{
 				Function: "testing_noop",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						List: &list{
-							Values: []value{},
+						Value: value{
+							List: &list{
+								Values: []value{},
+							},
 						},
 					},
 				},
@@ -414,10 +617,12 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "empty slice arg",
 			inv: editor{
 				Function: "testing_string_slice",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						List: &list{
-							Values: []value{},
+						Value: value{
+							List: &list{
+								Values: []value{},
+							},
 						},
 					},
 				},
@@ -428,18 +633,20 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "string slice arg",
 			inv: editor{
 				Function: "testing_string_slice",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						List: &list{
-							Values: []value{
-								{
-									String: ottltest.Strp("test"),
-								},
-								{
-									String: ottltest.Strp("test"),
-								},
-								{
-									String: ottltest.Strp("test"),
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										String: ottltest.Strp("test"),
+									},
+									{
+										String: ottltest.Strp("test"),
+									},
+									{
+										String: ottltest.Strp("test"),
+									},
 								},
 							},
 						},
@@ -452,23 +659,25 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "float slice arg",
 			inv: editor{
 				Function: "testing_float_slice",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						List: &list{
-							Values: []value{
-								{
-									Literal: &mathExprLiteral{
-										Float: ottltest.Floatp(1.1),
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										Literal: &mathExprLiteral{
+											Float: ottltest.Floatp(1.1),
+										},
 									},
-								},
-								{
-									Literal: &mathExprLiteral{
-										Float: ottltest.Floatp(1.2),
+									{
+										Literal: &mathExprLiteral{
+											Float: ottltest.Floatp(1.2),
+										},
 									},
-								},
-								{
-									Literal: &mathExprLiteral{
-										Float: ottltest.Floatp(1.3),
+									{
+										Literal: &mathExprLiteral{
+											Float: ottltest.Floatp(1.3),
+										},
 									},
 								},
 							},
@@ -482,23 +691,25 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "int slice arg",
 			inv: editor{
 				Function: "testing_int_slice",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						List: &list{
-							Values: []value{
-								{
-									Literal: &mathExprLiteral{
-										Int: ottltest.Intp(1),
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										Literal: &mathExprLiteral{
+											Int: ottltest.Intp(1),
+										},
 									},
-								},
-								{
-									Literal: &mathExprLiteral{
-										Int: ottltest.Intp(1),
+									{
+										Literal: &mathExprLiteral{
+											Int: ottltest.Intp(1),
+										},
 									},
-								},
-								{
-									Literal: &mathExprLiteral{
-										Int: ottltest.Intp(1),
+									{
+										Literal: &mathExprLiteral{
+											Int: ottltest.Intp(1),
+										},
 									},
 								},
 							},
@@ -512,72 +723,74 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "getter slice arg",
 			inv: editor{
 				Function: "testing_getter_slice",
-				Arguments: []value{
-					{
-						List: &list{
-							Values: []value{
-								{
-									Literal: &mathExprLiteral{
-										Path: &Path{
-											Fields: []Field{
-												{
-													Name: "name",
+				Arguments: []argument{
+					{
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										Literal: &mathExprLiteral{
+											Path: &Path{
+												Fields: []Field{
+													{
+														Name: "name",
+													},
 												},
 											},
 										},
 									},
-								},
-								{
-									String: ottltest.Strp("test"),
-								},
-								{
-									Literal: &mathExprLiteral{
-										Int: ottltest.Intp(1),
+									{
+										String: ottltest.Strp("test"),
 									},
-								},
-								{
-									Literal: &mathExprLiteral{
-										Float: ottltest.Floatp(1.1),
+									{
+										Literal: &mathExprLiteral{
+											Int: ottltest.Intp(1),
+										},
 									},
-								},
-								{
-									Bool: (*boolean)(ottltest.Boolp(true)),
-								},
-								{
-									Enum: (*EnumSymbol)(ottltest.Strp("TEST_ENUM")),
-								},
-								{
-									List: &list{
-										Values: []value{
-											{
-												String: ottltest.Strp("test"),
-											},
-											{
-												String: ottltest.Strp("test"),
-											},
+									{
+										Literal: &mathExprLiteral{
+											Float: ottltest.Floatp(1.1),
 										},
 									},
-								},
-								{
-									List: &list{
-										Values: []value{
-											{
-												String: ottltest.Strp("test"),
+									{
+										Bool: (*boolean)(ottltest.Boolp(true)),
+									},
+									{
+										Enum: (*EnumSymbol)(ottltest.Strp("TEST_ENUM")),
+									},
+									{
+										List: &list{
+											Values: []value{
+												{
+													String: ottltest.Strp("test"),
+												},
+												{
+													String: ottltest.Strp("test"),
+												},
 											},
-											{
-												List: &list{
-													Values: []value{
-														{
-															String: ottltest.Strp("test"),
-														},
-														{
-															List: &list{
-																Values: []value{
-																	{
-																		String: ottltest.Strp("test"),
-																	},
-																	{
-																		String: ottltest.Strp("test"),
+										},
+									},
+									{
+										List: &list{
+											Values: []value{
+												{
+													String: ottltest.Strp("test"),
+												},
+												{
+													List: &list{
+														Values: []value{
+															{
+																String: ottltest.Strp("test"),
+															},
+															{
+																List: &list{
+																	Values: []value{
+																		{
+																			String: ottltest.Strp("test"),
+																		},
+																		{
+																			String: ottltest.Strp("test"),
+																		},
 																	},
 																},
 															},
@@ -587,18 +800,20 @@ func Test_NewFunctionCall(t *testing.T) {
 											},
 										},
 									},
-								},
-								{
-									Literal: &mathExprLiteral{
-										Converter: &converter{
-											Function: "testing_getter",
-											Arguments: []value{
-												{
-													Literal: &mathExprLiteral{
-														Path: &Path{
-															Fields: []Field{
-																{
-																	Name: "name",
+									{
+										Literal: &mathExprLiteral{
+											Converter: &converter{
+												Function: "testing_getter",
+												Arguments: []argument{
+													{
+														Value: value{
+															Literal: &mathExprLiteral{
+																Path: &Path{
+																	Fields: []Field{
+																		{
+																			Name: "name",
+																		},
+																	},
 																},
 															},
 														},
@@ -619,15 +834,17 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "stringgetter slice arg",
 			inv: editor{
 				Function: "testing_stringgetter_slice",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						List: &list{
-							Values: []value{
-								{
-									String: ottltest.Strp("test"),
-								},
-								{
-									String: ottltest.Strp("also test"),
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										String: ottltest.Strp("test"),
+									},
+									{
+										String: ottltest.Strp("also test"),
+									},
 								},
 							},
 						},
@@ -640,16 +857,18 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "floatgetter slice arg",
 			inv: editor{
 				Function: "testing_floatgetter_slice",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						List: &list{
-							Values: []value{
-								{
-									String: ottltest.Strp("1.1"),
-								},
-								{
-									Literal: &mathExprLiteral{
-										Float: ottltest.Floatp(1),
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										String: ottltest.Strp("1.1"),
+									},
+									{
+										Literal: &mathExprLiteral{
+											Float: ottltest.Floatp(1),
+										},
 									},
 								},
 							},
@@ -663,27 +882,29 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "pmapgetter slice arg",
 			inv: editor{
 				Function: "testing_pmapgetter_slice",
-				Arguments: []value{
-					{
-						List: &list{
-							Values: []value{
-								{
-									Literal: &mathExprLiteral{
-										Path: &Path{
-											Fields: []Field{
-												{
-													Name: "name",
+				Arguments: []argument{
+					{
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										Literal: &mathExprLiteral{
+											Path: &Path{
+												Fields: []Field{
+													{
+														Name: "name",
+													},
 												},
 											},
 										},
 									},
-								},
-								{
-									Literal: &mathExprLiteral{
-										Path: &Path{
-											Fields: []Field{
-												{
-													Name: "name",
+									{
+										Literal: &mathExprLiteral{
+											Path: &Path{
+												Fields: []Field{
+													{
+														Name: "name",
+													},
 												},
 											},
 										},
@@ -700,16 +921,18 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "stringlikegetter slice arg",
 			inv: editor{
 				Function: "testing_stringlikegetter_slice",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						List: &list{
-							Values: []value{
-								{
-									String: ottltest.Strp("test"),
-								},
-								{
-									Literal: &mathExprLiteral{
-										Int: ottltest.Intp(1),
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										String: ottltest.Strp("test"),
+									},
+									{
+										Literal: &mathExprLiteral{
+											Int: ottltest.Intp(1),
+										},
 									},
 								},
 							},
@@ -723,16 +946,18 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "floatlikegetter slice arg",
 			inv: editor{
 				Function: "testing_floatlikegetter_slice",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						List: &list{
-							Values: []value{
-								{
-									String: ottltest.Strp("1.1"),
-								},
-								{
-									Literal: &mathExprLiteral{
-										Float: ottltest.Floatp(1.1),
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										String: ottltest.Strp("1.1"),
+									},
+									{
+										Literal: &mathExprLiteral{
+											Float: ottltest.Floatp(1.1),
+										},
 									},
 								},
 							},
@@ -746,13 +971,15 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "setter arg",
 			inv: editor{
 				Function: "testing_setter",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Literal: &mathExprLiteral{
-							Path: &Path{
-								Fields: []Field{
-									{
-										Name: "name",
+						Value: value{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "name",
+										},
 									},
 								},
 							},
@@ -766,13 +993,15 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "getsetter arg",
 			inv: editor{
 				Function: "testing_getsetter",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Literal: &mathExprLiteral{
-							Path: &Path{
-								Fields: []Field{
-									{
-										Name: "name",
+						Value: value{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "name",
+										},
 									},
 								},
 							},
@@ -786,13 +1015,15 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "getter arg",
 			inv: editor{
 				Function: "testing_getter",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Literal: &mathExprLiteral{
-							Path: &Path{
-								Fields: []Field{
-									{
-										Name: "name",
+						Value: value{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "name",
+										},
 									},
 								},
 							},
@@ -806,9 +1037,11 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "getter arg with nil literal",
 			inv: editor{
 				Function: "testing_getter",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						IsNil: (*isNil)(ottltest.Boolp(true)),
+						Value: value{
+							IsNil: (*isNil)(ottltest.Boolp(true)),
+						},
 					},
 				},
 			},
@@ -818,51 +1051,55 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "getter arg with list",
 			inv: editor{
 				Function: "testing_getter",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						List: &list{
-							Values: []value{
-								{
-									String: ottltest.Strp("test"),
-								},
-								{
-									Literal: &mathExprLiteral{
-										Int: ottltest.Intp(1),
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										String: ottltest.Strp("test"),
 									},
-								},
-								{
-									Literal: &mathExprLiteral{
-										Float: ottltest.Floatp(1.1),
+									{
+										Literal: &mathExprLiteral{
+											Int: ottltest.Intp(1),
+										},
 									},
-								},
-								{
-									Bool: (*boolean)(ottltest.Boolp(true)),
-								},
-								{
-									Bytes: (*byteSlice)(&[]byte{1, 2, 3, 4, 5, 6, 7, 8}),
-								},
-								{
-									Literal: &mathExprLiteral{
-										Path: &Path{
-											Fields: []Field{
-												{
-													Name: "name",
+									{
+										Literal: &mathExprLiteral{
+											Float: ottltest.Floatp(1.1),
+										},
+									},
+									{
+										Bool: (*boolean)(ottltest.Boolp(true)),
+									},
+									{
+										Bytes: (*byteSlice)(&[]byte{1, 2, 3, 4, 5, 6, 7, 8}),
+									},
+									{
+										Literal: &mathExprLiteral{
+											Path: &Path{
+												Fields: []Field{
+													{
+														Name: "name",
+													},
 												},
 											},
 										},
 									},
-								},
-								{
-									Literal: &mathExprLiteral{
-										Converter: &converter{
-											Function: "testing_getter",
-											Arguments: []value{
-												{
-													Literal: &mathExprLiteral{
-														Path: &Path{
-															Fields: []Field{
-																{
-																	Name: "name",
+									{
+										Literal: &mathExprLiteral{
+											Converter: &converter{
+												Function: "testing_getter",
+												Arguments: []argument{
+													{
+														Value: value{
+															Literal: &mathExprLiteral{
+																Path: &Path{
+																	Fields: []Field{
+																		{
+																			Name: "name",
+																		},
+																	},
 																},
 															},
 														},
@@ -883,9 +1120,11 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "stringgetter arg",
 			inv: editor{
 				Function: "testing_stringgetter",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						String: ottltest.Strp("test"),
+						Value: value{
+							String: ottltest.Strp("test"),
+						},
 					},
 				},
 			},
@@ -895,9 +1134,11 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "stringlikegetter arg",
 			inv: editor{
 				Function: "testing_stringlikegetter",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Bool: (*boolean)(ottltest.Boolp(false)),
+						Value: value{
+							Bool: (*boolean)(ottltest.Boolp(false)),
+						},
 					},
 				},
 			},
@@ -907,9 +1148,11 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "floatgetter arg",
 			inv: editor{
 				Function: "testing_floatgetter",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						String: ottltest.Strp("1.1"),
+						Value: value{
+							String: ottltest.Strp("1.1"),
+						},
 					},
 				},
 			},
@@ -919,9 +1162,11 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "floatlikegetter arg",
 			inv: editor{
 				Function: "testing_floatlikegetter",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Bool: (*boolean)(ottltest.Boolp(false)),
+						Value: value{
+							Bool: (*boolean)(ottltest.Boolp(false)),
+						},
 					},
 				},
 			},
@@ -931,10 +1176,40 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "intgetter arg",
 			inv: editor{
 				Function: "testing_intgetter",
-				Arguments: []value{
+				Arguments: []argument{
+					{
+						Value: value{
+							Literal: &mathExprLiteral{
+								Int: ottltest.Intp(1),
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "timegetter arg",
+			inv: editor{
+				Function: "testing_timegetter",
+				Arguments: []argument{
+					{
+						Value: value{
+							String: ottltest.Strp("test"),
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "durationgetter arg",
+			inv: editor{
+				Function: "testing_durationgetter",
+				Arguments: []argument{
 					{
-						Literal: &mathExprLiteral{
-							Int: ottltest.Intp(1),
+						Value: value{
+							String: ottltest.Strp("test"),
 						},
 					},
 				},
@@ -945,13 +1220,15 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "pmapgetter arg",
 			inv: editor{
 				Function: "testing_pmapgetter",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Literal: &mathExprLiteral{
-							Path: &Path{
-								Fields: []Field{
-									{
-										Name: "name",
+						Value: value{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "name",
+										},
 									},
 								},
 							},
@@ -965,9 +1242,11 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "string arg",
 			inv: editor{
 				Function: "testing_string",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						String: ottltest.Strp("test"),
+						Value: value{
+							String: ottltest.Strp("test"),
+						},
 					},
 				},
 			},
@@ -977,10 +1256,12 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "float arg",
 			inv: editor{
 				Function: "testing_float",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Literal: &mathExprLiteral{
-							Float: ottltest.Floatp(1.1),
+						Value: value{
+							Literal: &mathExprLiteral{
+								Float: ottltest.Floatp(1.1),
+							},
 						},
 					},
 				},
@@ -991,10 +1272,12 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "int arg",
 			inv: editor{
 				Function: "testing_int",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Literal: &mathExprLiteral{
-							Int: ottltest.Intp(1),
+						Value: value{
+							Literal: &mathExprLiteral{
+								Int: ottltest.Intp(1),
+							},
 						},
 					},
 				},
@@ -1005,9 +1288,11 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "bool arg",
 			inv: editor{
 				Function: "testing_bool",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Bool: (*boolean)(ottltest.Boolp(true)),
+						Value: value{
+							Bool: (*boolean)(ottltest.Boolp(true)),
+						},
 					},
 				},
 			},
@@ -1017,9 +1302,11 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "byteSlice arg",
 			inv: editor{
 				Function: "testing_byte_slice",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Bytes: (*byteSlice)(&[]byte{1, 2, 3, 4, 5, 6, 7, 8}),
+						Value: value{
+							Bytes: (*byteSlice)(&[]byte{1, 2, 3, 4, 5, 6, 7, 8}),
+						},
 					},
 				},
 			},
@@ -1029,29 +1316,37 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "multiple args",
 			inv: editor{
 				Function: "testing_multiple_args",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Literal: &mathExprLiteral{
-							Path: &Path{
-								Fields: []Field{
-									{
-										Name: "name",
+						Value: value{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "name",
+										},
 									},
 								},
 							},
 						},
 					},
 					{
-						String: ottltest.Strp("test"),
+						Value: value{
+							String: ottltest.Strp("test"),
+						},
 					},
 					{
-						Literal: &mathExprLiteral{
-							Float: ottltest.Floatp(1.1),
+						Value: value{
+							Literal: &mathExprLiteral{
+								Float: ottltest.Floatp(1.1),
+							},
 						},
 					},
 					{
-						Literal: &mathExprLiteral{
-							Int: ottltest.Intp(1),
+						Value: value{
+							Literal: &mathExprLiteral{
+								Int: ottltest.Intp(1),
+							},
 						},
 					},
 				},
@@ -1062,14 +1357,126 @@ func Test_NewFunctionCall(t *testing.T) {
 			name: "Enum arg",
 			inv: editor{
 				Function: "testing_enum",
-				Arguments: []value{
+				Arguments: []argument{
 					{
-						Enum: (*EnumSymbol)(ottltest.Strp("TEST_ENUM")),
+						Value: value{
+							Enum: (*EnumSymbol)(ottltest.Strp("TEST_ENUM")),
+						},
 					},
 				},
 			},
 			want: nil,
 		},
+		{
+			name: "map literal arg",
+			inv: editor{
+				Function: "testing_pmapgetter",
+				Arguments: []argument{
+					{
+						Value: value{
+							Map: &mapValue{
+								Values: []mapItem{
+									{
+										Key: ottltest.Strp("foo"),
+										Value: &value{
+											String: ottltest.Strp("bar"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "optional args omitted",
+			inv: editor{
+				Function: "testing_optional_args",
+				Arguments: []argument{
+					{
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+				},
+			},
+			want: []any{"str"},
+		},
+		{
+			name: "optional args by position",
+			inv: editor{
+				Function: "testing_optional_args",
+				Arguments: []argument{
+					{
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+					{
+						Value: value{
+							String: ottltest.Strp("optional"),
+						},
+					},
+					{
+						Value: value{
+							String: ottltest.Strp("getter"),
+						},
+					},
+				},
+			},
+			want: []any{"str", "optional", "getter"},
+		},
+		{
+			name: "optional args by name",
+			inv: editor{
+				Function: "testing_optional_args",
+				Arguments: []argument{
+					{
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+					{
+						Name: "optional_list_arg",
+						Value: value{
+							List: &list{
+								Values: []value{
+									{
+										Literal: &mathExprLiteral{
+											Int: ottltest.Intp(1),
+										},
+									},
+								},
+							},
+						},
+					},
+					{
+						Name: "optional_string_arg",
+						Value: value{
+							String: ottltest.Strp("optional"),
+						},
+					},
+				},
+			},
+			want: []any{"str", "optional", []int64{1}},
+		},
+		{
+			name: "required arg by name",
+			inv: editor{
+				Function: "testing_optional_args",
+				Arguments: []argument{
+					{
+						Name: "string_arg",
+						Value: value{
+							String: ottltest.Strp("str"),
+						},
+					},
+				},
+			},
+			want: []any{"str"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1270,6 +1677,26 @@ func functionWithIntGetter(IntGetter[interface{}]) (ExprFunc[interface{}], error
 	}, nil
 }
 
+type timeGetterArguments struct {
+	TimeGetterArg TimeGetter[any] `ottlarg:"0"`
+}
+
+func functionWithTimeGetter(TimeGetter[interface{}]) (ExprFunc[interface{}], error) {
+	return func(context.Context, interface{}) (interface{}, error) {
+		return "anything", nil
+	}, nil
+}
+
+type durationGetterArguments struct {
+	DurationGetterArg DurationGetter[any] `ottlarg:"0"`
+}
+
+func functionWithDurationGetter(DurationGetter[interface{}]) (ExprFunc[interface{}], error) {
+	return func(context.Context, interface{}) (interface{}, error) {
+		return "anything", nil
+	}, nil
+}
+
 type pMapGetterArguments struct {
 	PMapArg PMapGetter[any] `ottlarg:"0"`
 }
@@ -1333,6 +1760,38 @@ func functionWithMultipleArgs(GetSetter[interface{}], string, float64, int64) (E
 	}, nil
 }
 
+type optionalArgsArguments struct {
+	StringArg         string                      `ottlarg:"0"`
+	OptionalStringArg Optional[string]            `ottlarg:"1"`
+	OptionalGetterArg Optional[StringGetter[any]] `ottlarg:"2"`
+	OptionalListArg   Optional[[]int64]           `ottlarg:"3"`
+}
+
+func functionWithOptionalArgs(str string, optionalStr Optional[string], optionalGetter Optional[StringGetter[any]], optionalList Optional[[]int64]) (ExprFunc[any], error) {
+	return func(ctx context.Context, tCtx any) (any, error) {
+		result := []any{str}
+		if !optionalStr.IsEmpty() {
+			result = append(result, optionalStr.Get())
+		}
+		if !optionalGetter.IsEmpty() {
+			val, err := optionalGetter.Get().Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, val)
+		}
+		if !optionalList.IsEmpty() {
+			result = append(result, optionalList.Get())
+		}
+		return result, nil
+	}, nil
+}
+
+type optionalBeforeRequiredArguments struct {
+	OptionalStringArg Optional[string] `ottlarg:"0"`
+	StringArg         string           `ottlarg:"1"`
+}
+
 type errorFunctionArguments struct{}
 
 func functionThatHasAnError() (ExprFunc[interface{}], error) {
@@ -1504,6 +1963,16 @@ func defaultFunctionsForTests() map[string]Factory[any] {
 			&intGetterArguments{},
 			functionWithIntGetter,
 		),
+		createFactory[any](
+			"testing_timegetter",
+			&timeGetterArguments{},
+			functionWithTimeGetter,
+		),
+		createFactory[any](
+			"testing_durationgetter",
+			&durationGetterArguments{},
+			functionWithDurationGetter,
+		),
 		createFactory[any](
 			"testing_pmapgetter",
 			&pMapGetterArguments{},
@@ -1539,5 +2008,10 @@ func defaultFunctionsForTests() map[string]Factory[any] {
 			&enumArguments{},
 			functionWithEnum,
 		),
+		createFactory[any](
+			"testing_optional_args",
+			&optionalArgsArguments{},
+			functionWithOptionalArgs,
+		),
 	)
 }
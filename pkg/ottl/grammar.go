@@ -189,8 +189,8 @@ func (c *comparison) checkForCustomError() error {
 
 // editor represents the function call of a statement.
 type editor struct {
-	Function  string  `parser:"@(Lowercase(Uppercase | Lowercase)*)"`
-	Arguments []value `parser:"'(' ( @@ ( ',' @@ )* )? ')'"`
+	Function  string     `parser:"@(Lowercase(Uppercase | Lowercase)*)"`
+	Arguments []argument `parser:"'(' ( @@ ( ',' @@ )* )? ')'"`
 	// If keys are matched return an error
 	Keys []Key `parser:"( @@ )*"`
 }
@@ -198,7 +198,7 @@ type editor struct {
 func (i *editor) checkForCustomError() error {
 	var err error
 	for _, arg := range i.Arguments {
-		err = arg.checkForCustomError()
+		err = arg.Value.checkForCustomError()
 		if err != nil {
 			return err
 		}
@@ -211,9 +211,15 @@ func (i *editor) checkForCustomError() error {
 
 // converter represents a converter function call.
 type converter struct {
-	Function  string  `parser:"@(Uppercase(Uppercase | Lowercase)*)"`
-	Arguments []value `parser:"'(' ( @@ ( ',' @@ )* )? ')'"`
-	Keys      []Key   `parser:"( @@ )*"`
+	Function  string     `parser:"@(Uppercase(Uppercase | Lowercase)*)"`
+	Arguments []argument `parser:"'(' ( @@ ( ',' @@ )* )? ')'"`
+	Keys      []Key      `parser:"( @@ )*"`
+}
+
+// argument represents an argument of a function call, optionally given by name.
+type argument struct {
+	Name  string `parser:"( @(Lowercase(Uppercase | Lowercase)*) Equal )?"`
+	Value value  `parser:"@@"`
 }
 
 // value represents a part of a parsed statement which is resolved to a value of some sort. This can be a telemetry path
@@ -226,7 +232,8 @@ type value struct {
 	String         *string          `parser:"| @String"`
 	Bool           *boolean         `parser:"| @Boolean"`
 	Enum           *EnumSymbol      `parser:"| @Uppercase"`
-	List           *list            `parser:"| @@)"`
+	List           *list            `parser:"| @@"`
+	Map            *mapValue        `parser:"| @@)"`
 }
 
 func (v *value) checkForCustomError() error {
@@ -236,6 +243,9 @@ func (v *value) checkForCustomError() error {
 	if v.MathExpression != nil {
 		return v.MathExpression.checkForCustomError()
 	}
+	if v.Map != nil {
+		return v.Map.checkForCustomError()
+	}
 	return nil
 }
 
@@ -259,6 +269,25 @@ type list struct {
 	Values []value `parser:"'[' (@@)* (',' @@)* ']'"`
 }
 
+// mapValue represents a map literal, whose keys are strings.
+type mapValue struct {
+	Values []mapItem `parser:"'{' ( @@ ( ',' @@ )* )? '}'"`
+}
+
+func (m *mapValue) checkForCustomError() error {
+	for _, item := range m.Values {
+		if err := item.Value.checkForCustomError(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type mapItem struct {
+	Key   *string `parser:"@String ':'"`
+	Value *value  `parser:"@@"`
+}
+
 // byteSlice type for capturing byte slices
 type byteSlice []byte
 
@@ -427,12 +456,13 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
 		{Name: `OpComparison`, Pattern: `==|!=|>=|<=|>|<`},
+		{Name: `Equal`, Pattern: `=`},
 		{Name: `OpAddSub`, Pattern: `\+|\-`},
 		{Name: `OpMultDiv`, Pattern: `\/|\*`},
 		{Name: `Boolean`, Pattern: `\b(true|false)\b`},
 		{Name: `LParen`, Pattern: `\(`},
 		{Name: `RParen`, Pattern: `\)`},
-		{Name: `Punct`, Pattern: `[,.\[\]]`},
+		{Name: `Punct`, Pattern: `[,.:\[\]{}]`},
 		{Name: `Uppercase`, Pattern: `[A-Z][A-Z0-9_]*`},
 		{Name: `Lowercase`, Pattern: `[a-z][a-z0-9_]*`},
 		{Name: "whitespace", Pattern: `\s+`},
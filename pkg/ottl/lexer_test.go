@@ -78,7 +78,7 @@ func Test_lexer(t *testing.T) {
 			{"OpNot", "not"},
 			{"Boolean", "false"},
 		}},
-		{"nothing_recognizable", "{}", true, []result{
+		{"nothing_recognizable", "|", true, []result{
 			{"", ""},
 		}},
 		{"basic_ident_expr", `set(attributes["bytes"], 0x0102030405060708)`, false, []result{
@@ -92,6 +92,31 @@ func Test_lexer(t *testing.T) {
 			{"Bytes", "0x0102030405060708"},
 			{"RParen", ")"},
 		}},
+		{"map literal", `{"foo": 1, "bar": [2]}`, false, []result{
+			{"Punct", "{"},
+			{"String", `"foo"`},
+			{"Punct", ":"},
+			{"Int", "1"},
+			{"Punct", ","},
+			{"String", `"bar"`},
+			{"Punct", ":"},
+			{"Punct", "["},
+			{"Int", "2"},
+			{"Punct", "]"},
+			{"Punct", "}"},
+		}},
+		{"named argument", `Hash(value = attributes["id"])`, false, []result{
+			{"Uppercase", "H"},
+			{"Lowercase", "ash"},
+			{"LParen", "("},
+			{"Lowercase", "value"},
+			{"Equal", "="},
+			{"Lowercase", "attributes"},
+			{"Punct", "["},
+			{"String", `"id"`},
+			{"Punct", "]"},
+			{"RParen", ")"},
+		}},
 		{"Mixing case numbers and underscores", `aBCd_123E_4`, false, []result{
 			{"Lowercase", "a"},
 			{"Uppercase", "BC"},
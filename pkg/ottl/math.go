@@ -6,6 +6,7 @@ package ottl // import "github.com/open-telemetry/opentelemetry-collector-contri
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 func (p *Parser[K]) evaluateMathExpression(expr *mathExpression) (Getter[K], error) {
@@ -98,8 +99,12 @@ func attemptMathOperation[K any](lhs Getter[K], op mathOp, rhs Getter[K]) Getter
 					default:
 						return nil, fmt.Errorf("%v must be int64 or float64", y)
 					}
+				case time.Time:
+					return performOpTime(newX, y, op)
+				case time.Duration:
+					return performOpDuration(newX, y, op)
 				default:
-					return nil, fmt.Errorf("%v must be int64 or float64", x)
+					return nil, fmt.Errorf("%v must be int64, float64, time.Time or time.Duration", x)
 				}
 			},
 		},
@@ -122,3 +127,48 @@ func performOp[N int64 | float64](x N, y N, op mathOp) (N, error) {
 	}
 	return 0, fmt.Errorf("invalid operation %v", op)
 }
+
+// performOpTime supports subtracting two times, which results in a duration,
+// and adding or subtracting a duration to a time.
+func performOpTime(x time.Time, y any, op mathOp) (any, error) {
+	switch newY := y.(type) {
+	case time.Time:
+		if op != SUB {
+			return nil, fmt.Errorf("only subtraction is supported between two times")
+		}
+		return x.Sub(newY), nil
+	case time.Duration:
+		switch op {
+		case ADD:
+			return x.Add(newY), nil
+		case SUB:
+			return x.Add(-newY), nil
+		default:
+			return nil, fmt.Errorf("only addition and subtraction are supported between a time and a duration")
+		}
+	default:
+		return nil, fmt.Errorf("%v must be time.Time or time.Duration", y)
+	}
+}
+
+// performOpDuration supports adding or subtracting two durations, and adding a time to a duration.
+func performOpDuration(x time.Duration, y any, op mathOp) (any, error) {
+	switch newY := y.(type) {
+	case time.Duration:
+		switch op {
+		case ADD:
+			return x + newY, nil
+		case SUB:
+			return x - newY, nil
+		default:
+			return nil, fmt.Errorf("only addition and subtraction are supported between two durations")
+		}
+	case time.Time:
+		if op != ADD {
+			return nil, fmt.Errorf("only addition is supported between a duration and a time")
+		}
+		return newY.Add(x), nil
+	default:
+		return nil, fmt.Errorf("%v must be time.Time or time.Duration", y)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -56,6 +57,24 @@ func threePointOne[K any]() (ExprFunc[K], error) {
 	}, nil
 }
 
+func testTime[K any]() (ExprFunc[K], error) {
+	return func(context.Context, K) (interface{}, error) {
+		return time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC), nil
+	}, nil
+}
+
+func testLaterTime[K any]() (ExprFunc[K], error) {
+	return func(context.Context, K) (interface{}, error) {
+		return time.Date(2023, 5, 2, 12, 30, 0, 0, time.UTC), nil
+	}, nil
+}
+
+func testHour[K any]() (ExprFunc[K], error) {
+	return func(context.Context, K) (interface{}, error) {
+		return time.Hour, nil
+	}, nil
+}
+
 type sumArguments struct {
 	Ints []int64 `ottlarg:"0"`
 }
@@ -197,6 +216,36 @@ func Test_evaluateMathExpression(t *testing.T) {
 			input:    "4 / 2.0",
 			expected: 2.0,
 		},
+		{
+			name:     "time minus time",
+			input:    "LaterTime() - Time()",
+			expected: 24*time.Hour + 30*time.Minute,
+		},
+		{
+			name:     "time plus duration",
+			input:    "Time() + Hour()",
+			expected: time.Date(2023, 5, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "time minus duration",
+			input:    "Time() - Hour()",
+			expected: time.Date(2023, 5, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "duration plus time",
+			input:    "Hour() + Time()",
+			expected: time.Date(2023, 5, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "duration plus duration",
+			input:    "Hour() + Hour()",
+			expected: 2 * time.Hour,
+		},
+		{
+			name:     "duration minus duration",
+			input:    "LaterTime() - Time() - Hour()",
+			expected: 23*time.Hour + 30*time.Minute,
+		},
 	}
 
 	functions := CreateFactoryMap(
@@ -204,6 +253,9 @@ func Test_evaluateMathExpression(t *testing.T) {
 		createFactory("Two", &struct{}{}, two[any]),
 		createFactory("ThreePointOne", &struct{}{}, threePointOne[any]),
 		createFactory("Sum", &sumArguments{}, sum[any]),
+		createFactory("Time", &struct{}{}, testTime[any]),
+		createFactory("LaterTime", &struct{}{}, testLaterTime[any]),
+		createFactory("Hour", &struct{}{}, testHour[any]),
 	)
 
 	p, _ := NewParser[any](
@@ -240,6 +292,30 @@ func Test_evaluateMathExpression_error(t *testing.T) {
 			name:  "divide by 0 is gracefully handled",
 			input: "1 / 0",
 		},
+		{
+			name:  "time plus time",
+			input: "Time() + Time()",
+		},
+		{
+			name:  "time multiplied by duration",
+			input: "Time() * Hour()",
+		},
+		{
+			name:  "duration minus time",
+			input: "Hour() - Time()",
+		},
+		{
+			name:  "duration divided by duration",
+			input: "Hour() / Hour()",
+		},
+		{
+			name:  "time plus int",
+			input: "Time() + 1",
+		},
+		{
+			name:  "int plus duration",
+			input: "1 + Hour()",
+		},
 	}
 
 	functions := CreateFactoryMap(
@@ -247,6 +323,8 @@ func Test_evaluateMathExpression_error(t *testing.T) {
 		createFactory("two", &struct{}{}, two[any]),
 		createFactory("threePointOne", &struct{}{}, threePointOne[any]),
 		createFactory("sum", &sumArguments{}, sum[any]),
+		createFactory("Time", &struct{}{}, testTime[any]),
+		createFactory("Hour", &struct{}{}, testHour[any]),
 	)
 
 	p, _ := NewParser[any](
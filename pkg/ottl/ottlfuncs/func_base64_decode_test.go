@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Base64Decode(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         interface{}
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:     "string",
+			value:    "aGVsbG8gd29ybGQ=",
+			expected: "hello world",
+		},
+		{
+			name:     "empty string",
+			value:    "",
+			expected: "",
+		},
+		{
+			name:          "invalid base64",
+			value:         "hello world",
+			expectedError: "cannot decode \"hello world\" as base64",
+		},
+		{
+			name:          "not a string",
+			value:         int64(1),
+			expectedError: "expected string but got int64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := base64Decode[any](&ottl.StandardTypeGetter[any, string]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type DoubleArguments[K any] struct {
+	Target ottl.Getter[K] `ottlarg:"0"`
+}
+
+func NewDoubleFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Double", &DoubleArguments[K]{}, createDoubleFunction[K])
+}
+
+func createDoubleFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*DoubleArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("DoubleFactory args must be of type *DoubleArguments[K]")
+	}
+
+	return doubleFunc(args.Target), nil
+}
+
+func doubleFunc[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		value, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		switch value := value.(type) {
+		case float64:
+			return value, nil
+		case string:
+			doubleValue, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, nil
+			}
+
+			return doubleValue, nil
+		case int64:
+			return float64(value), nil
+		case bool:
+			if value {
+				return float64(1), nil
+			}
+			return float64(0), nil
+		default:
+			return nil, nil
+		}
+	}
+}
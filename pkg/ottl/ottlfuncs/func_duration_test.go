@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Duration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration string
+		expected time.Duration
+	}{
+		{
+			name:     "hours",
+			duration: "24h",
+			expected: 24 * time.Hour,
+		},
+		{
+			name:     "combination of units",
+			duration: "1h30m15s",
+			expected: time.Hour + 30*time.Minute + 15*time.Second,
+		},
+		{
+			name:     "fractional milliseconds",
+			duration: "1.5ms",
+			expected: 1500 * time.Microsecond,
+		},
+		{
+			name:     "negative",
+			duration: "-10m",
+			expected: -10 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := duration[interface{}](&ottl.StandardTypeGetter[interface{}, string]{
+				Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+					return tt.duration, nil
+				},
+			})
+			result, err := exprFunc(nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_DurationError(t *testing.T) {
+	tests := []struct {
+		name          string
+		duration      interface{}
+		expectedError string
+	}{
+		{
+			name:          "empty duration",
+			duration:      "",
+			expectedError: "invalid duration",
+		},
+		{
+			name:          "missing unit",
+			duration:      "10",
+			expectedError: "missing unit",
+		},
+		{
+			name:          "not a string",
+			duration:      int64(10),
+			expectedError: "expected string but got int64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := duration[interface{}](&ottl.StandardTypeGetter[interface{}, string]{
+				Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+					return tt.duration, nil
+				},
+			})
+			_, err := exprFunc(nil, nil)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
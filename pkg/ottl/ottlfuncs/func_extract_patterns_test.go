@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_extractPatterns(t *testing.T) {
+	target := &ottl.StandardTypeGetter[any, string]{
+		Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+			return `a=b c=d`, nil
+		},
+	}
+	tests := []struct {
+		name          string
+		pattern       string
+		want          func(pcommon.Map)
+		expectedError string
+	}{
+		{
+			name:    "extract patterns",
+			pattern: `^a=(?P<a>\w+)\s+c=(?P<c>\w+)$`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("a", "b")
+				expectedMap.PutStr("c", "d")
+			},
+		},
+		{
+			name:          "no pattern found",
+			pattern:       `^a=(?P<a>\w+)$`,
+			want:          func(_ pcommon.Map) {},
+			expectedError: "regex pattern does not match",
+		},
+		{
+			name:    "unnamed groups are ignored",
+			pattern: `^a=(?P<a>\w+)\s+c=(\w+)$`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("a", "b")
+			},
+		},
+		{
+			name:    "optional named group not matched",
+			pattern: `^a=(?P<a>\w+)\s+c=(?P<c>\w+)(?P<e>,e)?$`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("a", "b")
+				expectedMap.PutStr("c", "d")
+				expectedMap.PutStr("e", "")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := extractPatterns[any](target, tt.pattern)
+			assert.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+			assert.Equal(t, expected.AsRaw(), result.(pcommon.Map).AsRaw())
+		})
+	}
+}
+
+func Test_extractPatterns_validation(t *testing.T) {
+	target := &ottl.StandardTypeGetter[any, string]{
+		Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+			return "foobar", nil
+		},
+	}
+
+	_, err := extractPatterns[any](target, "(")
+	assert.ErrorContains(t, err, "not a valid pattern")
+
+	_, err = extractPatterns[any](target, "^a=(\\w+)$")
+	assert.ErrorContains(t, err, "at least 1 named capture group")
+}
+
+func Test_extractPatterns_bad_input(t *testing.T) {
+	target := &ottl.StandardTypeGetter[any, string]{
+		Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+			return 1, nil
+		},
+	}
+
+	exprFunc, err := extractPatterns[any](target, "(?P<line>.*)")
+	assert.NoError(t, err)
+
+	_, err = exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "expected string but got int")
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type FNVArguments[K any] struct {
+	Target ottl.StringLikeGetter[K] `ottlarg:"0"`
+	Salt   ottl.Optional[string]    `ottlarg:"1"`
+}
+
+func NewFNVFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("FNV", &FNVArguments[K]{}, createFNVFunction[K])
+}
+
+func createFNVFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*FNVArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("FNVFactory args must be of type *FNVArguments[K]")
+	}
+
+	return fnvHash(args.Target, args.Salt), nil
+}
+
+// fnvHash returns the 64-bit FNV-1a hash as an int64 of the target, the salt is prepended to the target before hashing.
+func fnvHash[K any](target ottl.StringLikeGetter[K], salt ottl.Optional[string]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return nil, nil
+		}
+		h := fnv.New64a()
+		if !salt.IsEmpty() {
+			_, _ = h.Write([]byte(salt.Get()))
+		}
+		_, _ = h.Write([]byte(*val))
+		return int64(h.Sum64()), nil
+	}
+}
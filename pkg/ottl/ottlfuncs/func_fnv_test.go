@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_FNV(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		salt     ottl.Optional[string]
+		expected interface{}
+	}{
+		{
+			name:     "string",
+			value:    "user@example.com",
+			expected: int64(-5181782894033253669),
+		},
+		{
+			name:     "string with salt",
+			value:    "user@example.com",
+			salt:     ottl.NewTestingOptional[string]("my-salt"),
+			expected: int64(-8221250797425815020),
+		},
+		{
+			name:     "int",
+			value:    int64(12345),
+			expected: int64(-1912366794928059912),
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := fnvHash[any](&ottl.StandardStringLikeGetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.salt)
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_FNV_error(t *testing.T) {
+	exprFunc := fnvHash[any](&ottl.StandardStringLikeGetter[any]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return nil, errors.New("error getting value")
+		},
+	}, ottl.Optional[string]{})
+	_, err := exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "error getting value")
+}
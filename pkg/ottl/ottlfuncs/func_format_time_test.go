@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_FormatTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     time.Time
+		format   string
+		expected string
+	}{
+		{
+			name:     "date",
+			time:     time.Date(2023, 4, 12, 0, 0, 0, 0, time.UTC),
+			format:   "%Y-%m-%d",
+			expected: "2023-04-12",
+		},
+		{
+			name:     "date and time with milliseconds",
+			time:     time.Date(2023, 2, 15, 13, 5, 30, 123000000, time.UTC),
+			format:   "%d/%m/%Y %H:%M:%S.%L",
+			expected: "15/02/2023 13:05:30.123",
+		},
+		{
+			name:     "month and day names with time zone",
+			time:     time.Date(2023, 5, 26, 12, 34, 56, 0, time.FixedZone("", -7*60*60)),
+			format:   "%A %B %d, %Y %I:%M %p %z",
+			expected: "Friday May 26, 2023 12:34 PM -0700",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := formatTime[interface{}](&ottl.StandardTypeGetter[interface{}, time.Time]{
+				Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+					return tt.time, nil
+				},
+			}, tt.format)
+			assert.NoError(t, err)
+			result, err := exprFunc(nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_FormatTimeError(t *testing.T) {
+	getter := &ottl.StandardTypeGetter[interface{}, time.Time]{
+		Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+			return int64(1), nil
+		},
+	}
+
+	_, err := formatTime[interface{}](getter, "")
+	assert.ErrorContains(t, err, "format cannot be empty")
+
+	_, err = formatTime[interface{}](getter, "%Y %1")
+	assert.ErrorContains(t, err, "invalid format")
+
+	exprFunc, err := formatTime[interface{}](getter, "%Y")
+	assert.NoError(t, err)
+	_, err = exprFunc(nil, nil)
+	assert.ErrorContains(t, err, "expected time.Time but got int64")
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type HexArguments[K any] struct {
+	Target ottl.Getter[K] `ottlarg:"0"`
+}
+
+func NewHexFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Hex", &HexArguments[K]{}, createHexFunction[K])
+}
+
+func createHexFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*HexArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("HexFactory args must be of type *HexArguments[K]")
+	}
+
+	return hexFunc(args.Target), nil
+}
+
+func hexFunc[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		switch v := val.(type) {
+		case nil:
+			return nil, nil
+		case string:
+			return hex.EncodeToString([]byte(v)), nil
+		case []byte:
+			return hex.EncodeToString(v), nil
+		case pcommon.Value:
+			switch v.Type() {
+			case pcommon.ValueTypeStr:
+				return hex.EncodeToString([]byte(v.Str())), nil
+			case pcommon.ValueTypeBytes:
+				return hex.EncodeToString(v.Bytes().AsRaw()), nil
+			}
+		}
+		return nil, fmt.Errorf("unsupported type: %T", val)
+	}
+}
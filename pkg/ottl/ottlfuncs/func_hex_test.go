@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Hex(t *testing.T) {
+	bytesValue := pcommon.NewValueBytes()
+	bytesValue.Bytes().FromRaw([]byte{0x01, 0xab})
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected interface{}
+		err      bool
+	}{
+		{
+			name:     "string",
+			value:    "hello",
+			expected: "68656c6c6f",
+		},
+		{
+			name:     "bytes",
+			value:    []byte{0x01, 0xab},
+			expected: "01ab",
+		},
+		{
+			name:     "pcommon.Value string",
+			value:    pcommon.NewValueStr("hello"),
+			expected: "68656c6c6f",
+		},
+		{
+			name:     "pcommon.Value bytes",
+			value:    bytesValue,
+			expected: "01ab",
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: nil,
+		},
+		{
+			name:  "int",
+			value: int64(1),
+			err:   true,
+		},
+		{
+			name:  "pcommon.Value int",
+			value: pcommon.NewValueInt(1),
+			err:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := hexFunc[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
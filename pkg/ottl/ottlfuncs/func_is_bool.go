@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IsBoolArguments[K any] struct {
+	Target ottl.Getter[K] `ottlarg:"0"`
+}
+
+func NewIsBoolFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("IsBool", &IsBoolArguments[K]{}, createIsBoolFunction[K])
+}
+
+func createIsBoolFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IsBoolArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("IsBoolFactory args must be of type *IsBoolArguments[K]")
+	}
+
+	return isBool(args.Target), nil
+}
+
+// isBool returns true if the target is a bool.
+func isBool[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		switch v := val.(type) {
+		case bool:
+			return true, nil
+		case pcommon.Value:
+			return v.Type() == pcommon.ValueTypeBool, nil
+		default:
+			return false, nil
+		}
+	}
+}
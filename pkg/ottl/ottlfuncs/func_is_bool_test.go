@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_IsBool(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected bool
+	}{
+		{
+			name:     "bool",
+			value:    true,
+			expected: true,
+		},
+		{
+			name:     "pcommon.Value bool",
+			value:    pcommon.NewValueBool(true),
+			expected: true,
+		},
+		{
+			name:     "string",
+			value:    "a string",
+			expected: false,
+		},
+		{
+			name:     "int",
+			value:    int64(1),
+			expected: false,
+		},
+		{
+			name:     "pcommon.Value string",
+			value:    pcommon.NewValueStr("a string"),
+			expected: false,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := isBool[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IsDoubleArguments[K any] struct {
+	Target ottl.Getter[K] `ottlarg:"0"`
+}
+
+func NewIsDoubleFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("IsDouble", &IsDoubleArguments[K]{}, createIsDoubleFunction[K])
+}
+
+func createIsDoubleFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IsDoubleArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("IsDoubleFactory args must be of type *IsDoubleArguments[K]")
+	}
+
+	return isDouble(args.Target), nil
+}
+
+// isDouble returns true if the target is a double.
+func isDouble[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		switch v := val.(type) {
+		case float64:
+			return true, nil
+		case pcommon.Value:
+			return v.Type() == pcommon.ValueTypeDouble, nil
+		default:
+			return false, nil
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_IsDouble(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected bool
+	}{
+		{
+			name:     "double",
+			value:    float64(1.5),
+			expected: true,
+		},
+		{
+			name:     "pcommon.Value double",
+			value:    pcommon.NewValueDouble(1.5),
+			expected: true,
+		},
+		{
+			name:     "int",
+			value:    int64(1),
+			expected: false,
+		},
+		{
+			name:     "string",
+			value:    "a string",
+			expected: false,
+		},
+		{
+			name:     "pcommon.Value int",
+			value:    pcommon.NewValueInt(1),
+			expected: false,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := isDouble[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IsIntArguments[K any] struct {
+	Target ottl.Getter[K] `ottlarg:"0"`
+}
+
+func NewIsIntFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("IsInt", &IsIntArguments[K]{}, createIsIntFunction[K])
+}
+
+func createIsIntFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IsIntArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("IsIntFactory args must be of type *IsIntArguments[K]")
+	}
+
+	return isInt(args.Target), nil
+}
+
+// isInt returns true if the target is an int.
+func isInt[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		switch v := val.(type) {
+		case int64:
+			return true, nil
+		case pcommon.Value:
+			return v.Type() == pcommon.ValueTypeInt, nil
+		default:
+			return false, nil
+		}
+	}
+}
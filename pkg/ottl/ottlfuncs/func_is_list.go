@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IsListArguments[K any] struct {
+	Target ottl.Getter[K] `ottlarg:"0"`
+}
+
+func NewIsListFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("IsList", &IsListArguments[K]{}, createIsListFunction[K])
+}
+
+func createIsListFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IsListArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("IsListFactory args must be of type *IsListArguments[K]")
+	}
+
+	return isList(args.Target), nil
+}
+
+// isList returns true if the target is a list.
+func isList[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		switch v := val.(type) {
+		case pcommon.Slice, []any, []string, []int64, []float64, []bool:
+			return true, nil
+		case pcommon.Value:
+			return v.Type() == pcommon.ValueTypeSlice, nil
+		default:
+			return false, nil
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_IsList(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected bool
+	}{
+		{
+			name:     "list",
+			value:    []any{"a", int64(1)},
+			expected: true,
+		},
+		{
+			name:     "string list",
+			value:    []string{"a", "b"},
+			expected: true,
+		},
+		{
+			name:     "pcommon.Slice",
+			value:    pcommon.NewSlice(),
+			expected: true,
+		},
+		{
+			name:     "pcommon.Value slice",
+			value:    pcommon.NewValueSlice(),
+			expected: true,
+		},
+		{
+			name:     "map",
+			value:    map[string]any{"key": "value"},
+			expected: false,
+		},
+		{
+			name:     "string",
+			value:    "a string",
+			expected: false,
+		},
+		{
+			name:     "pcommon.Value map",
+			value:    pcommon.NewValueMap(),
+			expected: false,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := isList[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type KeysArguments[K any] struct {
+	Target ottl.PMapGetter[K] `ottlarg:"0"`
+}
+
+func NewKeysFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Keys", &KeysArguments[K]{}, createKeysFunction[K])
+}
+
+func createKeysFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*KeysArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("KeysFactory args must be of type *KeysArguments[K]")
+	}
+
+	return keysFunc(args.Target), nil
+}
+
+// keysFunc returns the keys of the target map as a list of strings, in the order of the map.
+// The list is a pcommon.Slice, just like the one returned by Values.
+func keysFunc[K any](target ottl.PMapGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		keys := pcommon.NewSlice()
+		keys.EnsureCapacity(val.Len())
+		val.Range(func(k string, _ pcommon.Value) bool {
+			keys.AppendEmpty().SetStr(k)
+			return true
+		})
+		return keys, nil
+	}
+}
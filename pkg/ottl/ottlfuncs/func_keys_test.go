@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Keys(t *testing.T) {
+	input := pcommon.NewMap()
+	input.PutStr("b", "value")
+	input.PutInt("a", 1)
+	input.PutEmptyMap("c")
+
+	tests := []struct {
+		name     string
+		value    pcommon.Map
+		expected []any
+	}{
+		{
+			name:     "map",
+			value:    input,
+			expected: []any{"b", "a", "c"},
+		},
+		{
+			name:     "empty map",
+			value:    pcommon.NewMap(),
+			expected: []any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := keysFunc[any](&ottl.StandardTypeGetter[any, pcommon.Map]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result.(pcommon.Slice).AsRaw())
+		})
+	}
+}
+
+func Test_Keys_bad_input(t *testing.T) {
+	exprFunc := keysFunc[any](&ottl.StandardTypeGetter[any, pcommon.Map]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return "not a map", nil
+		},
+	})
+	_, err := exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "expected pcommon.Map but got string")
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type LenArguments[K any] struct {
+	Target ottl.Getter[K] `ottlarg:"0"`
+}
+
+func NewLenFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Len", &LenArguments[K]{}, createLenFunction[K])
+}
+
+func createLenFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*LenArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("LenFactory args must be of type *LenArguments[K]")
+	}
+
+	return lenFunc(args.Target), nil
+}
+
+// lenFunc returns the length of the target string, byte array, map or list.
+func lenFunc[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		switch v := val.(type) {
+		case string:
+			return int64(len(v)), nil
+		case []byte:
+			return int64(len(v)), nil
+		case pcommon.Map:
+			return int64(v.Len()), nil
+		case map[string]any:
+			return int64(len(v)), nil
+		case pcommon.Slice:
+			return int64(v.Len()), nil
+		case []any:
+			return int64(len(v)), nil
+		case []string:
+			return int64(len(v)), nil
+		case []int64:
+			return int64(len(v)), nil
+		case []float64:
+			return int64(len(v)), nil
+		case []bool:
+			return int64(len(v)), nil
+		case pcommon.Value:
+			switch v.Type() {
+			case pcommon.ValueTypeStr:
+				return int64(len(v.Str())), nil
+			case pcommon.ValueTypeBytes:
+				return int64(v.Bytes().Len()), nil
+			case pcommon.ValueTypeMap:
+				return int64(v.Map().Len()), nil
+			case pcommon.ValueTypeSlice:
+				return int64(v.Slice().Len()), nil
+			}
+			return nil, fmt.Errorf("computing length of %v value not supported", v.Type())
+		}
+
+		return nil, fmt.Errorf("computing length of %T not supported", val)
+	}
+}
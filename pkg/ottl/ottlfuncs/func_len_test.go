@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Len(t *testing.T) {
+	pMap := pcommon.NewMap()
+	pMap.PutStr("a", "b")
+	pMap.PutStr("c", "d")
+
+	pSlice := pcommon.NewSlice()
+	pSlice.AppendEmpty().SetStr("a")
+
+	pValueBytes := pcommon.NewValueBytes()
+	pValueBytes.Bytes().FromRaw([]byte{1, 2, 3})
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected int64
+	}{
+		{
+			name:     "string",
+			value:    "hello",
+			expected: 5,
+		},
+		{
+			name:     "empty string",
+			value:    "",
+			expected: 0,
+		},
+		{
+			name:     "byte array",
+			value:    []byte{1, 2},
+			expected: 2,
+		},
+		{
+			name:     "map",
+			value:    map[string]any{"a": "b"},
+			expected: 1,
+		},
+		{
+			name:     "pcommon.Map",
+			value:    pMap,
+			expected: 2,
+		},
+		{
+			name:     "list",
+			value:    []any{"a", int64(1), true},
+			expected: 3,
+		},
+		{
+			name:     "string list",
+			value:    []string{"a", "b"},
+			expected: 2,
+		},
+		{
+			name:     "pcommon.Slice",
+			value:    pSlice,
+			expected: 1,
+		},
+		{
+			name:     "pcommon.Value string",
+			value:    pcommon.NewValueStr("hello"),
+			expected: 5,
+		},
+		{
+			name:     "pcommon.Value bytes",
+			value:    pValueBytes,
+			expected: 3,
+		},
+		{
+			name:     "pcommon.Value map",
+			value:    pcommon.NewValueMap(),
+			expected: 0,
+		},
+		{
+			name:     "pcommon.Value slice",
+			value:    pcommon.NewValueSlice(),
+			expected: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := lenFunc[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Len_Error(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         interface{}
+		expectedError string
+	}{
+		{
+			name:          "int",
+			value:         int64(1),
+			expectedError: "computing length of int64 not supported",
+		},
+		{
+			name:          "nil",
+			value:         nil,
+			expectedError: "computing length of <nil> not supported",
+		},
+		{
+			name:          "pcommon.Value int",
+			value:         pcommon.NewValueInt(1),
+			expectedError: "computing length of Int value not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := lenFunc[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			_, err := exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"crypto/md5" // #nosec
+	"encoding/hex"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type MD5Arguments[K any] struct {
+	Target ottl.StringLikeGetter[K] `ottlarg:"0"`
+	Salt   ottl.Optional[string]    `ottlarg:"1"`
+}
+
+func NewMD5Factory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("MD5", &MD5Arguments[K]{}, createMD5Function[K])
+}
+
+func createMD5Function[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*MD5Arguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("MD5Factory args must be of type *MD5Arguments[K]")
+	}
+
+	return md5Hash(args.Target, args.Salt), nil
+}
+
+// md5Hash returns the hex encoded MD5 hash of the target, the salt is prepended to the target before hashing.
+func md5Hash[K any](target ottl.StringLikeGetter[K], salt ottl.Optional[string]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return nil, nil
+		}
+		h := md5.New() // #nosec
+		if !salt.IsEmpty() {
+			_, _ = h.Write([]byte(salt.Get()))
+		}
+		_, _ = h.Write([]byte(*val))
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+}
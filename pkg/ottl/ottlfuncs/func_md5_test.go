@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_MD5(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		salt     ottl.Optional[string]
+		expected interface{}
+	}{
+		{
+			name:     "string",
+			value:    "user@example.com",
+			expected: "b58996c504c5638798eb6b511e6f49af",
+		},
+		{
+			name:     "string with salt",
+			value:    "user@example.com",
+			salt:     ottl.NewTestingOptional[string]("my-salt"),
+			expected: "8260e8deecbf3af6c20c6934ef845bc8",
+		},
+		{
+			name:     "int",
+			value:    int64(12345),
+			expected: "827ccb0eea8a706c4c34a16891f84e7b",
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := md5Hash[any](&ottl.StandardStringLikeGetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.salt)
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_MD5_error(t *testing.T) {
+	exprFunc := md5Hash[any](&ottl.StandardStringLikeGetter[any]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return nil, errors.New("error getting value")
+		},
+	}, ottl.Optional[string]{})
+	_, err := exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "error getting value")
+}
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Now(t *testing.T) {
+	exprFunc, err := now[interface{}]()
+	require.NoError(t, err)
+
+	before := time.Now()
+	result, err := exprFunc(nil, nil)
+	after := time.Now()
+	require.NoError(t, err)
+
+	assert.IsType(t, time.Time{}, result)
+	assert.False(t, result.(time.Time).Before(before))
+	assert.False(t, result.(time.Time).After(after))
+}
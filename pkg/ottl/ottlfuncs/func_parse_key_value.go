@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const defaultKeyValueDelimiter = "="
+
+type ParseKeyValueArguments[K any] struct {
+	Target        ottl.StringGetter[K]  `ottlarg:"0"`
+	Delimiter     ottl.Optional[string] `ottlarg:"1"`
+	PairDelimiter ottl.Optional[string] `ottlarg:"2"`
+}
+
+func NewParseKeyValueFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseKeyValue", &ParseKeyValueArguments[K]{}, createParseKeyValueFunction[K])
+}
+
+func createParseKeyValueFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseKeyValueArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseKeyValueFactory args must be of type *ParseKeyValueArguments[K]")
+	}
+
+	return parseKeyValue(args.Target, args.Delimiter, args.PairDelimiter)
+}
+
+// parseKeyValue returns a `pcommon.Map` of the key value pairs of the target string,
+// the same way as the stanza key_value_parser operator.
+func parseKeyValue[K any](target ottl.StringGetter[K], d ottl.Optional[string], p ottl.Optional[string]) (ottl.ExprFunc[K], error) {
+	delimiter := defaultKeyValueDelimiter
+	if !d.IsEmpty() {
+		delimiter = d.Get()
+	}
+	if delimiter == "" {
+		return nil, fmt.Errorf("delimiter cannot be empty")
+	}
+
+	// split on whitespace by default, if pair delimiter is set, use strings.Split()
+	pairSplitFunc := splitStringByWhitespace
+	if !p.IsEmpty() {
+		pairDelimiter := p.Get()
+		if pairDelimiter == "" {
+			return nil, fmt.Errorf("pair delimiter cannot be empty")
+		}
+		if pairDelimiter == delimiter {
+			return nil, fmt.Errorf("pair delimiter %q cannot be equal to delimiter %q", pairDelimiter, delimiter)
+		}
+		pairSplitFunc = func(input string) []string {
+			return strings.Split(input, pairDelimiter)
+		}
+	}
+
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if source == "" {
+			return nil, fmt.Errorf("cannot parse from empty target")
+		}
+
+		result := pcommon.NewMap()
+		for _, raw := range pairSplitFunc(source) {
+			m := strings.Split(raw, delimiter)
+			if len(m) != 2 {
+				err = multierr.Append(err, fmt.Errorf("expected '%s' to split by '%s' into two items, got %d", raw, delimiter, len(m)))
+				continue
+			}
+
+			key := strings.TrimSpace(strings.Trim(m[0], "\"'"))
+			value := strings.TrimSpace(strings.Trim(m[1], "\"'"))
+			result.PutStr(key, value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}, nil
+}
+
+// splitStringByWhitespace splits on whitespace and preserves quoted text.
+func splitStringByWhitespace(input string) []string {
+	quoted := false
+	return strings.FieldsFunc(input, func(r rune) bool {
+		if r == '"' || r == '\'' {
+			quoted = !quoted
+		}
+		return !quoted && r == ' '
+	})
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseKeyValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		delimiter     ottl.Optional[string]
+		pairDelimiter ottl.Optional[string]
+		expected      map[string]interface{}
+	}{
+		{
+			name:   "default delimiters",
+			target: "name=val user=bob",
+			expected: map[string]interface{}{
+				"name": "val",
+				"user": "bob",
+			},
+		},
+		{
+			name:   "quoted values with whitespace",
+			target: `name="val with spaces" user='bob smith' empty=""`,
+			expected: map[string]interface{}{
+				"name":  "val with spaces",
+				"user":  "bob smith",
+				"empty": "",
+			},
+		},
+		{
+			name:      "custom delimiter",
+			target:    "name:val user:bob",
+			delimiter: ottl.NewTestingOptional[string](":"),
+			expected: map[string]interface{}{
+				"name": "val",
+				"user": "bob",
+			},
+		},
+		{
+			name:          "custom pair delimiter",
+			target:        "name=val; user=bob; ip = 10.0.0.1",
+			pairDelimiter: ottl.NewTestingOptional[string](";"),
+			expected: map[string]interface{}{
+				"name": "val",
+				"user": "bob",
+				"ip":   "10.0.0.1",
+			},
+		},
+		{
+			name:          "custom delimiters",
+			target:        "name:val|user:bob",
+			delimiter:     ottl.NewTestingOptional[string](":"),
+			pairDelimiter: ottl.NewTestingOptional[string]("|"),
+			expected: map[string]interface{}{
+				"name": "val",
+				"user": "bob",
+			},
+		},
+		{
+			name:   "duplicate keys keep the last value",
+			target: "name=val name=other",
+			expected: map[string]interface{}{
+				"name": "other",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardTypeGetter[any, string]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := parseKeyValue[any](target, tt.delimiter, tt.pairDelimiter)
+			assert.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+
+			expected := pcommon.NewMap()
+			assert.NoError(t, expected.FromRaw(tt.expected))
+			assert.Equal(t, expected.AsRaw(), result.(pcommon.Map).AsRaw())
+		})
+	}
+}
+
+func Test_parseKeyValue_bad_target(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        interface{}
+		expectedError string
+	}{
+		{
+			name:          "empty target",
+			target:        "",
+			expectedError: "cannot parse from empty target",
+		},
+		{
+			name:          "pair without delimiter",
+			target:        "name=val user",
+			expectedError: "expected 'user' to split by '=' into two items, got 1",
+		},
+		{
+			name:          "pair with multiple delimiters",
+			target:        "name=val=other",
+			expectedError: "expected 'name=val=other' to split by '=' into two items, got 3",
+		},
+		{
+			name:          "not a string",
+			target:        int64(1),
+			expectedError: "expected string but got int64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardTypeGetter[any, string]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := parseKeyValue[any](target, ottl.Optional[string]{}, ottl.Optional[string]{})
+			assert.NoError(t, err)
+
+			_, err = exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+func Test_parseKeyValue_bad_delimiters(t *testing.T) {
+	target := &ottl.StandardTypeGetter[any, string]{
+		Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+			return "name=val", nil
+		},
+	}
+
+	_, err := parseKeyValue[any](target, ottl.NewTestingOptional[string](""), ottl.Optional[string]{})
+	assert.ErrorContains(t, err, "delimiter cannot be empty")
+
+	_, err = parseKeyValue[any](target, ottl.Optional[string]{}, ottl.NewTestingOptional[string](""))
+	assert.ErrorContains(t, err, "pair delimiter cannot be empty")
+
+	_, err = parseKeyValue[any](target, ottl.NewTestingOptional[string](":"), ottl.NewTestingOptional[string](":"))
+	assert.ErrorContains(t, err, "cannot be equal to delimiter")
+}
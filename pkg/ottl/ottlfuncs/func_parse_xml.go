@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/xmlutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ParseXMLArguments[K any] struct {
+	Target ottl.StringGetter[K] `ottlarg:"0"`
+}
+
+func NewParseXMLFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseXML", &ParseXMLArguments[K]{}, createParseXMLFunction[K])
+}
+
+func createParseXMLFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseXMLArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseXMLFactory args must be of type *ParseXMLArguments[K]")
+	}
+
+	return parseXML(args.Target), nil
+}
+
+// parseXML returns a `pcommon.Map` struct that is a result of parsing the target string as XML.
+// The document is decoded with xmlutil.Decode, which describes the layout of the map.
+func parseXML[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		targetVal, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		root, err := xmlutil.Decode(targetVal, false)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal xml: %w", err)
+		}
+
+		result := pcommon.NewMap()
+		if err = result.FromRaw(root); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+}
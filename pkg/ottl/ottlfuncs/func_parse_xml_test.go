@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ParseXML(t *testing.T) {
+	tests := []struct {
+		name   string
+		target ottl.StringGetter[any]
+		want   func(pcommon.Map)
+	}{
+		{
+			name: "single element",
+			target: ottl.StandardTypeGetter[any, string]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return `<Log>some text</Log>`, nil
+				},
+			},
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("tag", "Log")
+				expectedMap.PutStr("content", "some text")
+			},
+		},
+		{
+			name: "attributes",
+			target: ottl.StandardTypeGetter[any, string]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return `<Log id="1" level="info"/>`, nil
+				},
+			},
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("tag", "Log")
+				attributes := expectedMap.PutEmptyMap("attributes")
+				attributes.PutStr("id", "1")
+				attributes.PutStr("level", "info")
+			},
+		},
+		{
+			name: "nested elements",
+			target: ottl.StandardTypeGetter[any, string]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return `<?xml version="1.0" encoding="UTF-8"?>
+<Log>
+	<User id="12">bob</User>
+	<Message>
+		<Text>hello</Text>
+	</Message>
+</Log>`, nil
+				},
+			},
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("tag", "Log")
+				children := expectedMap.PutEmptySlice("children")
+
+				user := children.AppendEmpty().SetEmptyMap()
+				user.PutStr("tag", "User")
+				user.PutEmptyMap("attributes").PutStr("id", "12")
+				user.PutStr("content", "bob")
+
+				message := children.AppendEmpty().SetEmptyMap()
+				message.PutStr("tag", "Message")
+				text := message.PutEmptySlice("children").AppendEmpty().SetEmptyMap()
+				text.PutStr("tag", "Text")
+				text.PutStr("content", "hello")
+			},
+		},
+		{
+			name: "namespaces",
+			target: ottl.StandardTypeGetter[any, string]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return `<e:Event xmlns:e="urn:events"><e:EventID e:Qualifiers="0">4624</e:EventID></e:Event>`, nil
+				},
+			},
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("tag", "Event")
+				eventID := expectedMap.PutEmptySlice("children").AppendEmpty().SetEmptyMap()
+				eventID.PutStr("tag", "EventID")
+				eventID.PutEmptyMap("attributes").PutStr("Qualifiers", "0")
+				eventID.PutStr("content", "4624")
+			},
+		},
+		{
+			name: "mixed content",
+			target: ottl.StandardTypeGetter[any, string]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return `<Log>hello <b>world</b> again</Log>`, nil
+				},
+			},
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("tag", "Log")
+				expectedMap.PutStr("content", "hello  again")
+				child := expectedMap.PutEmptySlice("children").AppendEmpty().SetEmptyMap()
+				child.PutStr("tag", "b")
+				child.PutStr("content", "world")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseXML(tt.target)
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+
+			resultMap, ok := result.(pcommon.Map)
+			if !ok {
+				assert.Fail(t, "pcommon.Map not returned")
+			}
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+
+			assert.Equal(t, expected.AsRaw(), resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_ParseXML_Error(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		expectedError string
+	}{
+		{
+			name:          "empty document",
+			target:        "",
+			expectedError: "the document has no root element",
+		},
+		{
+			name:          "multiple root elements",
+			target:        "<a/><b/>",
+			expectedError: "the document has more than one root element",
+		},
+		{
+			name:          "unclosed element",
+			target:        "<a><b></a>",
+			expectedError: "unmarshal xml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardTypeGetter[any, string]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := parseXML[any](target)
+			_, err := exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"crypto/sha1" // #nosec
+	"encoding/hex"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type SHA1Arguments[K any] struct {
+	Target ottl.StringLikeGetter[K] `ottlarg:"0"`
+	Salt   ottl.Optional[string]    `ottlarg:"1"`
+}
+
+func NewSHA1Factory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("SHA1", &SHA1Arguments[K]{}, createSHA1Function[K])
+}
+
+func createSHA1Function[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*SHA1Arguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("SHA1Factory args must be of type *SHA1Arguments[K]")
+	}
+
+	return sha1Hash(args.Target, args.Salt), nil
+}
+
+// sha1Hash returns the hex encoded SHA-1 hash of the target, the salt is prepended to the target before hashing.
+func sha1Hash[K any](target ottl.StringLikeGetter[K], salt ottl.Optional[string]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return nil, nil
+		}
+		h := sha1.New() // #nosec
+		if !salt.IsEmpty() {
+			_, _ = h.Write([]byte(salt.Get()))
+		}
+		_, _ = h.Write([]byte(*val))
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+}
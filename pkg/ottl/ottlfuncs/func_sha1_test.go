@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_SHA1(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		salt     ottl.Optional[string]
+		expected interface{}
+	}{
+		{
+			name:     "string",
+			value:    "user@example.com",
+			expected: "63a710569261a24b3766275b7000ce8d7b32e2f7",
+		},
+		{
+			name:     "string with salt",
+			value:    "user@example.com",
+			salt:     ottl.NewTestingOptional[string]("my-salt"),
+			expected: "495c671913fd25f301eadc8bb3f072d76c8b34f2",
+		},
+		{
+			name:     "int",
+			value:    int64(12345),
+			expected: "8cb2237d0679ca88db6464eac60da96345513964",
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := sha1Hash[any](&ottl.StandardStringLikeGetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.salt)
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_SHA1_error(t *testing.T) {
+	exprFunc := sha1Hash[any](&ottl.StandardStringLikeGetter[any]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return nil, errors.New("error getting value")
+		},
+	}, ottl.Optional[string]{})
+	_, err := exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "error getting value")
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type SHA256Arguments[K any] struct {
+	Target ottl.StringLikeGetter[K] `ottlarg:"0"`
+	Salt   ottl.Optional[string]    `ottlarg:"1"`
+}
+
+func NewSHA256Factory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("SHA256", &SHA256Arguments[K]{}, createSHA256Function[K])
+}
+
+func createSHA256Function[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*SHA256Arguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("SHA256Factory args must be of type *SHA256Arguments[K]")
+	}
+
+	return sha256Hash(args.Target, args.Salt), nil
+}
+
+// sha256Hash returns the hex encoded SHA-256 hash of the target, the salt is prepended to the target before hashing.
+func sha256Hash[K any](target ottl.StringLikeGetter[K], salt ottl.Optional[string]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return nil, nil
+		}
+		h := sha256.New()
+		if !salt.IsEmpty() {
+			_, _ = h.Write([]byte(salt.Get()))
+		}
+		_, _ = h.Write([]byte(*val))
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_SHA256(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		salt     ottl.Optional[string]
+		expected interface{}
+	}{
+		{
+			name:     "string",
+			value:    "user@example.com",
+			expected: "b4c9a289323b21a01c3e940f150eb9b8c542587f1abfd8f0e1cc1ffc5e475514",
+		},
+		{
+			name:     "string with salt",
+			value:    "user@example.com",
+			salt:     ottl.NewTestingOptional[string]("my-salt"),
+			expected: "20afa99babd33ffd3b7a35ecf0a01e02ff84cedb7e89a3ae0e4c45e6ad3c831b",
+		},
+		{
+			name:     "int",
+			value:    int64(12345),
+			expected: "5994471abb01112afcc18159f6cc74b4f511b99806da59b3caf5a9c173cacfc5",
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := sha256Hash[any](&ottl.StandardStringLikeGetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.salt)
+			result, err := exprFunc(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_SHA256_error(t *testing.T) {
+	exprFunc := sha256Hash[any](&ottl.StandardStringLikeGetter[any]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return nil, errors.New("error getting value")
+		},
+	}, ottl.Optional[string]{})
+	_, err := exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "error getting value")
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_String(t *testing.T) {
+	pMap := pcommon.NewMap()
+	pMap.PutStr("a", "b")
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected interface{}
+	}{
+		{
+			name:     "string",
+			value:    "test",
+			expected: "test",
+		},
+		{
+			name:     "int64",
+			value:    int64(333),
+			expected: "333",
+		},
+		{
+			name:     "float64",
+			value:    float64(2.7),
+			expected: "2.7",
+		},
+		{
+			name:     "bool",
+			value:    true,
+			expected: "true",
+		},
+		{
+			name:     "byte array",
+			value:    []byte{0x01, 0xab},
+			expected: "01ab",
+		},
+		{
+			name:     "pcommon.Map",
+			value:    pMap,
+			expected: `{"a":"b"}`,
+		},
+		{
+			name:     "pcommon.Value",
+			value:    pcommon.NewValueInt(1),
+			expected: "1",
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := stringFunc[interface{}](&ottl.StandardStringLikeGetter[interface{}]{
+				Getter: func(context.Context, interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
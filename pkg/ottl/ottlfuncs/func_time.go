@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	strptime "github.com/observiq/ctimefmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type TimeArguments[K any] struct {
+	Target ottl.StringGetter[K] `ottlarg:"0"`
+	Format string               `ottlarg:"1"`
+}
+
+func NewTimeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Time", &TimeArguments[K]{}, createTimeFunction[K])
+}
+
+func createTimeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*TimeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("TimeFactory args must be of type *TimeArguments[K]")
+	}
+
+	return timeFunc(args.Target, args.Format)
+}
+
+func timeFunc[K any](target ottl.StringGetter[K], format string) (ottl.ExprFunc[K], error) {
+	if format == "" {
+		return nil, fmt.Errorf("format cannot be empty")
+	}
+	layout, err := strptime.ToNative(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format %q: %w", format, err)
+	}
+
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if val == "" {
+			return nil, fmt.Errorf("time cannot be empty")
+		}
+		return time.Parse(layout, val)
+	}, nil
+}
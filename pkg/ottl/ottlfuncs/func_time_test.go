@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Time(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     ottl.StringGetter[interface{}]
+		format   string
+		expected time.Time
+	}{
+		{
+			name: "simple short form",
+			time: &ottl.StandardTypeGetter[interface{}, string]{
+				Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+					return "2023-04-12", nil
+				},
+			},
+			format:   "%Y-%m-%d",
+			expected: time.Date(2023, 4, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "simple short form with short year and slashes",
+			time: &ottl.StandardTypeGetter[interface{}, string]{
+				Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+					return "11/11/11", nil
+				},
+			},
+			format:   "%d/%m/%y",
+			expected: time.Date(2011, 11, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "month day year with time",
+			time: &ottl.StandardTypeGetter[interface{}, string]{
+				Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+					return "Feb 15, 2023 13:05:30", nil
+				},
+			},
+			format:   "%b %d, %Y %H:%M:%S",
+			expected: time.Date(2023, 2, 15, 13, 5, 30, 0, time.UTC),
+		},
+		{
+			name: "with time zone offset",
+			time: &ottl.StandardTypeGetter[interface{}, string]{
+				Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+					return "2023-05-26T12:34:56.123-0700", nil
+				},
+			},
+			format:   "%Y-%m-%dT%H:%M:%S.%L%z",
+			expected: time.Date(2023, 5, 26, 19, 34, 56, 123000000, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := timeFunc(tt.time, tt.format)
+			assert.NoError(t, err)
+			result, err := exprFunc(nil, nil)
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(result.(time.Time)), "expected %v but got %v", tt.expected, result)
+		})
+	}
+}
+
+func Test_TimeError(t *testing.T) {
+	tests := []struct {
+		name          string
+		time          ottl.StringGetter[interface{}]
+		format        string
+		expectedError string
+	}{
+		{
+			name: "empty time",
+			time: &ottl.StandardTypeGetter[interface{}, string]{
+				Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+					return "", nil
+				},
+			},
+			format:        "%Y-%m-%d",
+			expectedError: "time cannot be empty",
+		},
+		{
+			name: "time not matching the format",
+			time: &ottl.StandardTypeGetter[interface{}, string]{
+				Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+					return "12/04/2023", nil
+				},
+			},
+			format:        "%Y-%m-%d",
+			expectedError: "cannot parse",
+		},
+		{
+			name: "not a string",
+			time: &ottl.StandardTypeGetter[interface{}, string]{
+				Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+					return int64(1), nil
+				},
+			},
+			format:        "%Y-%m-%d",
+			expectedError: "expected string but got int64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := timeFunc(tt.time, tt.format)
+			assert.NoError(t, err)
+			_, err = exprFunc(nil, nil)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+func Test_TimeFormatError(t *testing.T) {
+	target := &ottl.StandardTypeGetter[interface{}, string]{
+		Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+			return "2023-04-12", nil
+		},
+	}
+
+	_, err := timeFunc[interface{}](target, "")
+	assert.ErrorContains(t, err, "format cannot be empty")
+
+	_, err = timeFunc[interface{}](target, "%Y-%m-%d %1")
+	assert.ErrorContains(t, err, "invalid format")
+}
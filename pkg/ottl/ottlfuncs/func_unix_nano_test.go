@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_UnixNano(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     interface{}
+		expected int64
+	}{
+		{
+			name:     "January 1, 2023",
+			time:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: int64(1672531200000000000),
+		},
+		{
+			name:     "with nanoseconds and time zone",
+			time:     time.Date(2023, 1, 1, 2, 0, 0, 123, time.FixedZone("UTC+2", 2*60*60)),
+			expected: int64(1672531200000000123),
+		},
+		{
+			name:     "before the epoch",
+			time:     time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC),
+			expected: int64(-1000000000),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := unixNano[interface{}](&ottl.StandardTypeGetter[interface{}, time.Time]{
+				Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+					return tt.time, nil
+				},
+			})
+			result, err := exprFunc(nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_UnixNanoError(t *testing.T) {
+	exprFunc := unixNano[interface{}](&ottl.StandardTypeGetter[interface{}, time.Time]{
+		Getter: func(ctx context.Context, tCtx interface{}) (interface{}, error) {
+			return "2023-01-01", nil
+		},
+	})
+	_, err := exprFunc(nil, nil)
+	assert.ErrorContains(t, err, "expected time.Time but got string")
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ValuesArguments[K any] struct {
+	Target ottl.PMapGetter[K] `ottlarg:"0"`
+}
+
+func NewValuesFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Values", &ValuesArguments[K]{}, createValuesFunction[K])
+}
+
+func createValuesFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ValuesArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ValuesFactory args must be of type *ValuesArguments[K]")
+	}
+
+	return valuesFunc(args.Target), nil
+}
+
+// valuesFunc returns the values of the target map as a list, in the order of the map.
+func valuesFunc[K any](target ottl.PMapGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		values := pcommon.NewSlice()
+		values.EnsureCapacity(val.Len())
+		val.Range(func(_ string, v pcommon.Value) bool {
+			v.CopyTo(values.AppendEmpty())
+			return true
+		})
+		return values, nil
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pdatautil // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// MetricsBatch builds metrics out of a subset of the metrics of another pmetric.Metrics,
+// keeping track of the resources and scopes already copied so that they are not
+// duplicated for every metric appended to the batch.
+type MetricsBatch struct {
+	md        pmetric.Metrics
+	resources map[int]pmetric.ResourceMetrics
+	scopes    map[[2]int]pmetric.MetricSlice
+}
+
+// NewMetricsBatch creates an empty MetricsBatch.
+func NewMetricsBatch() *MetricsBatch {
+	return &MetricsBatch{
+		md:        pmetric.NewMetrics(),
+		resources: make(map[int]pmetric.ResourceMetrics),
+		scopes:    make(map[[2]int]pmetric.MetricSlice),
+	}
+}
+
+// Metrics returns the metrics of the batch.
+func (b *MetricsBatch) Metrics() pmetric.Metrics {
+	return b.md
+}
+
+// AppendMetric appends an empty metric to the copy of the scope sm of the resource rm,
+// the indexes rmIdx and smIdx identify both of them within the original metrics.
+func (b *MetricsBatch) AppendMetric(rmIdx int, rm pmetric.ResourceMetrics, smIdx int, sm pmetric.ScopeMetrics) pmetric.Metric {
+	key := [2]int{rmIdx, smIdx}
+	metrics, ok := b.scopes[key]
+	if !ok {
+		destRm, ok := b.resources[rmIdx]
+		if !ok {
+			destRm = b.md.ResourceMetrics().AppendEmpty()
+			rm.Resource().CopyTo(destRm.Resource())
+			destRm.SetSchemaUrl(rm.SchemaUrl())
+			b.resources[rmIdx] = destRm
+		}
+		destSm := destRm.ScopeMetrics().AppendEmpty()
+		sm.Scope().CopyTo(destSm.Scope())
+		destSm.SetSchemaUrl(sm.SchemaUrl())
+		metrics = destSm.Metrics()
+		b.scopes[key] = metrics
+	}
+	return metrics.AppendEmpty()
+}
+
+// ForEachDataPointAttributes calls fn with the index and
+// attributes of every data point of the metric.
+func ForEachDataPointAttributes(m pmetric.Metric, fn func(idx int, attrs pcommon.Map)) {
+	//exhaustive:enforce
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+			fn(i, m.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			fn(i, m.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < m.Histogram().DataPoints().Len(); i++ {
+			fn(i, m.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+			fn(i, m.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < m.Summary().DataPoints().Len(); i++ {
+			fn(i, m.Summary().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
+
+// RemoveDataPointsIf removes the data points of the metric whose index fn returns true for.
+func RemoveDataPointsIf(m pmetric.Metric, fn func(idx int) bool) {
+	idx := -1
+	next := func() int {
+		idx++
+		return idx
+	}
+	//exhaustive:enforce
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		m.Gauge().DataPoints().RemoveIf(func(pmetric.NumberDataPoint) bool { return fn(next()) })
+	case pmetric.MetricTypeSum:
+		m.Sum().DataPoints().RemoveIf(func(pmetric.NumberDataPoint) bool { return fn(next()) })
+	case pmetric.MetricTypeHistogram:
+		m.Histogram().DataPoints().RemoveIf(func(pmetric.HistogramDataPoint) bool { return fn(next()) })
+	case pmetric.MetricTypeExponentialHistogram:
+		m.ExponentialHistogram().DataPoints().RemoveIf(func(pmetric.ExponentialHistogramDataPoint) bool { return fn(next()) })
+	case pmetric.MetricTypeSummary:
+		m.Summary().DataPoints().RemoveIf(func(pmetric.SummaryDataPoint) bool { return fn(next()) })
+	case pmetric.MetricTypeEmpty:
+	}
+}
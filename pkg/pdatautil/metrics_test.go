@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pdatautil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestMetricsBatch(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, svc := range []string{"svc-1", "svc-2"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.SetSchemaUrl("resource-schema")
+		rm.Resource().Attributes().PutStr("service.name", svc)
+		for _, scope := range []string{"scope-1", "scope-2"} {
+			sm := rm.ScopeMetrics().AppendEmpty()
+			sm.SetSchemaUrl("scope-schema")
+			sm.Scope().SetName(scope)
+			sm.Metrics().AppendEmpty().SetName("m1")
+			sm.Metrics().AppendEmpty().SetName("m2")
+		}
+	}
+
+	b := NewMetricsBatch()
+	rms := md.ResourceMetrics()
+	for _, idx := range [][3]int{{0, 0, 0}, {0, 0, 1}, {0, 1, 0}, {1, 1, 1}} {
+		rm := rms.At(idx[0])
+		sm := rm.ScopeMetrics().At(idx[1])
+		sm.Metrics().At(idx[2]).CopyTo(b.AppendMetric(idx[0], rm, idx[1], sm))
+	}
+
+	// Resources and scopes are only copied once.
+	got := b.Metrics()
+	require.Equal(t, 2, got.ResourceMetrics().Len())
+	first := got.ResourceMetrics().At(0)
+	assert.Equal(t, "resource-schema", first.SchemaUrl())
+	assert.Equal(t, map[string]any{"service.name": "svc-1"}, first.Resource().Attributes().AsRaw())
+	require.Equal(t, 2, first.ScopeMetrics().Len())
+	assert.Equal(t, "scope-schema", first.ScopeMetrics().At(0).SchemaUrl())
+	assert.Equal(t, "scope-1", first.ScopeMetrics().At(0).Scope().Name())
+	assert.Equal(t, 2, first.ScopeMetrics().At(0).Metrics().Len())
+	assert.Equal(t, "scope-2", first.ScopeMetrics().At(1).Scope().Name())
+	assert.Equal(t, 1, first.ScopeMetrics().At(1).Metrics().Len())
+
+	second := got.ResourceMetrics().At(1)
+	assert.Equal(t, map[string]any{"service.name": "svc-2"}, second.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, second.ScopeMetrics().Len())
+	assert.Equal(t, "scope-2", second.ScopeMetrics().At(0).Scope().Name())
+	assert.Equal(t, "m2", second.ScopeMetrics().At(0).Metrics().At(0).Name())
+	assert.Equal(t, 8, md.MetricCount())
+}
+
+func TestDataPoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric func(values []string) pmetric.Metric
+	}{
+		{
+			name: "gauge",
+			metric: func(values []string) pmetric.Metric {
+				m := pmetric.NewMetric()
+				dps := m.SetEmptyGauge().DataPoints()
+				for _, v := range values {
+					dps.AppendEmpty().Attributes().PutStr("k", v)
+				}
+				return m
+			},
+		},
+		{
+			name: "sum",
+			metric: func(values []string) pmetric.Metric {
+				m := pmetric.NewMetric()
+				dps := m.SetEmptySum().DataPoints()
+				for _, v := range values {
+					dps.AppendEmpty().Attributes().PutStr("k", v)
+				}
+				return m
+			},
+		},
+		{
+			name: "histogram",
+			metric: func(values []string) pmetric.Metric {
+				m := pmetric.NewMetric()
+				dps := m.SetEmptyHistogram().DataPoints()
+				for _, v := range values {
+					dps.AppendEmpty().Attributes().PutStr("k", v)
+				}
+				return m
+			},
+		},
+		{
+			name: "exponential_histogram",
+			metric: func(values []string) pmetric.Metric {
+				m := pmetric.NewMetric()
+				dps := m.SetEmptyExponentialHistogram().DataPoints()
+				for _, v := range values {
+					dps.AppendEmpty().Attributes().PutStr("k", v)
+				}
+				return m
+			},
+		},
+		{
+			name: "summary",
+			metric: func(values []string) pmetric.Metric {
+				m := pmetric.NewMetric()
+				dps := m.SetEmptySummary().DataPoints()
+				for _, v := range values {
+					dps.AppendEmpty().Attributes().PutStr("k", v)
+				}
+				return m
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.metric([]string{"a", "b", "c", "d"})
+			var values []string
+			ForEachDataPointAttributes(m, func(idx int, attrs pcommon.Map) {
+				v, _ := attrs.Get("k")
+				assert.Equal(t, len(values), idx)
+				values = append(values, v.Str())
+			})
+			assert.Equal(t, []string{"a", "b", "c", "d"}, values)
+
+			RemoveDataPointsIf(m, func(idx int) bool { return idx%2 == 0 })
+			values = nil
+			ForEachDataPointAttributes(m, func(idx int, attrs pcommon.Map) {
+				v, _ := attrs.Get("k")
+				values = append(values, v.Str())
+			})
+			assert.Equal(t, []string{"b", "d"}, values)
+		})
+	}
+}
+
+func TestDataPointsEmptyMetric(t *testing.T) {
+	m := pmetric.NewMetric()
+	ForEachDataPointAttributes(m, func(int, pcommon.Map) {
+		assert.Fail(t, "an empty metric has no data points")
+	})
+	RemoveDataPointsIf(m, func(int) bool { return true })
+	assert.Equal(t, pmetric.MetricTypeEmpty, m.Type())
+}
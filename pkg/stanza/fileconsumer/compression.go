@@ -0,0 +1,292 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	compressionNone = ""
+	compressionAuto = "auto"
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// detectCompression returns the compression of the file according to the configured compression.
+// With `auto`, the compression is detected from the magic number at the beginning of the file,
+// files that don't start with a known magic number are read as plain text.
+func detectCompression(file *os.File, configured string) (string, error) {
+	if configured != compressionAuto {
+		return configured, nil
+	}
+	header := make([]byte, len(zstdMagic))
+	n, err := file.ReadAt(header, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("reading compression header: %w", err)
+	}
+	header = header[:n]
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return compressionGzip, nil
+	case bytes.HasPrefix(header, zstdMagic):
+		return compressionZstd, nil
+	default:
+		return compressionNone, nil
+	}
+}
+
+// memberBoundary is the position of the end of a complete gzip member or zstd frame,
+// both in the compressed file and in the decompressed content. Decompression can
+// resume at a boundary without reading the content that precedes it.
+type memberBoundary struct {
+	Compressed   int64
+	Decompressed int64
+}
+
+// decompressor reads the decompressed content of a file from a member boundary.
+// A truncated stream, as found in a file that is still being written,
+// is reported as the end of the file so that it is read again on the next poll.
+type decompressor struct {
+	file        *os.File
+	compression string
+
+	member    io.Reader
+	memberEnd func() (int64, bool)
+	start     memberBoundary
+	read      int64
+
+	// boundaries holds the start of every member opened so far.
+	boundaries []memberBoundary
+
+	gzip *gzip.Reader
+	zstd *zstd.Decoder
+}
+
+func newDecompressor(file *os.File, compression string, start memberBoundary) (*decompressor, error) {
+	switch compression {
+	case compressionGzip, compressionZstd:
+	default:
+		return nil, fmt.Errorf("unsupported compression '%s'", compression)
+	}
+	return &decompressor{
+		file:        file,
+		compression: compression,
+		start:       start,
+		boundaries:  []memberBoundary{start},
+	}, nil
+}
+
+func (d *decompressor) Read(dst []byte) (int, error) {
+	for {
+		if d.member == nil {
+			if err := d.openMember(); err != nil {
+				return 0, err
+			}
+		}
+
+		n, err := d.member.Read(dst)
+		d.read += int64(n)
+		switch {
+		case errors.Is(err, io.EOF):
+			end, complete := d.memberEnd()
+			if !complete {
+				return n, io.EOF
+			}
+			d.member = nil
+			d.start = memberBoundary{Compressed: end, Decompressed: d.start.Decompressed + d.read}
+			d.read = 0
+			d.boundaries = append(d.boundaries, d.start)
+			if n > 0 {
+				return n, nil
+			}
+		case errors.Is(err, io.ErrUnexpectedEOF):
+			return n, io.EOF
+		default:
+			return n, err
+		}
+	}
+}
+
+// openMember starts decompressing the member at the current boundary,
+// io.EOF is returned when there is no complete header to start from.
+func (d *decompressor) openMember() error {
+	// A section reader does not move the offset of the file.
+	src := io.NewSectionReader(d.file, d.start.Compressed, math.MaxInt64-d.start.Compressed)
+	if d.compression == compressionGzip {
+		return d.openGzipMember(src)
+	}
+	return d.openZstdFrame(src)
+}
+
+func (d *decompressor) openGzipMember(src io.Reader) error {
+	// The gzip reader reads through a byte reader without buffering on its own,
+	// so the compressed size of a member is what was read minus what is buffered.
+	counter := &countingReader{r: src}
+	br := bufio.NewReader(counter)
+	var err error
+	if d.gzip == nil {
+		d.gzip, err = gzip.NewReader(br)
+	} else {
+		err = d.gzip.Reset(br)
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		// No further member, or its header has not been completely written yet.
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("open gzip stream: %w", err)
+	}
+	d.gzip.Multistream(false)
+	d.member = d.gzip
+	start := d.start.Compressed
+	d.memberEnd = func() (int64, bool) {
+		return start + counter.n - int64(br.Buffered()), true
+	}
+	return nil
+}
+
+func (d *decompressor) openZstdFrame(src io.Reader) error {
+	size, complete, err := zstdFrameSize(d.file, d.start.Compressed)
+	if err != nil {
+		return err
+	}
+	if size == 0 {
+		return io.EOF
+	}
+	if complete {
+		src = io.LimitReader(src, size)
+	}
+	if d.zstd == nil {
+		d.zstd, err = zstd.NewReader(src, zstd.WithDecoderConcurrency(1))
+	} else {
+		err = d.zstd.Reset(src)
+	}
+	if err != nil {
+		return fmt.Errorf("open zstd stream: %w", err)
+	}
+	d.member = d.zstd
+	end := d.start.Compressed + size
+	d.memberEnd = func() (int64, bool) {
+		return end, complete
+	}
+	return nil
+}
+
+// zstdFrameSize walks the headers of the zstd frame starting at offset, returning
+// the size of the frame and whether it was completely written. A size of 0 is
+// returned if not even the header of the frame is available.
+func zstdFrameSize(file *os.File, offset int64) (int64, bool, error) {
+	buf := make([]byte, zstd.HeaderMaxSize)
+	n, err := file.ReadAt(buf, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, false, err
+	}
+	var header zstd.Header
+	if err = header.Decode(buf[:n]); errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, fmt.Errorf("open zstd stream: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, false, err
+	}
+	available := info.Size() - offset
+
+	if header.Skippable {
+		size := int64(header.HeaderSize) + int64(header.SkippableSize)
+		return size, size <= available, nil
+	}
+
+	size := int64(header.HeaderSize)
+	blockHeader := make([]byte, 3)
+	for {
+		if size+int64(len(blockHeader)) > available {
+			return available, false, nil
+		}
+		if _, err = file.ReadAt(blockHeader, offset+size); err != nil {
+			return 0, false, err
+		}
+		// Block headers are 3 bytes little endian: the last block flag,
+		// 2 bits of block type and 21 bits of block size.
+		bh := uint32(blockHeader[0]) | uint32(blockHeader[1])<<8 | uint32(blockHeader[2])<<16
+		blockSize := int64(bh >> 3)
+		if blockType := (bh >> 1) & 3; blockType == 1 {
+			// RLE blocks hold a single byte repeated block size times.
+			blockSize = 1
+		}
+		size += int64(len(blockHeader)) + blockSize
+		if bh&1 == 1 {
+			break
+		}
+	}
+	if header.HasCheckSum {
+		size += 4
+	}
+	if size > available {
+		return available, false, nil
+	}
+	return size, true, nil
+}
+
+// boundaryBefore returns the last boundary passed that is not past offset.
+func (d *decompressor) boundaryBefore(offset int64) memberBoundary {
+	boundary := d.boundaries[0]
+	for _, b := range d.boundaries {
+		if b.Decompressed <= offset {
+			boundary = b
+		}
+	}
+	return boundary
+}
+
+// Close releases the resources held by the decompressor, the file is not closed.
+func (d *decompressor) Close() {
+	if d.gzip != nil {
+		_ = d.gzip.Close()
+	}
+	if d.zstd != nil {
+		d.zstd.Close()
+	}
+}
+
+// decompressedSize returns the size of the decompressed content of the file
+// along with the boundary of its last complete member.
+func decompressedSize(file *os.File, compression string) (int64, memberBoundary, error) {
+	d, err := newDecompressor(file, compression, memberBoundary{})
+	if err != nil {
+		return 0, memberBoundary{}, err
+	}
+	defer d.Close()
+	size, err := io.Copy(io.Discard, d)
+	return size, d.boundaryBefore(size), err
+}
+
+// countingReader keeps track of the number of bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
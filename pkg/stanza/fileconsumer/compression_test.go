@@ -0,0 +1,300 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func gzipCompress(t testing.TB, s string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zstdCompress(t testing.TB, s string) []byte {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func appendBytes(t testing.TB, path string, b []byte) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.Write(b)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func TestDetectCompression(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		content    []byte
+		configured string
+		expected   string
+	}{
+		{"AutoGzip", gzipCompress(t, "line\n"), compressionAuto, compressionGzip},
+		{"AutoZstd", zstdCompress(t, "line\n"), compressionAuto, compressionZstd},
+		{"AutoPlain", []byte("line\n"), compressionAuto, compressionNone},
+		{"AutoEmpty", []byte{}, compressionAuto, compressionNone},
+		{"Configured", []byte("line\n"), compressionGzip, compressionGzip},
+		{"None", gzipCompress(t, "line\n"), compressionNone, compressionNone},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "file")
+			require.NoError(t, os.WriteFile(path, tc.content, 0600))
+			file := openFile(t, path)
+
+			compression, err := detectCompression(file, tc.configured)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, compression)
+		})
+	}
+}
+
+func TestReadCompressedFile(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		compression string
+		compress    func(testing.TB, string) []byte
+	}{
+		{"Gzip", compressionGzip, gzipCompress},
+		{"Zstd", compressionZstd, zstdCompress},
+		{"AutoGzip", compressionAuto, gzipCompress},
+		{"AutoZstd", compressionAuto, zstdCompress},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = tc.compression
+			operator, emitCalls := buildTestManagerWithOptions(t, cfg)
+			operator.persister = testutil.NewMockPersister("test")
+
+			path := filepath.Join(tempDir, "app.log.1")
+			appendBytes(t, path, tc.compress(t, "line1\nline2\n"))
+
+			operator.poll(context.Background())
+			waitForTokens(t, emitCalls, [][]byte{[]byte("line1"), []byte("line2")})
+
+			// Concatenated streams are read as a single stream,
+			// only the content after the offset is emitted.
+			appendBytes(t, path, tc.compress(t, "line3\n"))
+			operator.poll(context.Background())
+			waitForToken(t, emitCalls, []byte("line3"))
+
+			operator.poll(context.Background())
+			expectNoTokens(t, emitCalls)
+			require.NoError(t, operator.Stop())
+		})
+	}
+}
+
+func TestReadPartiallyWrittenCompressedFile(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		compress func(testing.TB, string) []byte
+	}{
+		{"Gzip", gzipCompress},
+		{"Zstd", zstdCompress},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = compressionAuto
+			operator, emitCalls := buildTestManagerWithOptions(t, cfg)
+			operator.persister = testutil.NewMockPersister("test")
+
+			// The first line is written out as its own stream, the second
+			// stream is truncated as if it was still being written.
+			path := filepath.Join(tempDir, "app.log.1")
+			appendBytes(t, path, tc.compress(t, "line1\n"))
+			second := tc.compress(t, "line2\n")
+			appendBytes(t, path, second[:len(second)-4])
+
+			operator.poll(context.Background())
+			waitForToken(t, emitCalls, []byte("line1"))
+
+			appendBytes(t, path, second[len(second)-4:])
+			operator.poll(context.Background())
+			waitForToken(t, emitCalls, []byte("line2"))
+			require.NoError(t, operator.Stop())
+		})
+	}
+}
+
+func TestCompressedFileStartAtEnd(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = compressionGzip
+	operator, emitCalls := buildTestManagerWithOptions(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	path := filepath.Join(tempDir, "app.log.gz")
+	appendBytes(t, path, gzipCompress(t, "line1\n"))
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	appendBytes(t, path, gzipCompress(t, "line2\n"))
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("line2"))
+	require.NoError(t, operator.Stop())
+}
+
+func TestCompressedFingerprint(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = compressionAuto
+	cfg.FingerprintSize = MinFingerprintSize
+	operator, _ := buildTestManagerWithOptions(t, cfg)
+
+	path := filepath.Join(tempDir, "app.log.gz")
+	appendBytes(t, path, gzipCompress(t, "0123456789abcdefghij\n"))
+
+	fp, err := operator.readerFactory.newFingerprint(openFile(t, path))
+	require.NoError(t, err)
+	require.Equal(t, []byte("0123456789abcdef"), fp.FirstBytes)
+}
+
+func TestDecompressorMemberBoundaries(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		compression string
+		compress    func(testing.TB, string) []byte
+	}{
+		{"Gzip", compressionGzip, gzipCompress},
+		{"Zstd", compressionZstd, zstdCompress},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			first := tc.compress(t, "line1\n")
+			second := tc.compress(t, "line2\n")
+			third := tc.compress(t, "line3\n")
+			path := filepath.Join(t.TempDir(), "app.log.1")
+			appendBytes(t, path, first)
+			appendBytes(t, path, second)
+			appendBytes(t, path, third[:10])
+			file := openFile(t, path)
+
+			// The truncated member holds no complete data, so the last
+			// boundary is the end of the second member.
+			size, boundary, err := decompressedSize(file, tc.compression)
+			require.NoError(t, err)
+			require.Equal(t, int64(12), size)
+			require.Equal(t, memberBoundary{Compressed: int64(len(first) + len(second)), Decompressed: 12}, boundary)
+
+			// Decompression resumes without reading the first member.
+			d, err := newDecompressor(file, tc.compression, memberBoundary{Compressed: int64(len(first)), Decompressed: 6})
+			require.NoError(t, err)
+			defer d.Close()
+			content, err := io.ReadAll(d)
+			require.NoError(t, err)
+			require.Equal(t, "line2\n", string(content))
+			require.Equal(t, boundary, d.boundaryBefore(12))
+			require.Equal(t, memberBoundary{Compressed: int64(len(first)), Decompressed: 6}, d.boundaryBefore(11))
+		})
+	}
+}
+
+func TestCompressedFileRestart(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		compress func(testing.TB, string) []byte
+	}{
+		{"Gzip", gzipCompress},
+		{"Zstd", zstdCompress},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = compressionAuto
+			persister := testutil.NewMockPersister("test")
+
+			path := filepath.Join(tempDir, "app.log.1")
+			first := tc.compress(t, "line1\n")
+			appendBytes(t, path, first)
+
+			operatorOne, emitCallsOne := buildTestManagerWithOptions(t, cfg)
+			operatorOne.persister = persister
+			operatorOne.poll(context.Background())
+			waitForToken(t, emitCallsOne, []byte("line1"))
+			require.NoError(t, operatorOne.Stop())
+
+			// The size and member boundary of the compressed file are part of the checkpoint,
+			// so the file is neither decompressed on restart nor read again from its beginning.
+			operatorTwo, emitCallsTwo := buildTestManagerWithOptions(t, cfg)
+			operatorTwo.persister = persister
+			require.NoError(t, operatorTwo.loadLastPollFiles(context.Background()))
+			require.Len(t, operatorTwo.knownFiles, 1)
+			require.Equal(t, int64(len(first)), operatorTwo.knownFiles[0].CompressedSize)
+			require.Equal(t, memberBoundary{Compressed: int64(len(first)), Decompressed: 6}, operatorTwo.knownFiles[0].MemberBoundary)
+
+			operatorTwo.poll(context.Background())
+			expectNoTokens(t, emitCallsTwo)
+
+			appendBytes(t, path, tc.compress(t, "line2\n"))
+			operatorTwo.poll(context.Background())
+			waitForToken(t, emitCallsTwo, []byte("line2"))
+			require.NoError(t, operatorTwo.Stop())
+		})
+	}
+}
@@ -68,6 +68,7 @@ type Config struct {
 	DeleteAfterRead         bool                  `mapstructure:"delete_after_read,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"`
 	Header                  *HeaderConfig         `mapstructure:"header,omitempty"`
+	Compression             string                `mapstructure:"compression,omitempty"`
 }
 
 // Build will build a file input operator from the supplied configuration
@@ -148,6 +149,7 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit EmitFunc, factory s
 			readerConfig: &readerConfig{
 				fingerprintSize: int(c.FingerprintSize),
 				maxLogSize:      int(c.MaxLogSize),
+				compression:     c.Compression,
 				emit:            emit,
 			},
 			fromBeginning:   startAtBeginning,
@@ -211,6 +213,12 @@ func (c Config) validate() error {
 		return errors.New("`max_batches` must not be negative")
 	}
 
+	switch c.Compression {
+	case compressionNone, compressionAuto, compressionGzip, compressionZstd:
+	default:
+		return fmt.Errorf("invalid compression '%s'", c.Compression)
+	}
+
 	_, err := c.Splitter.EncodingConfig.Build()
 	if err != nil {
 		return err
@@ -352,6 +352,14 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "compression_auto",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Compression = "auto"
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "header_config",
 				Expect: func() *mockOperatorConfig {
@@ -516,6 +524,24 @@ func TestBuild(t *testing.T) {
 			require.Error,
 			nil,
 		},
+		{
+			"InvalidCompression",
+			func(f *Config) {
+				f.Compression = "bzip2"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"ValidCompression",
+			func(f *Config) {
+				f.Compression = "zstd"
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, "zstd", m.readerFactory.readerConfig.compression)
+			},
+		},
 		{
 			"ValidMaxBatches",
 			func(f *Config) {
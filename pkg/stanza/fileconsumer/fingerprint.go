@@ -36,6 +36,20 @@ func NewFingerprint(file *os.File, size int) (*Fingerprint, error) {
 	return fp, nil
 }
 
+// newFingerprintFromReader creates a new fingerprint from the first bytes read from r
+func newFingerprintFromReader(r io.Reader, size int) (*Fingerprint, error) {
+	buf := make([]byte, size)
+
+	n, err := io.ReadFull(r, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+
+	return &Fingerprint{
+		FirstBytes: buf[:n],
+	}, nil
+}
+
 // Copy creates a new copy of the fingerprint
 func (f Fingerprint) Copy() *Fingerprint {
 	buf := make([]byte, len(f.FirstBytes), cap(f.FirstBytes))
@@ -7,6 +7,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -19,6 +20,7 @@ import (
 type readerConfig struct {
 	fingerprintSize int
 	maxLogSize      int
+	compression     string
 	emit            EmitFunc
 }
 
@@ -38,6 +40,18 @@ type Reader struct {
 	FileAttributes *FileAttributes
 	eof            bool
 
+	// compression is the compression detected for the file. The Offset
+	// and Fingerprint of compressed files refer to the decompressed content.
+	compression  string
+	src          io.Reader
+	decompressor *decompressor
+	// CompressedSize is the size of the compressed file when it was last read
+	// to the end, compressed files are only decompressed again once they grow.
+	CompressedSize int64
+	// MemberBoundary is the end of the last complete gzip member or zstd frame
+	// before the Offset, decompression resumes from it once the file grows.
+	MemberBoundary memberBoundary
+
 	HeaderFinalized bool
 	recreateScanner bool
 
@@ -48,6 +62,15 @@ type Reader struct {
 
 // offsetToEnd sets the starting offset
 func (r *Reader) offsetToEnd() error {
+	if r.compression != compressionNone {
+		size, boundary, err := decompressedSize(r.file, r.compression)
+		if err != nil {
+			return fmt.Errorf("decompress: %w", err)
+		}
+		r.Offset = size
+		r.MemberBoundary = boundary
+		return nil
+	}
 	info, err := r.file.Stat()
 	if err != nil {
 		return fmt.Errorf("stat: %w", err)
@@ -56,12 +79,58 @@ func (r *Reader) offsetToEnd() error {
 	return nil
 }
 
+// seekToOffset positions the source the file is read from at the offset.
+// Compressed files are decompressed from the end of the last complete
+// member before the offset, only the incomplete member is read again.
+func (r *Reader) seekToOffset() error {
+	if r.compression == compressionNone {
+		r.src = r.file
+		_, err := r.file.Seek(r.Offset, 0)
+		return err
+	}
+
+	if r.decompressor != nil {
+		r.decompressor.Close()
+	}
+	if r.MemberBoundary.Decompressed > r.Offset {
+		r.MemberBoundary = memberBoundary{}
+	}
+	d, err := newDecompressor(r.file, r.compression, r.MemberBoundary)
+	if err != nil {
+		return err
+	}
+	r.decompressor = d
+	r.src = d
+	// Reaching the end of the content before the offset is not an error,
+	// there is just nothing new to read, like seeking past the end of a plain file.
+	if _, err = io.CopyN(io.Discard, d, r.Offset-r.MemberBoundary.Decompressed); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// compressedSizeUnchanged returns true if a compressed file was already read
+// to the end and has not grown since.
+func (r *Reader) compressedSizeUnchanged() bool {
+	if r.compression == compressionNone || r.CompressedSize == 0 {
+		return false
+	}
+	info, err := r.file.Stat()
+	return err == nil && info.Size() == r.CompressedSize
+}
+
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
-	if _, err := r.file.Seek(r.Offset, 0); err != nil {
+	if r.compressedSizeUnchanged() {
+		r.eof = true
+		return
+	}
+
+	if err := r.seekToOffset(); err != nil {
 		r.Errorw("Failed to seek", zap.Error(err))
 		return
 	}
+	defer r.updateMemberBoundary()
 
 	scanner := NewPositionalScanner(r, r.maxLogSize, r.Offset, r.splitFunc)
 
@@ -81,6 +150,7 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 				r.eof = false
 				r.Errorw("Failed during scan", zap.Error(err))
 			}
+			r.updateCompressedSize()
 			break
 		}
 
@@ -97,7 +167,7 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 			// We do not use the updated offset from the scanner,
 			// as the log line we just read could be multiline, and would be
 			// split differently with the new splitter.
-			if _, err := r.file.Seek(r.Offset, 0); err != nil {
+			if err := r.seekToOffset(); err != nil {
 				r.Errorw("Failed to seek post-header", zap.Error(err))
 				return
 			}
@@ -109,6 +179,23 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 	}
 }
 
+// updateCompressedSize records the size of a compressed file read to the end.
+func (r *Reader) updateCompressedSize() {
+	if r.compression == compressionNone || !r.eof {
+		return
+	}
+	if info, err := r.file.Stat(); err == nil {
+		r.CompressedSize = info.Size()
+	}
+}
+
+// updateMemberBoundary records the end of the last complete member that was read.
+func (r *Reader) updateMemberBoundary() {
+	if r.decompressor != nil {
+		r.MemberBoundary = r.decompressor.boundaryBefore(r.Offset)
+	}
+}
+
 // consumeHeaderLine checks if the given token is a line of the header, and consumes it if it is.
 // The return value dictates whether the given line was a header line or not.
 // If false is returned, the full header can be assumed to be read.
@@ -156,6 +243,10 @@ func (r *Reader) consumeHeaderLine(ctx context.Context, attrs *FileAttributes, t
 
 // Close will close the file
 func (r *Reader) Close() {
+	if r.decompressor != nil {
+		r.decompressor.Close()
+		r.decompressor = nil
+	}
 	if r.file != nil {
 		if err := r.file.Close(); err != nil {
 			r.Debugw("Problem closing reader", zap.Error(err))
@@ -174,9 +265,9 @@ func (r *Reader) Read(dst []byte) (int, error) {
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.fingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
-		return r.file.Read(dst)
+		return r.src.Read(dst)
 	}
-	n, err := r.file.Read(dst)
+	n, err := r.src.Read(dst)
 	appendCount := min0(n, r.fingerprintSize-int(r.Offset))
 	// return for n == 0 or r.Offset >= r.fileInput.fingerprintSize
 	if appendCount == 0 {
@@ -40,6 +40,8 @@ func (f *readerFactory) copy(old *Reader, newFile *os.File) (*Reader, error) {
 		withSplitterFunc(old.lineSplitFunc).
 		withHeaderAttributes(mapCopy(old.FileAttributes.HeaderAttributes)).
 		withHeaderFinalized(old.HeaderFinalized).
+		withCompressedSize(old.CompressedSize).
+		withMemberBoundary(old.MemberBoundary).
 		build()
 }
 
@@ -48,7 +50,20 @@ func (f *readerFactory) unsafeReader() (*Reader, error) {
 }
 
 func (f *readerFactory) newFingerprint(file *os.File) (*Fingerprint, error) {
-	return NewFingerprint(file, f.readerConfig.fingerprintSize)
+	compression, err := detectCompression(file, f.readerConfig.compression)
+	if err != nil {
+		return nil, err
+	}
+	if compression == compressionNone {
+		return NewFingerprint(file, f.readerConfig.fingerprintSize)
+	}
+
+	d, err := newDecompressor(file, compression, memberBoundary{})
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+	return newFingerprintFromReader(d, f.readerConfig.fingerprintSize)
 }
 
 type readerBuilder struct {
@@ -59,6 +74,8 @@ type readerBuilder struct {
 	splitFunc        bufio.SplitFunc
 	headerFinalized  bool
 	headerAttributes map[string]any
+	compressedSize   int64
+	memberBoundary   memberBoundary
 }
 
 func (f *readerFactory) newReaderBuilder() *readerBuilder {
@@ -95,12 +112,24 @@ func (b *readerBuilder) withHeaderAttributes(attrs map[string]any) *readerBuilde
 	return b
 }
 
+func (b *readerBuilder) withCompressedSize(size int64) *readerBuilder {
+	b.compressedSize = size
+	return b
+}
+
+func (b *readerBuilder) withMemberBoundary(boundary memberBoundary) *readerBuilder {
+	b.memberBoundary = boundary
+	return b
+}
+
 func (b *readerBuilder) build() (r *Reader, err error) {
 	r = &Reader{
 		readerConfig:    b.readerConfig,
 		Offset:          b.offset,
 		headerSettings:  b.headerSettings,
 		HeaderFinalized: b.headerFinalized,
+		CompressedSize:  b.compressedSize,
+		MemberBoundary:  b.memberBoundary,
 	}
 
 	if b.splitFunc != nil {
@@ -133,6 +162,11 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 			b.Errorf("resolve attributes: %w", err)
 		}
 
+		r.compression, err = detectCompression(b.file, b.readerConfig.compression)
+		if err != nil {
+			return nil, err
+		}
+
 		// unsafeReader has the file set to nil, so don't try emending its offset.
 		if !b.fromBeginning {
 			if err := r.offsetToEnd(); err != nil {
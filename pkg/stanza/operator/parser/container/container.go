@@ -0,0 +1,385 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package container // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/container"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/recombine"
+)
+
+const (
+	operatorType = "container"
+
+	dockerFormat     = "docker"
+	crioFormat       = "crio"
+	containerdFormat = "containerd"
+
+	recombineSuffix       = "_recombine"
+	dockerRecombineSuffix = "_docker_recombine"
+	trimNewlineSuffix     = "_trim_newline"
+	trimNewlineType       = "trim_newline"
+
+	defaultTimeLayout = time.RFC3339Nano
+	defaultMaxLogSize = 1024 * 1024
+
+	logFilePathAttribute = "log.file.path"
+	logIOStreamAttribute = "log.iostream"
+	logTagAttribute      = "logtag"
+	timeAttribute        = "time"
+	logAttribute         = "log"
+	partialLogTag        = "P"
+	dockerLineEnd        = "\n"
+
+	k8sNamespaceNameKey = "k8s.namespace.name"
+	k8sPodNameKey       = "k8s.pod.name"
+	k8sPodUIDKey        = "k8s.pod.uid"
+	k8sContainerNameKey = "k8s.container.name"
+	k8sRestartCountKey  = "k8s.container.restart_count"
+)
+
+var (
+	// criRegexp matches lines written by CRI-O and containerd, e.g.
+	// 2023-06-22T10:10:38.793706244Z stdout F message
+	criRegexp = regexp.MustCompile(`^(?P<time>[^ ]+) (?P<stream>stdout|stderr) (?P<logtag>[^ ]*) ?(?P<log>.*)$`)
+
+	// filePathRegexp matches the paths kubelet writes container logs to, e.g.
+	// /var/log/pods/<namespace>_<pod_name>_<pod_uid>/<container_name>/<restart_count>.log
+	filePathRegexp = regexp.MustCompile(`^.*/(?P<namespace>[^_/]+)_(?P<pod_name>[^_/]+)_(?P<uid>[a-f0-9\-]+)/(?P<container_name>[^._/]+)/(?P<restart_count>\d+)\.log$`)
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new container parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new container parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig:            helper.NewParserConfig(operatorID, operatorType),
+		AddMetadataFromFilePath: true,
+		MaxLogSize:              defaultMaxLogSize,
+	}
+}
+
+// Config is the configuration of a container parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+
+	Format                  string          `mapstructure:"format"`
+	AddMetadataFromFilePath bool            `mapstructure:"add_metadata_from_file_path"`
+	MaxLogSize              helper.ByteSize `mapstructure:"max_log_size"`
+}
+
+// Build will build a container parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	if c.ParseTo.String() != entry.NewAttributeField().String() {
+		return nil, fmt.Errorf("`parse_to` can only be set to attributes")
+	}
+
+	switch c.Format {
+	case "", dockerFormat, crioFormat, containerdFormat:
+	default:
+		return nil, fmt.Errorf("invalid `format` '%s', must be one of '%s', '%s' or '%s'",
+			c.Format, dockerFormat, crioFormat, containerdFormat)
+	}
+
+	if c.TimeParser == nil {
+		parseFrom := entry.NewAttributeField(timeAttribute)
+		c.TimeParser = &helper.TimeParser{
+			ParseFrom:  &parseFrom,
+			Layout:     defaultTimeLayout,
+			LayoutType: helper.GotimeKey,
+		}
+	}
+
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	recombineConfig := recombine.NewConfigWithID(c.ID() + recombineSuffix)
+	recombineConfig.OutputIDs = c.OutputIDs
+	recombineConfig.IsLastEntry = fmt.Sprintf("attributes.%s != '%s'", logTagAttribute, partialLogTag)
+	recombineConfig.CombineField = entry.NewBodyField()
+	recombineConfig.CombineWith = ""
+	recombineConfig.SourceIdentifier = entry.NewAttributeField(logFilePathAttribute)
+	recombineConfig.MaxLogSize = c.MaxLogSize
+	recombineOperator, err := recombineConfig.Build(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build internal recombine operator: %w", err)
+	}
+
+	trimNewlineConfig := helper.NewTransformerConfig(c.ID()+trimNewlineSuffix, trimNewlineType)
+	trimNewlineConfig.OutputIDs = c.OutputIDs
+	trimNewlineTransformer, err := trimNewlineConfig.Build(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build internal trim newline operator: %w", err)
+	}
+	trimNewlineOperator := &trimNewline{TransformerOperator: trimNewlineTransformer}
+
+	// The docker runtime ends every line it writes with a newline and splits
+	// lines longer than 16KB into parts, all but the last one without the newline.
+	dockerRecombineConfig := recombine.NewConfigWithID(c.ID() + dockerRecombineSuffix)
+	dockerRecombineConfig.OutputIDs = []string{trimNewlineOperator.ID()}
+	dockerRecombineConfig.IsLastEntry = fmt.Sprintf("body endsWith %q", dockerLineEnd)
+	dockerRecombineConfig.CombineField = entry.NewBodyField()
+	dockerRecombineConfig.CombineWith = ""
+	dockerRecombineConfig.SourceIdentifier = entry.NewAttributeField(logFilePathAttribute)
+	dockerRecombineConfig.MaxLogSize = c.MaxLogSize
+	dockerRecombineOperator, err := dockerRecombineConfig.Build(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build internal docker recombine operator: %w", err)
+	}
+	if err = dockerRecombineOperator.SetOutputs([]operator.Operator{trimNewlineOperator}); err != nil {
+		return nil, fmt.Errorf("failed to set the outputs of the internal docker recombine operator: %w", err)
+	}
+
+	return &Parser{
+		ParserOperator:          parserOperator,
+		recombineOperator:       recombineOperator,
+		dockerRecombineOperator: dockerRecombineOperator,
+		trimNewlineOperator:     trimNewlineOperator,
+		format:                  c.Format,
+		addMetadataFromFilePath: c.AddMetadataFromFilePath,
+		json:                    jsoniter.ConfigFastest,
+	}, nil
+}
+
+// Parser is an operator that parses container logs written by
+// the docker, CRI-O and containerd runtimes.
+type Parser struct {
+	helper.ParserOperator
+	recombineOperator       operator.Operator
+	dockerRecombineOperator operator.Operator
+	trimNewlineOperator     operator.Operator
+	format                  string
+	addMetadataFromFilePath bool
+	json                    jsoniter.API
+}
+
+// Start will start the internal recombine operators.
+func (p *Parser) Start(persister operator.Persister) error {
+	if err := p.recombineOperator.Start(persister); err != nil {
+		return err
+	}
+	return p.dockerRecombineOperator.Start(persister)
+}
+
+// Stop will flush and stop the internal recombine operators.
+func (p *Parser) Stop() error {
+	if err := p.recombineOperator.Stop(); err != nil {
+		return err
+	}
+	return p.dockerRecombineOperator.Stop()
+}
+
+// SetOutputIDs sets the outputs of the parser and of its internal operators.
+func (p *Parser) SetOutputIDs(opIDs []string) {
+	p.ParserOperator.SetOutputIDs(opIDs)
+	p.recombineOperator.SetOutputIDs(opIDs)
+	p.trimNewlineOperator.SetOutputIDs(opIDs)
+}
+
+// SetOutputs sets the outputs of the parser and of its internal operators.
+// The internal docker recombine operator always outputs to the trim newline operator.
+func (p *Parser) SetOutputs(operators []operator.Operator) error {
+	if err := p.ParserOperator.SetOutputs(operators); err != nil {
+		return err
+	}
+	if err := p.recombineOperator.SetOutputs(operators); err != nil {
+		return err
+	}
+	return p.trimNewlineOperator.SetOutputs(operators)
+}
+
+// Process will parse an entry as a container log line.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	skip, err := p.Skip(ctx, entry)
+	if err != nil {
+		return p.HandleEntryError(ctx, entry, err)
+	}
+	if skip {
+		p.Write(ctx, entry)
+		return nil
+	}
+
+	format := p.format
+	if format == "" {
+		format, err = p.detectFormat(entry)
+		if err != nil {
+			return p.HandleEntryError(ctx, entry, err)
+		}
+	}
+
+	parse := p.parseCRI
+	if format == dockerFormat {
+		parse = p.parseDocker
+	}
+	if err = p.ParseWith(ctx, entry, parse); err != nil {
+		return err
+	}
+
+	if err = p.handleAttributeMappings(entry); err != nil {
+		return p.HandleEntryError(ctx, entry, err)
+	}
+
+	if p.addMetadataFromFilePath {
+		if err = p.extractMetadataFromFilePath(entry); err != nil {
+			return p.HandleEntryError(ctx, entry, err)
+		}
+	}
+
+	// The docker runtime marks the end of a line with a newline instead of a log tag,
+	// so docker entries are recombined by their own operator before the newline is trimmed.
+	if format == dockerFormat {
+		return p.dockerRecombineOperator.Process(ctx, entry)
+	}
+	return p.recombineOperator.Process(ctx, entry)
+}
+
+// detectFormat determines the format of a container log line.
+func (p *Parser) detectFormat(e *entry.Entry) (string, error) {
+	value, ok := e.Get(p.ParseFrom)
+	if !ok {
+		return "", errors.NewError(
+			"Entry is missing the expected parse_from field.",
+			"Ensure that all incoming entries contain the parse_from field.",
+			"parse_from", p.ParseFrom.String(),
+		)
+	}
+
+	raw, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("type '%T' cannot be parsed as container logs", value)
+	}
+
+	if strings.HasPrefix(raw, "{") {
+		return dockerFormat, nil
+	}
+	match := criRegexp.FindStringSubmatch(raw)
+	if match == nil {
+		return "", fmt.Errorf("entry cannot be parsed as container logs")
+	}
+	if strings.HasSuffix(match[criRegexp.SubexpIndex("time")], "Z") {
+		return containerdFormat, nil
+	}
+	return crioFormat, nil
+}
+
+// parseDocker will parse a docker JSON log line.
+func (p *Parser) parseDocker(value interface{}) (interface{}, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("type '%T' cannot be parsed as docker logs", value)
+	}
+
+	var parsedValue struct {
+		Log    string `json:"log"`
+		Stream string `json:"stream"`
+		Time   string `json:"time"`
+	}
+	if err := p.json.UnmarshalFromString(raw, &parsedValue); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		logAttribute:         parsedValue.Log,
+		logIOStreamAttribute: parsedValue.Stream,
+		timeAttribute:        parsedValue.Time,
+	}, nil
+}
+
+// parseCRI will parse a log line written by CRI-O or containerd.
+func (p *Parser) parseCRI(value interface{}) (interface{}, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("type '%T' cannot be parsed as cri logs", value)
+	}
+
+	match := criRegexp.FindStringSubmatch(raw)
+	if match == nil {
+		return nil, fmt.Errorf("entry does not match the cri log format")
+	}
+
+	return map[string]interface{}{
+		timeAttribute:        match[criRegexp.SubexpIndex("time")],
+		logIOStreamAttribute: match[criRegexp.SubexpIndex("stream")],
+		logTagAttribute:      match[criRegexp.SubexpIndex("logtag")],
+		logAttribute:         match[criRegexp.SubexpIndex("log")],
+	}, nil
+}
+
+// handleAttributeMappings moves the parsed log message to the body
+// and drops the time attribute once it has been parsed.
+func (p *Parser) handleAttributeMappings(e *entry.Entry) error {
+	logField := entry.NewAttributeField(logAttribute)
+	message, ok := e.Delete(logField)
+	if !ok {
+		return fmt.Errorf("failed to find the parsed log message")
+	}
+	if err := e.Set(entry.NewBodyField(), message); err != nil {
+		return err
+	}
+	e.Delete(entry.NewAttributeField(timeAttribute))
+	return nil
+}
+
+// extractMetadataFromFilePath sets resource attributes for the pod and the container
+// from the path of the file the entry was read from.
+func (p *Parser) extractMetadataFromFilePath(e *entry.Entry) error {
+	var path string
+	if err := e.Read(entry.NewAttributeField(logFilePathAttribute), &path); err != nil {
+		return fmt.Errorf("failed to read the '%s' attribute: %w", logFilePathAttribute, err)
+	}
+
+	match := filePathRegexp.FindStringSubmatch(path)
+	if match == nil {
+		return fmt.Errorf("failed to extract metadata from file path '%s'", path)
+	}
+
+	for key, group := range map[string]string{
+		k8sNamespaceNameKey: "namespace",
+		k8sPodNameKey:       "pod_name",
+		k8sPodUIDKey:        "uid",
+		k8sContainerNameKey: "container_name",
+		k8sRestartCountKey:  "restart_count",
+	} {
+		if err := e.Set(entry.NewResourceField(key), match[filePathRegexp.SubexpIndex(group)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trimNewline is an operator that trims the newline ending
+// the docker log lines once they have been recombined.
+type trimNewline struct {
+	helper.TransformerOperator
+}
+
+// Process will trim the newline ending the body of an entry.
+func (t *trimNewline) Process(ctx context.Context, e *entry.Entry) error {
+	return t.ProcessWith(ctx, e, func(e *entry.Entry) error {
+		if body, ok := e.Body.(string); ok {
+			e.Body = strings.TrimSuffix(body, dockerLineEnd)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,333 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const testFilePath = "/var/log/pods/some-ns_kube-controller-kind-control-plane_49cc7c1fd3702c40b2686ea7486091d3/kube-controller/1.log"
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestConfigBuild(t *testing.T) {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.OnError = "invalid_on_error"
+	_, err := config.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid `on_error` field")
+}
+
+func TestConfigBuildInvalidFormat(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.Format = "invalid"
+	_, err := config.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid `format` 'invalid'")
+}
+
+func TestConfigBuildInvalidParseTo(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.ParseTo = entry.RootableField{Field: entry.NewBodyField()}
+	_, err := config.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "`parse_to` can only be set to attributes")
+}
+
+func TestContainerImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestDetectFormat(t *testing.T) {
+	parser := newTestParser(t)
+	cases := []struct {
+		name   string
+		body   interface{}
+		format string
+		err    string
+	}{
+		{
+			"docker",
+			`{"log":"INFO: log line here\n","stream":"stdout","time":"2029-03-30T08:31:20.545192187Z"}`,
+			dockerFormat,
+			"",
+		},
+		{
+			"containerd",
+			"2023-06-22T10:10:38.793706244Z stdout F INFO: log line here",
+			containerdFormat,
+			"",
+		},
+		{
+			"crio",
+			"2023-06-22T10:10:38.793706244+02:00 stderr F INFO: log line here",
+			crioFormat,
+			"",
+		},
+		{
+			"unknown",
+			"INFO: log line here",
+			"",
+			"entry cannot be parsed as container logs",
+		},
+		{
+			"invalid_type",
+			[]int{},
+			"",
+			"type '[]int' cannot be parsed as container logs",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := entry.New()
+			e.Body = tc.body
+			format, err := parser.detectFormat(e)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.format, format)
+		})
+	}
+}
+
+func TestParser(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     *entry.Entry
+		expect    *entry.Entry
+	}{
+		{
+			"docker",
+			func(p *Config) {},
+			&entry.Entry{
+				Body: `{"log":"INFO: log line here\n","stream":"stdout","time":"2029-03-30T08:31:20.545192187Z"}`,
+				Attributes: map[string]interface{}{
+					"log.file.path": testFilePath,
+				},
+			},
+			&entry.Entry{
+				Body: "INFO: log line here",
+				Attributes: map[string]interface{}{
+					"log.file.path": testFilePath,
+					"log.iostream":  "stdout",
+				},
+				Resource: map[string]interface{}{
+					"k8s.namespace.name":          "some-ns",
+					"k8s.pod.name":                "kube-controller-kind-control-plane",
+					"k8s.pod.uid":                 "49cc7c1fd3702c40b2686ea7486091d3",
+					"k8s.container.name":          "kube-controller",
+					"k8s.container.restart_count": "1",
+				},
+				Timestamp: time.Date(2029, time.March, 30, 8, 31, 20, 545192187, time.UTC),
+			},
+		},
+		{
+			"containerd",
+			func(p *Config) {},
+			&entry.Entry{
+				Body: "2023-06-22T10:10:38.793706244Z stderr F INFO: log line here",
+				Attributes: map[string]interface{}{
+					"log.file.path": testFilePath,
+				},
+			},
+			&entry.Entry{
+				Body: "INFO: log line here",
+				Attributes: map[string]interface{}{
+					"log.file.path": testFilePath,
+					"log.iostream":  "stderr",
+					"logtag":        "F",
+				},
+				Resource: map[string]interface{}{
+					"k8s.namespace.name":          "some-ns",
+					"k8s.pod.name":                "kube-controller-kind-control-plane",
+					"k8s.pod.uid":                 "49cc7c1fd3702c40b2686ea7486091d3",
+					"k8s.container.name":          "kube-controller",
+					"k8s.container.restart_count": "1",
+				},
+				Timestamp: time.Date(2023, time.June, 22, 10, 10, 38, 793706244, time.UTC),
+			},
+		},
+		{
+			"crio_without_metadata",
+			func(p *Config) {
+				p.Format = crioFormat
+				p.AddMetadataFromFilePath = false
+			},
+			&entry.Entry{
+				Body: "2023-06-22T10:10:38.793706244+02:00 stdout F INFO: log line here",
+			},
+			&entry.Entry{
+				Body: "INFO: log line here",
+				Attributes: map[string]interface{}{
+					"log.iostream": "stdout",
+					"logtag":       "F",
+				},
+				Timestamp: time.Date(2023, time.June, 22, 10, 10, 38, 793706244, time.FixedZone("", 2*60*60)),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			tc.input.ObservedTimestamp = ots
+			tc.expect.ObservedTimestamp = ots
+
+			err = op.Process(context.Background(), tc.input)
+			require.NoError(t, err)
+			fake.ExpectEntry(t, tc.expect)
+		})
+	}
+}
+
+func TestRecombineCRI(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(nil))
+	defer func() {
+		require.NoError(t, op.Stop())
+	}()
+
+	for _, line := range []string{
+		"2023-06-22T10:10:38.793706244Z stdout P INFO: first part, ",
+		"2023-06-22T10:10:38.793706245Z stdout P second part, ",
+		"2023-06-22T10:10:38.793706246Z stdout F last part",
+	} {
+		e := entry.New()
+		e.Body = line
+		e.Attributes = map[string]interface{}{"log.file.path": testFilePath}
+		require.NoError(t, op.Process(context.Background(), e))
+	}
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "INFO: first part, second part, last part", e.Body)
+		require.Equal(t, time.Date(2023, time.June, 22, 10, 10, 38, 793706244, time.UTC), e.Timestamp)
+		require.Equal(t, "kube-controller", e.Resource["k8s.container.name"])
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for the recombined entry")
+	}
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestRecombineDocker(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(nil))
+	defer func() {
+		require.NoError(t, op.Stop())
+	}()
+
+	// The docker runtime splits the second line, longer than 16KB, into two parts.
+	file, err := os.Open(filepath.Join("testdata", "docker.log"))
+	require.NoError(t, err)
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64*1024)
+	for scanner.Scan() {
+		e := entry.New()
+		e.Body = scanner.Text()
+		e.Attributes = map[string]interface{}{"log.file.path": testFilePath}
+		require.NoError(t, op.Process(context.Background(), e))
+	}
+	require.NoError(t, scanner.Err())
+
+	for _, expected := range []struct {
+		body      string
+		stream    string
+		timestamp time.Time
+	}{
+		{
+			"2023/06/22 10:10:38 starting server on :8080",
+			"stdout",
+			time.Date(2023, time.June, 22, 10, 10, 38, 793706244, time.UTC),
+		},
+		{
+			`{"level":"info","msg":"payload","data":"` + strings.Repeat("x", 20000) + `"}`,
+			"stdout",
+			time.Date(2023, time.June, 22, 10, 10, 39, 793706244, time.UTC),
+		},
+		{
+			"2023/06/22 10:10:40 connection refused",
+			"stderr",
+			time.Date(2023, time.June, 22, 10, 10, 40, 793706244, time.UTC),
+		},
+	} {
+		select {
+		case e := <-fake.Received:
+			require.Equal(t, expected.body, e.Body)
+			require.Equal(t, expected.stream, e.Attributes["log.iostream"])
+			require.Equal(t, expected.timestamp, e.Timestamp)
+			require.Equal(t, "kube-controller", e.Resource["k8s.container.name"])
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for the recombined entry")
+		}
+	}
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestMissingFilePath(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	e := entry.New()
+	e.Body = "2023-06-22T10:10:38.793706244Z stdout F INFO: log line here"
+	err = op.Process(context.Background(), e)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read the 'log.file.path' attribute")
+}
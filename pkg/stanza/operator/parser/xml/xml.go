@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/xml"
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/xmlutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "xml_parser"
+
+	// Keys used for attributes and text of elements that are parsed into a map.
+	attributePrefix = "@"
+	textKey         = "#text"
+
+	repeatedElementsList  = "list"
+	repeatedElementsFirst = "first"
+	repeatedElementsLast  = "last"
+
+	namespacesStrip  = "strip"
+	namespacesPrefix = "prefix"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new XML parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new XML parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig:     helper.NewParserConfig(operatorID, operatorType),
+		RepeatedElements: repeatedElementsList,
+		Namespaces:       namespacesStrip,
+	}
+}
+
+// Config is the configuration of an XML parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+
+	RepeatedElements string `mapstructure:"repeated_elements"`
+	Namespaces       string `mapstructure:"namespaces"`
+}
+
+// Build will build an XML parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.RepeatedElements {
+	case repeatedElementsList, repeatedElementsFirst, repeatedElementsLast:
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'repeated_elements', must be one of '%s', '%s' or '%s'",
+			c.RepeatedElements, repeatedElementsList, repeatedElementsFirst, repeatedElementsLast)
+	}
+
+	switch c.Namespaces {
+	case namespacesStrip, namespacesPrefix:
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'namespaces', must be one of '%s' or '%s'",
+			c.Namespaces, namespacesStrip, namespacesPrefix)
+	}
+
+	return &Parser{
+		ParserOperator:   parserOperator,
+		repeatedElements: c.RepeatedElements,
+		keepPrefix:       c.Namespaces == namespacesPrefix,
+	}, nil
+}
+
+// Parser is an operator that parses XML.
+type Parser struct {
+	helper.ParserOperator
+	repeatedElements string
+	keepPrefix       bool
+}
+
+// Process will parse an entry for XML.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ParserOperator.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value as XML.
+func (p *Parser) parse(value interface{}) (interface{}, error) {
+	var raw string
+	switch m := value.(type) {
+	case string:
+		raw = m
+	case []byte:
+		raw = string(m)
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as XML", value)
+	}
+
+	root, err := xmlutil.Parse(raw, p.keepPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{root.Tag: p.toValue(root)}, nil
+}
+
+// toValue converts an element without attributes and children into its text,
+// and any other element into a map holding its attributes, text and children
+// under their names, so that they can be addressed by the sub-parsers.
+func (p *Parser) toValue(el *xmlutil.Element) interface{} {
+	if len(el.Attributes) == 0 && len(el.Children) == 0 {
+		return el.Content
+	}
+
+	m := make(map[string]interface{}, len(el.Attributes)+len(el.Children)+1)
+	for _, attr := range el.Attributes {
+		m[attributePrefix+attr.Name] = attr.Value
+	}
+	if el.Content != "" {
+		m[textKey] = el.Content
+	}
+
+	for _, child := range el.Children {
+		value := p.toValue(child)
+		existing, ok := m[child.Tag]
+		if !ok {
+			m[child.Tag] = value
+			continue
+		}
+
+		switch p.repeatedElements {
+		case repeatedElementsFirst:
+			// Keep the value of the first element.
+		case repeatedElementsLast:
+			m[child.Tag] = value
+		default:
+			if list, isList := existing.([]interface{}); isList {
+				m[child.Tag] = append(list, value)
+			} else {
+				m[child.Tag] = []interface{}{existing, value}
+			}
+		}
+	}
+	return m
+}
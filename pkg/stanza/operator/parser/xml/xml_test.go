@@ -0,0 +1,327 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestConfigBuild(t *testing.T) {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		err       string
+	}{
+		{
+			"invalid_on_error",
+			func(c *Config) { c.OnError = "invalid_on_error" },
+			"invalid `on_error` field",
+		},
+		{
+			"invalid_repeated_elements",
+			func(c *Config) { c.RepeatedElements = "merge" },
+			"invalid value 'merge' for parameter 'repeated_elements'",
+		},
+		{
+			"invalid_namespaces",
+			func(c *Config) { c.Namespaces = "uri" },
+			"invalid value 'uri' for parameter 'namespaces'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := NewConfigWithID("test")
+			tc.configure(config)
+			_, err := config.Build(testutil.Logger(t))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestParserInvalid(t *testing.T) {
+	cases := []struct {
+		name  string
+		input interface{}
+		err   string
+	}{
+		{
+			"invalid_type",
+			[]int{},
+			"type []int cannot be parsed as XML",
+		},
+		{
+			"empty",
+			"",
+			"the document has no root element",
+		},
+		{
+			"not_xml",
+			"invalid",
+			"the document has no root element",
+		},
+		{
+			"multiple_roots",
+			"<a/><b/>",
+			"the document has more than one root element",
+		},
+		{
+			"unclosed",
+			"<a><b></b>",
+			"XML syntax error",
+		},
+	}
+
+	parser := newTestParser(t)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parser.parse(tc.input)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestXMLImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestParser(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     *entry.Entry
+		expect    *entry.Entry
+	}{
+		{
+			"text_only",
+			func(p *Config) {},
+			&entry.Entry{
+				Body: `<message> hello world </message>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"message": "hello world",
+				},
+				Body: `<message> hello world </message>`,
+			},
+		},
+		{
+			"attributes_and_children",
+			func(p *Config) {},
+			&entry.Entry{
+				Body: `<event id="42" level="ERROR"><source host="app-1">billing</source><message>payment failed</message></event>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"event": map[string]interface{}{
+						"@id":    "42",
+						"@level": "ERROR",
+						"source": map[string]interface{}{
+							"@host": "app-1",
+							"#text": "billing",
+						},
+						"message": "payment failed",
+					},
+				},
+				Body: `<event id="42" level="ERROR"><source host="app-1">billing</source><message>payment failed</message></event>`,
+			},
+		},
+		{
+			"repeated_elements_list",
+			func(p *Config) {},
+			&entry.Entry{
+				Body: `<log><line>one</line><line>two</line><line>three</line><empty/></log>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"log": map[string]interface{}{
+						"line":  []interface{}{"one", "two", "three"},
+						"empty": "",
+					},
+				},
+				Body: `<log><line>one</line><line>two</line><line>three</line><empty/></log>`,
+			},
+		},
+		{
+			"repeated_elements_first",
+			func(p *Config) {
+				p.RepeatedElements = "first"
+			},
+			&entry.Entry{
+				Body: `<log><line>one</line><line>two</line><line>three</line></log>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"log": map[string]interface{}{
+						"line": "one",
+					},
+				},
+				Body: `<log><line>one</line><line>two</line><line>three</line></log>`,
+			},
+		},
+		{
+			"repeated_elements_last",
+			func(p *Config) {
+				p.RepeatedElements = "last"
+			},
+			&entry.Entry{
+				Body: `<log><line>one</line><line>two</line><line>three</line></log>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"log": map[string]interface{}{
+						"line": "three",
+					},
+				},
+				Body: `<log><line>one</line><line>two</line><line>three</line></log>`,
+			},
+		},
+		{
+			"namespaces_strip",
+			func(p *Config) {},
+			&entry.Entry{
+				Body: `<e:Event xmlns:e="http://schemas.microsoft.com/win/2004/08/events/event" xmlns="urn:default"><e:EventID e:Qualifiers="0">4624</e:EventID></e:Event>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"Event": map[string]interface{}{
+						"EventID": map[string]interface{}{
+							"@Qualifiers": "0",
+							"#text":       "4624",
+						},
+					},
+				},
+				Body: `<e:Event xmlns:e="http://schemas.microsoft.com/win/2004/08/events/event" xmlns="urn:default"><e:EventID e:Qualifiers="0">4624</e:EventID></e:Event>`,
+			},
+		},
+		{
+			"namespaces_prefix",
+			func(p *Config) {
+				p.Namespaces = "prefix"
+			},
+			&entry.Entry{
+				Body: `<e:Event xmlns:e="http://schemas.microsoft.com/win/2004/08/events/event"><e:EventID e:Qualifiers="0">4624</e:EventID></e:Event>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"e:Event": map[string]interface{}{
+						"@xmlns:e": "http://schemas.microsoft.com/win/2004/08/events/event",
+						"e:EventID": map[string]interface{}{
+							"@e:Qualifiers": "0",
+							"#text":         "4624",
+						},
+					},
+				},
+				Body: `<e:Event xmlns:e="http://schemas.microsoft.com/win/2004/08/events/event"><e:EventID e:Qualifiers="0">4624</e:EventID></e:Event>`,
+			},
+		},
+		{
+			"with_timestamp",
+			func(p *Config) {
+				parseFrom := entry.NewAttributeField("record", "@millis")
+				p.TimeParser = &helper.TimeParser{
+					ParseFrom:  &parseFrom,
+					LayoutType: "epoch",
+					Layout:     "ms",
+				}
+			},
+			&entry.Entry{
+				Body: `<record millis="1136214245000"><message>started</message></record>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"record": map[string]interface{}{
+						"@millis": "1136214245000",
+						"message": "started",
+					},
+				},
+				Body:      `<record millis="1136214245000"><message>started</message></record>`,
+				Timestamp: time.Unix(1136214245, 0),
+			},
+		},
+		{
+			"with_severity",
+			func(p *Config) {
+				severity := helper.NewSeverityConfig()
+				parseFrom := entry.NewAttributeField("record", "level")
+				severity.ParseFrom = &parseFrom
+				p.SeverityConfig = &severity
+			},
+			&entry.Entry{
+				Body: `<record><level>WARN</level></record>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"record": map[string]interface{}{
+						"level": "WARN",
+					},
+				},
+				Body:         `<record><level>WARN</level></record>`,
+				Severity:     entry.Warn,
+				SeverityText: "WARN",
+			},
+		},
+		{
+			"parse_to_body",
+			func(p *Config) {
+				p.ParseTo = entry.RootableField{Field: entry.NewBodyField()}
+			},
+			&entry.Entry{
+				Body: `<message>hello</message>`,
+			},
+			&entry.Entry{
+				Body: map[string]interface{}{
+					"message": "hello",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			tc.input.ObservedTimestamp = ots
+			tc.expect.ObservedTimestamp = ots
+
+			err = op.Process(context.Background(), tc.input)
+			require.NoError(t, err)
+			fake.ExpectEntry(t, tc.expect)
+		})
+	}
+}
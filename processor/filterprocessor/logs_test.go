@@ -737,6 +737,22 @@ func TestFilterLogProcessorWithOTTL(t *testing.T) {
 			filterEverything: true,
 			errorMode:        ottl.IgnoreError,
 		},
+		{
+			name: "drop logs older than 24h",
+			conditions: []string{
+				`Now() - time > Duration("24h")`,
+			},
+			filterEverything: true,
+			errorMode:        ottl.IgnoreError,
+		},
+		{
+			name: "keep logs older than their observed time",
+			conditions: []string{
+				`time > observed_time`,
+			},
+			want:      func(ld plog.Logs) {},
+			errorMode: ottl.IgnoreError,
+		},
 		{
 			name: "with error conditions",
 			conditions: []string{
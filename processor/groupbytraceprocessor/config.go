@@ -4,7 +4,10 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"errors"
 	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
 // Config is the configuration for the processor.
@@ -28,9 +31,24 @@ type Config struct {
 	// Not yet implemented, and an error will be returned when this option is used.
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
-	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
-	// Useful when the duration to wait for traces to complete is high.
+	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans
+	// through the storage extension set with Storage. Useful when the duration to wait for traces to
+	// complete is high, the traces pending at shutdown are also recovered at the next start.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// Storage is the ID of the storage extension used when StoreOnDisk is enabled.
+	Storage *component.ID `mapstructure:"storage"`
+}
+
+var errMissingStorage = errors.New("'storage' must be set when 'store_on_disk' is enabled")
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.StoreOnDisk && cfg.Storage == nil {
+		return errMissingStorage
+	}
+	return nil
 }
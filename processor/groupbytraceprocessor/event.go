@@ -57,6 +57,9 @@ type event struct {
 type tracesWithID struct {
 	id pcommon.TraceID
 	td ptrace.Traces
+
+	// stored is set when the spans are already in the storage, as for traces recovered after a restart
+	stored bool
 }
 
 // eventMachine is a machine that accepts events in a typically non-blocking manner,
@@ -214,6 +217,16 @@ func (em *eventMachine) handleEvent(e event, w *eventMachineWorker) {
 
 // consume takes a single trace and routes it to one of the workers.
 func (em *eventMachine) consume(td ptrace.Traces) error {
+	return em.route(td, false)
+}
+
+// consumeStored routes a trace whose spans are already in the storage, scheduling its release
+// without storing the spans again.
+func (em *eventMachine) consumeStored(td ptrace.Traces) error {
+	return em.route(td, true)
+}
+
+func (em *eventMachine) route(td ptrace.Traces, stored bool) error {
 	traceID, err := getTraceID(td)
 	if err != nil {
 		return fmt.Errorf("eventmachine consume failed: %w", err)
@@ -228,7 +241,7 @@ func (em *eventMachine) consume(td ptrace.Traces) error {
 
 	em.workers[bucket].fire(event{
 		typ:     traceReceived,
-		payload: tracesWithID{id: traceID, td: td},
+		payload: tracesWithID{id: traceID, td: td, stored: stored},
 	})
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -56,7 +57,7 @@ func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 			&Config{
 				StoreOnDisk: true,
 			},
-			errDiskStorageNotSupported,
+			errMissingStorage,
 		},
 	} {
 		p, err := f.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), tt.config, next)
@@ -66,3 +67,29 @@ func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 		assert.Nil(t, p)
 	}
 }
+
+func TestCreateTestProcessorWithDiskStorage(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.StoreOnDisk = true
+	storageID := component.NewIDWithName("file_storage", "groupbytrace")
+	c.Storage = &storageID
+
+	// test
+	p, err := createTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), c, &mockProcessor{})
+
+	// verify
+	assert.NoError(t, err)
+	assert.IsType(t, &diskStorage{}, p.(*groupByTraceProcessor).st)
+}
+
+func TestValidateConfig(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	assert.NoError(t, component.ValidateConfig(c))
+
+	c.StoreOnDisk = true
+	assert.Equal(t, errMissingStorage, component.ValidateConfig(c))
+
+	storageID := component.NewID("file_storage")
+	c.Storage = &storageID
+	assert.NoError(t, component.ValidateConfig(c))
+}
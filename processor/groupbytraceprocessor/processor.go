@@ -83,20 +83,47 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	sp.requeuePending()
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (sp *groupByTraceProcessor) Shutdown(_ context.Context) error {
+func (sp *groupByTraceProcessor) Shutdown(ctx context.Context) error {
 	sp.eventMachine.shutdown()
-	return sp.st.shutdown()
+	return sp.st.shutdown(ctx)
+}
+
+// requeuePending consumes the traces left over in the storage by a previous run again, so that
+// they wait for the entire duration before being released. The traces stay in the storage until
+// they are released, so that a trace that can't be consumed now is recovered on the next start.
+func (sp *groupByTraceProcessor) requeuePending() {
+	for _, traceID := range sp.st.pending() {
+		rss, err := sp.st.get(traceID)
+		if err != nil {
+			sp.logger.Warn("couldn't recover trace from the storage", zap.Stringer("traceID", traceID), zap.Error(err))
+			continue
+		}
+		if rss == nil {
+			continue
+		}
+		trace := ptrace.NewTraces()
+		for _, rs := range rss {
+			rs.CopyTo(trace.ResourceSpans().AppendEmpty())
+		}
+		if err = sp.eventMachine.consumeStored(trace); err != nil {
+			sp.logger.Warn("couldn't recover trace from the storage", zap.Stringer("traceID", traceID), zap.Error(err))
+		}
+	}
 }
 
 func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eventMachineWorker) error {
@@ -104,6 +131,11 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 	if worker.buffer.contains(traceID) {
 		sp.logger.Debug("trace is already in memory storage")
 
+		// the spans of a recovered trace are in the storage already
+		if trace.stored {
+			return nil
+		}
+
 		// it exists in memory already, just append the spans to the trace in the storage
 		if err := sp.addSpans(traceID, trace.td); err != nil {
 			return fmt.Errorf("couldn't add spans to existing trace: %w", err)
@@ -131,9 +163,11 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 			zap.Stringer("traceID", evicted))
 	}
 
-	// we have the traceID in the memory, place the spans in the storage too
-	if err := sp.addSpans(traceID, trace.td); err != nil {
-		return fmt.Errorf("couldn't add spans to existing trace: %w", err)
+	// we have the traceID in the memory, place the spans in the storage too, unless they are there already
+	if !trace.stored {
+		if err := sp.addSpans(traceID, trace.td); err != nil {
+			return fmt.Errorf("couldn't add spans to existing trace: %w", err)
+		}
 	}
 
 	sp.logger.Debug("scheduled to release trace", zap.Duration("duration", sp.config.WaitDuration))
@@ -19,7 +19,9 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
 )
 
 func TestTraceIsDispatchedAfterDuration(t *testing.T) {
@@ -577,6 +579,80 @@ func BenchmarkConsumeTracesCompleteOnFirstBatch(b *testing.B) {
 	}
 }
 
+func TestPendingTracesAreReleasedAfterRestart(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	config := Config{
+		WaitDuration: time.Hour,
+		NumTraces:    10,
+		NumWorkers:   1,
+	}
+	traces := simpleTraces()
+	st := newTestDiskStorage(t, host)
+	p := newGroupByTraceProcessor(zap.NewNop(), st, &mockProcessor{}, config)
+	p.eventMachine.startInBackground()
+	require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+	assert.Eventually(t, func() bool {
+		st.Lock()
+		defer st.Unlock()
+		return len(st.batches) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	wgReceived := &sync.WaitGroup{}
+	wgReceived.Add(1)
+	next := &mockProcessor{
+		onTraces: func(ctx context.Context, received ptrace.Traces) error {
+			assert.Equal(t, traces, received)
+			wgReceived.Done()
+			return nil
+		},
+	}
+	config.WaitDuration = time.Nanosecond
+	p = newGroupByTraceProcessor(zap.NewNop(), newDiskStorage(zap.NewNop(), storagetest.NewStorageID("test"), component.NewID(metadata.Type)), next, config)
+
+	// test
+	require.NoError(t, p.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// verify
+	wgReceived.Wait()
+}
+
+func TestPendingTraceIsKeptWhenRequeueFails(t *testing.T) {
+	// prepare
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	deleted := false
+	st := &mockStorage{
+		onPending: func() []pcommon.TraceID {
+			return []pcommon.TraceID{traceID}
+		},
+		onGet: func(pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+			// a trace without spans can't be consumed
+			return []ptrace.ResourceSpans{ptrace.NewResourceSpans()}, nil
+		},
+		onDelete: func(pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+			deleted = true
+			return nil, nil
+		},
+	}
+	config := Config{
+		WaitDuration: time.Nanosecond,
+		NumTraces:    10,
+		NumWorkers:   1,
+	}
+	p := newGroupByTraceProcessor(zap.NewNop(), st, &mockProcessor{}, config)
+
+	// test
+	require.NoError(t, p.Start(context.Background(), nil))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	// verify
+	assert.False(t, deleted)
+}
+
 type mockProcessor struct {
 	mutex    sync.Mutex
 	onTraces func(context.Context, ptrace.Traces) error
@@ -606,6 +682,7 @@ type mockStorage struct {
 	onCreateOrAppend func(pcommon.TraceID, ptrace.Traces) error
 	onGet            func(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 	onDelete         func(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
+	onPending        func() []pcommon.TraceID
 	onStart          func() error
 	onShutdown       func() error
 }
@@ -630,13 +707,19 @@ func (st *mockStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans,
 	}
 	return nil, nil
 }
-func (st *mockStorage) start() error {
+func (st *mockStorage) pending() []pcommon.TraceID {
+	if st.onPending != nil {
+		return st.onPending()
+	}
+	return nil
+}
+func (st *mockStorage) start(context.Context, component.Host) error {
 	if st.onStart != nil {
 		return st.onStart()
 	}
 	return nil
 }
-func (st *mockStorage) shutdown() error {
+func (st *mockStorage) shutdown(context.Context) error {
 	if st.onShutdown != nil {
 		return st.onShutdown()
 	}
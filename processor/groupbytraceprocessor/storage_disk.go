@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	extensionstorage "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// traceIDsKey is the key under which the IDs of the traces held by the storage are persisted,
+// along with the number of batches stored for each of them.
+const traceIDsKey = "trace_ids"
+
+// traceEntrySize is the size of the persisted entry of a trace, its ID followed by its number of batches.
+const traceEntrySize = len(pcommon.TraceID{}) + 8
+
+var errInvalidTraceIDs = errors.New("invalid trace IDs found in the storage")
+
+// diskStorage keeps only the trace IDs in memory, the spans being serialized through
+// the client of a storage extension. Each batch of spans received for a trace is stored
+// under its own key, so appending spans doesn't rewrite the spans already stored, and
+// the batches are concatenated once the trace is released. The trace IDs are persisted
+// periodically and at shutdown, so that the traces pending at shutdown can be recovered
+// at the next start.
+//
+// The lock only guards the trace IDs, the storage being accessed without it. The processor
+// serializes the operations on a given trace, so they never run concurrently.
+type diskStorage struct {
+	sync.Mutex
+	logger      *zap.Logger
+	storageID   component.ID
+	componentID component.ID
+	client      extensionstorage.Client
+	marshaler   ptrace.ProtoMarshaler
+	unmarshaler ptrace.ProtoUnmarshaler
+
+	// batches holds the number of batches stored for each trace
+	batches   map[pcommon.TraceID]uint64
+	recovered []pcommon.TraceID
+	dirty     bool
+
+	flushInterval time.Duration
+	stopCh        chan struct{}
+	stopWg        sync.WaitGroup
+}
+
+var _ storage = (*diskStorage)(nil)
+
+func newDiskStorage(logger *zap.Logger, storageID component.ID, componentID component.ID) *diskStorage {
+	return &diskStorage{
+		logger:        logger,
+		storageID:     storageID,
+		componentID:   componentID,
+		batches:       make(map[pcommon.TraceID]uint64),
+		flushInterval: time.Second,
+		stopCh:        make(chan struct{}),
+	}
+}
+
+func (st *diskStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	bytes, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+
+	st.Lock()
+	seq := st.batches[traceID]
+	st.batches[traceID] = seq + 1
+	st.dirty = true
+	st.Unlock()
+
+	// A batch that fails to be stored leaves a gap in the sequence, which is skipped when reading the trace.
+	return st.client.Set(context.Background(), batchKey(traceID, seq), bytes)
+}
+
+func (st *diskStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	count := st.batches[traceID]
+	st.Unlock()
+
+	ops := batchOperations(traceID, count, extensionstorage.GetOperation)
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return nil, err
+	}
+	return st.unmarshalBatches(traceID, ops)
+}
+
+func (st *diskStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	count := st.batches[traceID]
+	st.Unlock()
+
+	getOps := batchOperations(traceID, count, extensionstorage.GetOperation)
+	if len(getOps) == 0 {
+		return nil, nil
+	}
+	if err := st.client.Batch(context.Background(), getOps...); err != nil {
+		return nil, err
+	}
+	rss, err := st.unmarshalBatches(traceID, getOps)
+	if err != nil {
+		return nil, err
+	}
+	if err = st.client.Batch(context.Background(), batchOperations(traceID, count, extensionstorage.DeleteOperation)...); err != nil {
+		return nil, err
+	}
+
+	st.Lock()
+	delete(st.batches, traceID)
+	st.dirty = true
+	st.Unlock()
+	return rss, nil
+}
+
+// unmarshalBatches concatenates the resource spans of the batches read by the given operations,
+// it returns nil if none of the batches of the trace could be found.
+func (st *diskStorage) unmarshalBatches(traceID pcommon.TraceID, ops []extensionstorage.Operation) ([]ptrace.ResourceSpans, error) {
+	var result []ptrace.ResourceSpans
+	for _, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+		trace, err := st.unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal trace %q: %w", traceID, err)
+		}
+		result = append(result, resourceSpansOf(trace)...)
+	}
+	return result, nil
+}
+
+// batchKey returns the key of the batch with the given sequence number of a trace.
+func batchKey(traceID pcommon.TraceID, seq uint64) string {
+	return traceID.String() + "/" + strconv.FormatUint(seq, 10)
+}
+
+// batchOperations returns an operation created by newOp for each of the count batches of a trace.
+func batchOperations(traceID pcommon.TraceID, count uint64, newOp func(string) extensionstorage.Operation) []extensionstorage.Operation {
+	ops := make([]extensionstorage.Operation, 0, count)
+	for seq := uint64(0); seq < count; seq++ {
+		ops = append(ops, newOp(batchKey(traceID, seq)))
+	}
+	return ops
+}
+
+func (st *diskStorage) start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[st.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", st.storageID)
+	}
+	storageExt, ok := ext.(extensionstorage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", st.storageID)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, st.componentID, "")
+	if err != nil {
+		return err
+	}
+	st.client = client
+
+	if err = st.loadTraceIDs(ctx); err != nil {
+		return err
+	}
+
+	st.stopWg.Add(1)
+	go st.periodicFlush()
+	return nil
+}
+
+func (st *diskStorage) shutdown(ctx context.Context) error {
+	if st.client == nil {
+		return nil
+	}
+	close(st.stopCh)
+	st.stopWg.Wait()
+
+	return multierr.Append(st.flush(ctx), st.client.Close(ctx))
+}
+
+func (st *diskStorage) pending() []pcommon.TraceID {
+	st.Lock()
+	defer st.Unlock()
+	return st.recovered
+}
+
+func (st *diskStorage) loadTraceIDs(ctx context.Context) error {
+	bytes, err := st.client.Get(ctx, traceIDsKey)
+	if err != nil {
+		return err
+	}
+	if len(bytes)%traceEntrySize != 0 {
+		return errInvalidTraceIDs
+	}
+
+	st.Lock()
+	defer st.Unlock()
+	for i := 0; i < len(bytes); i += traceEntrySize {
+		var traceID pcommon.TraceID
+		copy(traceID[:], bytes[i:])
+		st.batches[traceID] = binary.BigEndian.Uint64(bytes[i+len(traceID):])
+		st.recovered = append(st.recovered, traceID)
+	}
+	if len(st.recovered) > 0 {
+		st.logger.Info("recovered traces from the storage", zap.Int("num-traces", len(st.recovered)))
+	}
+	return nil
+}
+
+func (st *diskStorage) periodicFlush() {
+	defer st.stopWg.Done()
+	ticker := time.NewTicker(st.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := st.flush(context.Background()); err != nil {
+				st.logger.Warn("failed to persist the trace IDs", zap.Error(err))
+			}
+		case <-st.stopCh:
+			return
+		}
+	}
+}
+
+// flush persists the trace IDs if they changed since the last flush,
+// and records the number of traces held by the storage.
+func (st *diskStorage) flush(ctx context.Context) error {
+	st.Lock()
+	stats.Record(context.Background(), mNumTracesInMemory.M(int64(len(st.batches))))
+	if !st.dirty {
+		st.Unlock()
+		return nil
+	}
+	bytes := make([]byte, 0, len(st.batches)*traceEntrySize)
+	for traceID, count := range st.batches {
+		bytes = append(bytes, traceID[:]...)
+		bytes = binary.BigEndian.AppendUint64(bytes, count)
+	}
+	st.dirty = false
+	st.Unlock()
+
+	if err := st.client.Set(ctx, traceIDsKey, bytes); err != nil {
+		st.Lock()
+		st.dirty = true
+		st.Unlock()
+		return err
+	}
+	return nil
+}
+
+func resourceSpansOf(trace ptrace.Traces) []ptrace.ResourceSpans {
+	result := make([]ptrace.ResourceSpans, 0, trace.ResourceSpans().Len())
+	for i := 0; i < trace.ResourceSpans().Len(); i++ {
+		result = append(result, trace.ResourceSpans().At(i))
+	}
+	return result
+}
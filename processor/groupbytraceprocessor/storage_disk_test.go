@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+func newTestDiskStorage(t *testing.T, host component.Host) *diskStorage {
+	st := newDiskStorage(zap.NewNop(), storagetest.NewStorageID("test"), component.NewID(metadata.Type))
+	require.NoError(t, st.start(context.Background(), host))
+	return st
+}
+
+func TestDiskCreateAndGetTrace(t *testing.T) {
+	// prepare
+	st := newTestDiskStorage(t, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir()))
+	defer func() {
+		require.NoError(t, st.shutdown(context.Background()))
+	}()
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.TraceID([16]byte{1, 2, 3, 4}),
+		pcommon.TraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	// test
+	for _, traceID := range traceIDs {
+		assert.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+
+	// verify
+	for _, traceID := range traceIDs {
+		retrieved, err := st.get(traceID)
+		require.NoError(t, err)
+		assert.Equal(t, []ptrace.ResourceSpans{simpleTracesWithID(traceID).ResourceSpans().At(0)}, retrieved)
+	}
+
+	missing, err := st.get(pcommon.TraceID([16]byte{9}))
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestDiskAppendAndDeleteTrace(t *testing.T) {
+	// prepare
+	st := newTestDiskStorage(t, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir()))
+	defer func() {
+		require.NoError(t, st.shutdown(context.Background()))
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	first := simpleTracesWithID(traceID)
+	second := simpleTracesWithID(traceID)
+	second.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("second-name")
+
+	require.NoError(t, st.createOrAppend(traceID, first))
+	require.NoError(t, st.createOrAppend(traceID, second))
+
+	// each batch is stored under its own key
+	for _, key := range []string{batchKey(traceID, 0), batchKey(traceID, 1)} {
+		bytes, err := st.client.Get(context.Background(), key)
+		require.NoError(t, err)
+		assert.NotNil(t, bytes)
+	}
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, deleted, 2)
+	assert.Equal(t, "second-name", deleted[1].ScopeSpans().At(0).Spans().At(0).Name())
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+	assert.Len(t, st.batches, 0)
+	bytes, err := st.client.Get(context.Background(), batchKey(traceID, 1))
+	require.NoError(t, err)
+	assert.Nil(t, bytes)
+}
+
+func TestDiskPendingTracesSurviveRestart(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	st := newTestDiskStorage(t, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir))
+	assert.Empty(t, st.pending())
+
+	pendingID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	releasedID := pcommon.TraceID([16]byte{2, 3, 4, 5})
+	require.NoError(t, st.createOrAppend(pendingID, simpleTracesWithID(pendingID)))
+	require.NoError(t, st.createOrAppend(pendingID, simpleTracesWithID(pendingID)))
+	require.NoError(t, st.createOrAppend(releasedID, simpleTracesWithID(releasedID)))
+	_, err := st.delete(releasedID)
+	require.NoError(t, err)
+	require.NoError(t, st.shutdown(context.Background()))
+
+	// test
+	st = newTestDiskStorage(t, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir))
+	defer func() {
+		require.NoError(t, st.shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Equal(t, []pcommon.TraceID{pendingID}, st.pending())
+	retrieved, err := st.get(pendingID)
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{
+		simpleTracesWithID(pendingID).ResourceSpans().At(0),
+		simpleTracesWithID(pendingID).ResourceSpans().At(0),
+	}, retrieved)
+}
+
+func TestDiskConcurrentTraces(t *testing.T) {
+	// prepare
+	st := newTestDiskStorage(t, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir()))
+	defer func() {
+		require.NoError(t, st.shutdown(context.Background()))
+	}()
+
+	// test
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		traceID := pcommon.TraceID([16]byte{byte(i)})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				assert.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+			}
+		}()
+	}
+	wg.Wait()
+
+	// verify
+	for i := 0; i < 10; i++ {
+		deleted, err := st.delete(pcommon.TraceID([16]byte{byte(i)}))
+		require.NoError(t, err)
+		assert.Len(t, deleted, 10)
+	}
+	assert.Len(t, st.batches, 0)
+}
+
+func TestDiskStartWithoutExtension(t *testing.T) {
+	st := newDiskStorage(zap.NewNop(), storagetest.NewStorageID("test"), component.NewID(metadata.Type))
+
+	err := st.start(context.Background(), componenttest.NewNopHost())
+
+	assert.EqualError(t, err, "storage extension 'test_storage/test' not found")
+	assert.NoError(t, st.shutdown(context.Background()))
+}
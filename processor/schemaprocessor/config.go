@@ -13,8 +13,10 @@ import (
 )
 
 var (
-	errRequiresTargets  = errors.New("requires schema targets")
-	errDuplicateTargets = errors.New("duplicate targets detected")
+	errRequiresTargets    = errors.New("requires schema targets")
+	errDuplicateTargets   = errors.New("duplicate targets detected")
+	errMissingSchemaFile  = errors.New("schema file path must be set")
+	errDuplicateSchemaURL = errors.New("duplicate schema file url detected")
 )
 
 // Config defines the user provided values for the Schema Processor
@@ -31,6 +33,20 @@ type Config struct {
 	// translated to, allowing older and newer formats
 	// to conform to the target schema identifier.
 	Targets []string `mapstructure:"targets"`
+
+	// SchemaFiles allows for reading schema files from the
+	// local file system instead of fetching them from their
+	// published schema URL. (Optional field)
+	SchemaFiles []SchemaFile `mapstructure:"schema_files"`
+}
+
+// SchemaFile maps a schema URL to a schema file stored locally.
+type SchemaFile struct {
+	// SchemaURL is the URL the schema file is published at.
+	SchemaURL string `mapstructure:"schema_url"`
+
+	// Path is the location of the schema file on the local file system.
+	Path string `mapstructure:"path"`
 }
 
 func (c *Config) Validate() error {
@@ -59,5 +75,19 @@ func (c *Config) Validate() error {
 		families[family] = struct{}{}
 	}
 
+	urls := make(map[string]struct{})
+	for _, file := range c.SchemaFiles {
+		if _, _, err := translation.GetFamilyAndVersion(file.SchemaURL); err != nil {
+			return err
+		}
+		if file.Path == "" {
+			return fmt.Errorf("%q: %w", file.SchemaURL, errMissingSchemaFile)
+		}
+		if _, exist := urls[file.SchemaURL]; exist {
+			return errDuplicateSchemaURL
+		}
+		urls[file.SchemaURL] = struct{}{}
+	}
+
 	return nil
 }
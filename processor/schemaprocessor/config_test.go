@@ -38,6 +38,12 @@ func TestLoadConfig(t *testing.T) {
 			"https://opentelemetry.io/schemas/1.4.2",
 			"https://example.com/otel/schemas/1.2.0",
 		},
+		SchemaFiles: []SchemaFile{
+			{
+				SchemaURL: "https://example.com/otel/schemas/1.2.0",
+				Path:      "/etc/otelcol/schemas/1.2.0.yaml",
+			},
+		},
 	}, cfg)
 }
 
@@ -47,6 +53,7 @@ func TestConfigurationValidation(t *testing.T) {
 	tests := []struct {
 		scenario    string
 		target      []string
+		files       []SchemaFile
 		expectError error
 	}{
 		{scenario: "No targets", target: nil, expectError: errRequiresTargets},
@@ -75,11 +82,37 @@ func TestConfigurationValidation(t *testing.T) {
 			},
 			expectError: errDuplicateTargets,
 		},
+		{
+			scenario: "Schema file without path",
+			target:   []string{"https://opentelemetry.io/schemas/1.9.0"},
+			files: []SchemaFile{
+				{SchemaURL: "https://opentelemetry.io/schemas/1.9.0"},
+			},
+			expectError: errMissingSchemaFile,
+		},
+		{
+			scenario: "Schema file with invalid schema url",
+			target:   []string{"https://opentelemetry.io/schemas/1.9.0"},
+			files: []SchemaFile{
+				{SchemaURL: "https://opentelemetry.io/schemas", Path: "schema.yaml"},
+			},
+			expectError: translation.ErrInvalidVersion,
+		},
+		{
+			scenario: "Duplicate schema files",
+			target:   []string{"https://opentelemetry.io/schemas/1.9.0"},
+			files: []SchemaFile{
+				{SchemaURL: "https://opentelemetry.io/schemas/1.9.0", Path: "a.yaml"},
+				{SchemaURL: "https://opentelemetry.io/schemas/1.9.0", Path: "b.yaml"},
+			},
+			expectError: errDuplicateSchemaURL,
+		},
 	}
 
 	for _, tc := range tests {
 		cfg := &Config{
-			Targets: tc.target,
+			Targets:     tc.target,
+			SchemaFiles: tc.files,
 		}
 
 		assert.ErrorIs(t, component.ValidateConfig(cfg), tc.expectError, tc.scenario)
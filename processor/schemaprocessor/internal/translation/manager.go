@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// initialRetryBackoff and maxRetryBackoff bound the time a schema URL
+	// that could not be retrieved is left alone before it is tried again.
+	initialRetryBackoff = 5 * time.Second
+	maxRetryBackoff     = 5 * time.Minute
+)
+
+type target struct {
+	schemaURL string
+	version   *Version
+}
+
+// failure records a schema URL that could not be retrieved or parsed.
+type failure struct {
+	err      error
+	attempts int
+	retryAt  time.Time
+}
+
+// Manager resolves the Translator needed to convert a signal's schema URL
+// into the configured target of the same schema family.
+// Parsed schema files are cached so they are only retrieved once, concurrent
+// requests for the same schema URL share a single retrieval, and failed
+// retrievals are not retried until their backoff has passed.
+type Manager struct {
+	log      *zap.Logger
+	provider Provider
+	targets  map[string]target
+	now      func() time.Time
+
+	group    singleflight.Group
+	rw       sync.RWMutex
+	cache    map[string]*Translation
+	failures map[string]*failure
+}
+
+// NewManager creates a Manager that translates signals to the targets schema URLs,
+// retrieving any schema file required from the provider.
+func NewManager(targets []string, provider Provider, log *zap.Logger) (*Manager, error) {
+	m := &Manager{
+		log:      log,
+		provider: provider,
+		targets:  make(map[string]target, len(targets)),
+		now:      time.Now,
+		cache:    make(map[string]*Translation),
+		failures: make(map[string]*failure),
+	}
+	for _, schemaURL := range targets {
+		family, version, err := GetFamilyAndVersion(schemaURL)
+		if err != nil {
+			return nil, err
+		}
+		m.targets[family] = target{schemaURL: schemaURL, version: version}
+	}
+	return m, nil
+}
+
+// Prefetch retrieves and caches the schema files for the given schema URLs.
+func (m *Manager) Prefetch(ctx context.Context, schemaURLs ...string) error {
+	for _, schemaURL := range schemaURLs {
+		if _, err := m.translation(ctx, schemaURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RequestTranslator returns the Translator that converts signals published with
+// schemaURL into the configured target of its schema family.
+// A nil Translator is returned when there is nothing to translate.
+func (m *Manager) RequestTranslator(ctx context.Context, schemaURL string) (*Translator, error) {
+	if schemaURL == "" {
+		return nil, nil
+	}
+	family, version, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	tgt, ok := m.targets[family]
+	if !ok || tgt.version.Equal(version) {
+		return nil, nil
+	}
+
+	// A schema file lists the changes of every version up to its own,
+	// so the file of the newest of both versions is needed.
+	source := tgt.schemaURL
+	if version.GreaterThan(tgt.version) {
+		source = schemaURL
+	}
+	t, err := m.translation(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	return t.Translator(version, tgt.schemaURL)
+}
+
+func (m *Manager) translation(ctx context.Context, schemaURL string) (*Translation, error) {
+	m.rw.RLock()
+	t, ok := m.cache[schemaURL]
+	f, failed := m.failures[schemaURL]
+	m.rw.RUnlock()
+	if ok {
+		return t, nil
+	}
+	if failed && m.now().Before(f.retryAt) {
+		return nil, f.err
+	}
+
+	v, err, _ := m.group.Do(schemaURL, func() (interface{}, error) {
+		return m.fetch(ctx, schemaURL)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Translation), nil
+}
+
+// fetch retrieves and parses the schema file, caching the result.
+// Failures are recorded so the schema URL is only tried again once
+// an exponentially growing backoff has passed.
+func (m *Manager) fetch(ctx context.Context, schemaURL string) (*Translation, error) {
+	m.rw.RLock()
+	t, ok := m.cache[schemaURL]
+	m.rw.RUnlock()
+	if ok {
+		return t, nil
+	}
+
+	t, err := m.retrieve(ctx, schemaURL)
+
+	m.rw.Lock()
+	defer m.rw.Unlock()
+	if err != nil {
+		// Cancelled requests say nothing about the schema URL itself.
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		f := &failure{err: err, attempts: 1}
+		if prev, ok := m.failures[schemaURL]; ok {
+			f.attempts = prev.attempts + 1
+		}
+		f.retryAt = m.now().Add(retryBackoff(f.attempts))
+		m.failures[schemaURL] = f
+		return nil, err
+	}
+	delete(m.failures, schemaURL)
+	m.cache[schemaURL] = t
+	return t, nil
+}
+
+func (m *Manager) retrieve(ctx context.Context, schemaURL string) (*Translation, error) {
+	m.log.Debug("Fetching schema", zap.String("schema-url", schemaURL))
+	content, err := m.provider.Retrieve(ctx, schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve schema %q: %w", schemaURL, err)
+	}
+	t, err := NewTranslation(schemaURL, content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse schema %q: %w", schemaURL, err)
+	}
+	return t, nil
+}
+
+// retryBackoff returns the time to wait after the given number of failed attempts.
+func retryBackoff(attempts int) time.Duration {
+	backoff := initialRetryBackoff
+	for i := 1; i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/fixture"
+)
+
+type countingProvider struct {
+	calls    atomic.Int64
+	provider Provider
+}
+
+func (cp *countingProvider) Retrieve(ctx context.Context, schemaURL string) (io.Reader, error) {
+	cp.calls.Add(1)
+	return cp.provider.Retrieve(ctx, schemaURL)
+}
+
+func newCountingProvider() *countingProvider {
+	return &countingProvider{
+		provider: NewFileProvider(map[string]string{
+			exampleURL: filepath.Join("testdata", "schema.yaml"),
+		}),
+	}
+}
+
+func TestManagerRequestTranslator(t *testing.T) {
+	t.Parallel()
+
+	provider := newCountingProvider()
+	m, err := NewManager([]string{exampleFamily + "/1.1.0"}, provider, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	tr, err := m.RequestTranslator(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Nil(t, tr, "Must not translate signals without a schema url")
+
+	tr, err = m.RequestTranslator(context.Background(), "https://other.com/schemas/1.0.0")
+	assert.NoError(t, err)
+	assert.Nil(t, tr, "Must not translate signals without a target")
+
+	tr, err = m.RequestTranslator(context.Background(), exampleFamily+"/1.1.0")
+	assert.NoError(t, err)
+	assert.Nil(t, tr, "Must not translate signals already at the target")
+	assert.Zero(t, provider.calls.Load())
+
+	tr, err = m.RequestTranslator(context.Background(), exampleURL)
+	require.NoError(t, err)
+	require.NotNil(t, tr)
+	assert.Equal(t, exampleFamily+"/1.1.0", tr.SchemaURL())
+
+	tr, err = m.RequestTranslator(context.Background(), exampleURL)
+	require.NoError(t, err)
+	require.NotNil(t, tr)
+	assert.Equal(t, int64(1), provider.calls.Load(), "Must cache retrieved schemas")
+
+	_, err = m.RequestTranslator(context.Background(), exampleFamily+"/1.0.0")
+	assert.ErrorIs(t, err, ErrSchemaNotFound, "Must fetch the target schema when upgrading")
+}
+
+func TestManagerInvalidTarget(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewManager([]string{"example.com/1.0.0"}, newCountingProvider(), zaptest.NewLogger(t))
+	assert.ErrorIs(t, err, ErrInvalidFamily)
+}
+
+func TestManagerPrefetch(t *testing.T) {
+	t.Parallel()
+
+	provider := newCountingProvider()
+	m, err := NewManager(nil, provider, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	assert.NoError(t, m.Prefetch(context.Background(), exampleURL))
+	assert.NoError(t, m.Prefetch(context.Background(), exampleURL))
+	assert.Equal(t, int64(1), provider.calls.Load())
+
+	assert.True(t, errors.Is(m.Prefetch(context.Background(), exampleFamily+"/1.0.0"), ErrSchemaNotFound))
+}
+
+func TestManagerConcurrentRequests(t *testing.T) {
+	m, err := NewManager([]string{exampleFamily + "/1.0.0"}, newCountingProvider(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	fixture.ParallelRaceCompute(t, 10, func() error {
+		_, err := m.RequestTranslator(context.Background(), exampleURL)
+		return err
+	})
+}
+
+func TestManagerCachesFailures(t *testing.T) {
+	t.Parallel()
+
+	provider := newCountingProvider()
+	m, err := NewManager([]string{exampleFamily + "/1.0.0"}, provider, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	missing := exampleFamily + "/1.3.0"
+	_, err = m.RequestTranslator(context.Background(), missing)
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+	_, err = m.RequestTranslator(context.Background(), missing)
+	assert.ErrorIs(t, err, ErrSchemaNotFound, "Must return the cached failure")
+	assert.Equal(t, int64(1), provider.calls.Load(), "Must not retry before the backoff has passed")
+
+	now = now.Add(initialRetryBackoff)
+	_, err = m.RequestTranslator(context.Background(), missing)
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+	assert.Equal(t, int64(2), provider.calls.Load(), "Must retry once the backoff has passed")
+
+	now = now.Add(initialRetryBackoff)
+	_, err = m.RequestTranslator(context.Background(), missing)
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+	assert.Equal(t, int64(2), provider.calls.Load(), "Must double the backoff after repeated failures")
+}
+
+func TestRetryBackoff(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, initialRetryBackoff, retryBackoff(1))
+	assert.Equal(t, 2*initialRetryBackoff, retryBackoff(2))
+	assert.Equal(t, 4*initialRetryBackoff, retryBackoff(3))
+	assert.Equal(t, maxRetryBackoff, retryBackoff(100))
+}
+
+type blockingProvider struct {
+	*countingProvider
+	release chan struct{}
+}
+
+func (bp *blockingProvider) Retrieve(ctx context.Context, schemaURL string) (io.Reader, error) {
+	<-bp.release
+	return bp.countingProvider.Retrieve(ctx, schemaURL)
+}
+
+func TestManagerSharesConcurrentRetrievals(t *testing.T) {
+	t.Parallel()
+
+	provider := &blockingProvider{countingProvider: newCountingProvider(), release: make(chan struct{})}
+	m, err := NewManager([]string{exampleFamily + "/1.0.0"}, provider, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := m.RequestTranslator(context.Background(), exampleURL)
+			assert.NoError(t, err)
+		}()
+	}
+	// Give all requests the chance to wait on the same retrieval.
+	time.Sleep(50 * time.Millisecond)
+	close(provider.release)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), provider.calls.Load())
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// ErrSchemaNotFound is returned by a Provider that has no
+// schema file for the requested schema URL.
+var ErrSchemaNotFound = errors.New("schema not found")
+
+// Provider allows for retrieving the content of a schema file
+// published at the given schema URL.
+type Provider interface {
+	Retrieve(ctx context.Context, schemaURL string) (io.Reader, error)
+}
+
+type httpProvider struct {
+	client *http.Client
+}
+
+var _ Provider = (*httpProvider)(nil)
+
+// NewHTTPProvider returns a provider that fetches
+// schema files from their published schema URL.
+func NewHTTPProvider(client *http.Client) Provider {
+	return &httpProvider{client: client}
+}
+
+func (hp *httpProvider) Retrieve(ctx context.Context, schemaURL string) (io.Reader, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch %q, status code %d", schemaURL, resp.StatusCode)
+	}
+
+	content := bytes.NewBuffer(nil)
+	if _, err := content.ReadFrom(resp.Body); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+type fileProvider struct {
+	files map[string]string
+}
+
+var _ Provider = (*fileProvider)(nil)
+
+// NewFileProvider returns a provider that reads schema files
+// from the local file system, files are keyed by their schema URL.
+func NewFileProvider(files map[string]string) Provider {
+	return &fileProvider{files: files}
+}
+
+func (fp *fileProvider) Retrieve(_ context.Context, schemaURL string) (io.Reader, error) {
+	path, ok := fp.files[schemaURL]
+	if !ok {
+		return nil, fmt.Errorf("%q: %w", schemaURL, ErrSchemaNotFound)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(content), nil
+}
+
+type chainProvider []Provider
+
+var _ Provider = (chainProvider)(nil)
+
+// NewChainProvider returns a provider that tries each of the
+// providers in order until one of them has the requested schema file.
+func NewChainProvider(providers ...Provider) Provider {
+	return chainProvider(providers)
+}
+
+func (cp chainProvider) Retrieve(ctx context.Context, schemaURL string) (io.Reader, error) {
+	for _, p := range cp {
+		content, err := p.Retrieve(ctx, schemaURL)
+		if errors.Is(err, ErrSchemaNotFound) {
+			continue
+		}
+		return content, err
+	}
+	return nil, fmt.Errorf("%q: %w", schemaURL, ErrSchemaNotFound)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPProvider(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/schemas/1.0.0" {
+			wr.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := wr.Write([]byte("content"))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+
+	p := NewHTTPProvider(s.Client())
+
+	content, err := p.Retrieve(context.Background(), s.URL+"/schemas/1.0.0")
+	require.NoError(t, err, "Must not error when fetching schema")
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+
+	_, err = p.Retrieve(context.Background(), s.URL+"/schemas/1.1.0")
+	assert.Error(t, err, "Must error on unexpected status codes")
+}
+
+func TestChainProvider(t *testing.T) {
+	t.Parallel()
+
+	p := NewChainProvider(
+		NewFileProvider(map[string]string{}),
+		NewFileProvider(map[string]string{exampleURL: "testdata/schema.yaml"}),
+	)
+
+	content, err := p.Retrieve(context.Background(), exampleURL)
+	require.NoError(t, err, "Must fall through to the next provider")
+	assert.NotNil(t, content)
+
+	_, err = p.Retrieve(context.Background(), exampleFamily+"/1.0.0")
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+}
+
+func TestFileProviderMissingFile(t *testing.T) {
+	t.Parallel()
+
+	p := NewFileProvider(map[string]string{exampleURL: filepath.Join("testdata", "missing.yaml")})
+	_, err := p.Retrieve(context.Background(), exampleURL)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,393 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	ast10 "go.opentelemetry.io/otel/schema/v1.0/ast"
+	ast11 "go.opentelemetry.io/otel/schema/v1.1/ast"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+)
+
+// direction defines if changes are applied to move
+// a signal to a newer or an older schema version.
+type direction int
+
+const (
+	upgrade direction = iota
+	downgrade
+)
+
+// renames holds a mapping of old names to new names
+// along with its inverse so it can be applied in both directions.
+// The keys of each mapping are kept sorted so renames apply in a fixed order.
+type renames struct {
+	forward      map[string]string
+	backward     map[string]string
+	forwardKeys  []string
+	backwardKeys []string
+}
+
+func newRenames(m map[string]string) renames {
+	r := renames{
+		forward:  make(map[string]string, len(m)),
+		backward: make(map[string]string, len(m)),
+	}
+	for from, to := range m {
+		r.forward[from] = to
+		r.backward[to] = from
+	}
+	r.forwardKeys = sortedKeys(r.forward)
+	r.backwardKeys = sortedKeys(r.backward)
+	return r
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (r renames) mapping(d direction) map[string]string {
+	if d == downgrade {
+		return r.backward
+	}
+	return r.forward
+}
+
+func (r renames) keys(d direction) []string {
+	if d == downgrade {
+		return r.backwardKeys
+	}
+	return r.forwardKeys
+}
+
+// applyAttributes renames the keys of attrs, replacing any existing
+// value already stored under the new key. All renames of the mapping
+// apply at once, so chained renames such as a->b and b->c move the
+// original value of a to b and the original value of b to c.
+func (r renames) applyAttributes(attrs pcommon.Map, d direction) {
+	mapping := r.mapping(d)
+	var renamed pcommon.Map
+	found := false
+	for _, from := range r.keys(d) {
+		v, ok := attrs.Get(from)
+		if !ok {
+			continue
+		}
+		if !found {
+			renamed = pcommon.NewMap()
+			found = true
+		}
+		v.CopyTo(renamed.PutEmpty(mapping[from]))
+		attrs.Remove(from)
+	}
+	if !found {
+		return
+	}
+	renamed.Range(func(to string, v pcommon.Value) bool {
+		v.CopyTo(attrs.PutEmpty(to))
+		return true
+	})
+}
+
+// applyName renames the signal if its name is part of the mapping.
+func (r renames) applyName(s alias.Signal, d direction) {
+	if to, ok := r.mapping(d)[s.Name()]; ok {
+		s.SetName(to)
+	}
+}
+
+// names is a set of signal names a change is restricted to,
+// an empty set matches all names.
+type names map[string]struct{}
+
+func newNames() names {
+	return make(names)
+}
+
+func (n names) matches(name string) bool {
+	if len(n) == 0 {
+		return true
+	}
+	_, ok := n[name]
+	return ok
+}
+
+type spanChange struct {
+	spans      names
+	attributes renames
+}
+
+type spanEventChange struct {
+	eventNames *renames
+
+	spans      names
+	events     names
+	attributes *renames
+}
+
+type metricChange struct {
+	metricNames *renames
+
+	metrics    names
+	attributes *renames
+
+	split *splitChange
+}
+
+// revision contains all of the changes introduced by a single
+// schema version, organised by the type of data they apply to.
+type revision struct {
+	version *Version
+
+	all        []renames
+	resources  []renames
+	spans      []spanChange
+	spanEvents []spanEventChange
+	metrics    []metricChange
+	logs       []renames
+}
+
+func newRevision(v *Version, def ast11.VersionDef) *revision {
+	r := &revision{version: v}
+	for _, c := range def.All.Changes {
+		if c.RenameAttributes != nil {
+			r.all = append(r.all, newRenames(c.RenameAttributes.AttributeMap))
+		}
+	}
+	for _, c := range def.Resources.Changes {
+		if c.RenameAttributes != nil {
+			r.resources = append(r.resources, newRenames(c.RenameAttributes.AttributeMap))
+		}
+	}
+	for _, c := range def.Spans.Changes {
+		if c.RenameAttributes != nil {
+			change := spanChange{
+				spans:      newNames(),
+				attributes: newRenames(c.RenameAttributes.AttributeMap),
+			}
+			for _, name := range c.RenameAttributes.ApplyToSpans {
+				change.spans[string(name)] = struct{}{}
+			}
+			r.spans = append(r.spans, change)
+		}
+	}
+	for _, c := range def.SpanEvents.Changes {
+		r.spanEvents = append(r.spanEvents, newSpanEventChange(c))
+	}
+	for _, c := range def.Metrics.Changes {
+		r.metrics = append(r.metrics, newMetricChange(c))
+	}
+	for _, c := range def.Logs.Changes {
+		if c.RenameAttributes != nil {
+			r.logs = append(r.logs, newRenames(c.RenameAttributes.AttributeMap))
+		}
+	}
+	return r
+}
+
+func newSpanEventChange(c ast10.SpanEventsChange) spanEventChange {
+	var change spanEventChange
+	if c.RenameEvents != nil {
+		events := newRenames(c.RenameEvents.EventNameMap)
+		change.eventNames = &events
+	}
+	if c.RenameAttributes != nil {
+		attrs := newRenames(c.RenameAttributes.AttributeMap)
+		change.attributes = &attrs
+		change.spans = newNames()
+		for _, name := range c.RenameAttributes.ApplyToSpans {
+			change.spans[string(name)] = struct{}{}
+		}
+		change.events = newNames()
+		for _, name := range c.RenameAttributes.ApplyToEvents {
+			change.events[string(name)] = struct{}{}
+		}
+	}
+	return change
+}
+
+func newMetricChange(c ast11.MetricsChange) metricChange {
+	var change metricChange
+	if c.RenameMetrics != nil {
+		m := make(map[string]string, len(c.RenameMetrics))
+		for from, to := range c.RenameMetrics {
+			m[string(from)] = string(to)
+		}
+		metrics := newRenames(m)
+		change.metricNames = &metrics
+	}
+	if c.RenameAttributes != nil {
+		attrs := newRenames(c.RenameAttributes.AttributeMap)
+		change.attributes = &attrs
+		change.metrics = newNames()
+		for _, name := range c.RenameAttributes.ApplyToMetrics {
+			change.metrics[string(name)] = struct{}{}
+		}
+	}
+	if c.Split != nil {
+		change.split = newSplitChange(c.Split)
+	}
+	return change
+}
+
+// apply performs the event rename followed by the attribute rename
+// when upgrading, and the reverse when downgrading.
+func (c spanEventChange) apply(span ptrace.Span, event ptrace.SpanEvent, d direction) {
+	steps := []func(){
+		func() {
+			if c.eventNames != nil {
+				c.eventNames.applyName(event, d)
+			}
+		},
+		func() {
+			if c.attributes != nil && c.spans.matches(span.Name()) && c.events.matches(event.Name()) {
+				c.attributes.applyAttributes(event.Attributes(), d)
+			}
+		},
+	}
+	for _, i := range order(len(steps), d) {
+		steps[i]()
+	}
+}
+
+// apply performs the metric split, metric rename and attribute rename
+// in that order when upgrading, and the reverse when downgrading.
+func (c metricChange) apply(metrics pmetric.MetricSlice, d direction) {
+	steps := []func(){
+		func() {
+			if c.split != nil {
+				c.split.apply(metrics, d)
+			}
+		},
+		func() {
+			if c.metricNames == nil {
+				return
+			}
+			for i := 0; i < metrics.Len(); i++ {
+				c.metricNames.applyName(metrics.At(i), d)
+			}
+		},
+		func() {
+			if c.attributes == nil {
+				return
+			}
+			for i := 0; i < metrics.Len(); i++ {
+				if metric := metrics.At(i); c.metrics.matches(metric.Name()) {
+					forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+						c.attributes.applyAttributes(attrs, d)
+					})
+				}
+			}
+		},
+	}
+	for _, i := range order(len(steps), d) {
+		steps[i]()
+	}
+}
+
+// applyAll applies the changes defined in the "all" section followed by the
+// section specific changes when upgrading, and the reverse when downgrading.
+func applyAll(all []renames, attrs pcommon.Map, d direction, section func()) {
+	if d == downgrade {
+		section()
+	}
+	for _, i := range order(len(all), d) {
+		all[i].applyAttributes(attrs, d)
+	}
+	if d == upgrade {
+		section()
+	}
+}
+
+// order returns the indexes of n changes in declared order
+// when upgrading and in reverse order when downgrading.
+func order(n int, d direction) []int {
+	idx := make([]int, n)
+	for i := range idx {
+		if d == upgrade {
+			idx[i] = i
+		} else {
+			idx[i] = n - 1 - i
+		}
+	}
+	return idx
+}
+
+func (r *revision) applyResource(res pcommon.Resource, d direction) {
+	attrs := res.Attributes()
+	applyAll(r.all, attrs, d, func() {
+		for _, i := range order(len(r.resources), d) {
+			r.resources[i].applyAttributes(attrs, d)
+		}
+	})
+}
+
+func (r *revision) applySpans(spans ptrace.SpanSlice, d direction) {
+	for i := 0; i < spans.Len(); i++ {
+		span := spans.At(i)
+		applyAll(r.all, span.Attributes(), d, func() {
+			for _, k := range order(len(r.spans), d) {
+				if c := r.spans[k]; c.spans.matches(span.Name()) {
+					c.attributes.applyAttributes(span.Attributes(), d)
+				}
+			}
+		})
+
+		events := span.Events()
+		for j := 0; j < events.Len(); j++ {
+			event := events.At(j)
+			applyAll(r.all, event.Attributes(), d, func() {
+				for _, k := range order(len(r.spanEvents), d) {
+					r.spanEvents[k].apply(span, event, d)
+				}
+			})
+		}
+	}
+}
+
+func (r *revision) applyMetrics(metrics pmetric.MetricSlice, d direction) {
+	applySection := func() {
+		for _, k := range order(len(r.metrics), d) {
+			r.metrics[k].apply(metrics, d)
+		}
+	}
+	applyAllToDataPoints := func() {
+		for i := 0; i < metrics.Len(); i++ {
+			forEachDataPointAttributes(metrics.At(i), func(attrs pcommon.Map) {
+				for _, k := range order(len(r.all), d) {
+					r.all[k].applyAttributes(attrs, d)
+				}
+			})
+		}
+	}
+	if d == downgrade {
+		applySection()
+		applyAllToDataPoints()
+		return
+	}
+	applyAllToDataPoints()
+	applySection()
+}
+
+func (r *revision) applyLogs(logs plog.LogRecordSlice, d direction) {
+	for i := 0; i < logs.Len(); i++ {
+		attrs := logs.At(i).Attributes()
+		applyAll(r.all, attrs, d, func() {
+			for _, k := range order(len(r.logs), d) {
+				r.logs[k].applyAttributes(attrs, d)
+			}
+		})
+	}
+}
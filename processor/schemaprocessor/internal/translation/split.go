@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	ast11 "go.opentelemetry.io/otel/schema/v1.1/ast"
+)
+
+// splitChange splits a metric into multiple metrics based on the value of
+// one of its attributes when upgrading, and merges them back when downgrading.
+type splitChange struct {
+	metric    string
+	attribute string
+	// newMetrics are the names of the metrics created by the split in a
+	// deterministic order, values holds the attribute value for each of them.
+	newMetrics []string
+	values     map[string]string
+	rawValues  map[string]any
+}
+
+func newSplitChange(s *ast11.SplitMetric) *splitChange {
+	c := &splitChange{
+		metric:    string(s.ApplyToMetric),
+		attribute: string(s.ByAttribute),
+		values:    make(map[string]string, len(s.MetricsFromAttributes)),
+		rawValues: make(map[string]any, len(s.MetricsFromAttributes)),
+	}
+	for name, value := range s.MetricsFromAttributes {
+		c.newMetrics = append(c.newMetrics, string(name))
+		c.values[string(name)] = fmt.Sprint(value)
+		c.rawValues[string(name)] = value
+	}
+	sort.Strings(c.newMetrics)
+	return c
+}
+
+func (c *splitChange) apply(metrics pmetric.MetricSlice, d direction) {
+	if d == downgrade {
+		c.merge(metrics)
+		return
+	}
+	c.split(metrics)
+}
+
+func (c *splitChange) matches(attrs pcommon.Map, value string) bool {
+	v, ok := attrs.Get(c.attribute)
+	return ok && v.AsString() == value
+}
+
+func (c *splitChange) split(metrics pmetric.MetricSlice) {
+	// Metrics are appended while iterating, so only
+	// the metrics that existed beforehand are checked.
+	n := metrics.Len()
+	for i := 0; i < n; i++ {
+		source := metrics.At(i)
+		if source.Name() != c.metric {
+			continue
+		}
+		for _, name := range c.newMetrics {
+			value := c.values[name]
+			matched := false
+			forEachDataPointAttributes(source, func(attrs pcommon.Map) {
+				matched = matched || c.matches(attrs, value)
+			})
+			if !matched {
+				continue
+			}
+			target := metrics.AppendEmpty()
+			source.CopyTo(target)
+			target.SetName(name)
+			removeDataPointsIf(target, func(attrs pcommon.Map) bool {
+				return !c.matches(attrs, value)
+			})
+			forEachDataPointAttributes(target, func(attrs pcommon.Map) {
+				attrs.Remove(c.attribute)
+			})
+		}
+		removeDataPointsIf(source, func(attrs pcommon.Map) bool {
+			for _, value := range c.values {
+				if c.matches(attrs, value) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	metrics.RemoveIf(func(m pmetric.Metric) bool {
+		return m.Name() == c.metric && dataPointCount(m) == 0
+	})
+}
+
+func (c *splitChange) merge(metrics pmetric.MetricSlice) {
+	var (
+		target pmetric.Metric
+		found  bool
+	)
+	for i := 0; i < metrics.Len(); i++ {
+		if m := metrics.At(i); m.Name() == c.metric {
+			target, found = m, true
+			break
+		}
+	}
+
+	merged := make(map[int]struct{})
+	for i := 0; i < metrics.Len(); i++ {
+		source := metrics.At(i)
+		value, ok := c.rawValues[source.Name()]
+		if !ok {
+			continue
+		}
+		if !found {
+			target, found = metrics.AppendEmpty(), true
+			source.CopyTo(target)
+			target.SetName(c.metric)
+			removeDataPointsIf(target, func(pcommon.Map) bool { return true })
+		}
+		if source.Type() != target.Type() {
+			continue
+		}
+		forEachDataPointAttributes(source, func(attrs pcommon.Map) {
+			if err := attrs.PutEmpty(c.attribute).FromRaw(value); err != nil {
+				attrs.PutStr(c.attribute, fmt.Sprint(value))
+			}
+		})
+		moveDataPoints(source, target)
+		merged[i] = struct{}{}
+	}
+
+	i := -1
+	metrics.RemoveIf(func(pmetric.Metric) bool {
+		i++
+		_, ok := merged[i]
+		return ok
+	})
+}
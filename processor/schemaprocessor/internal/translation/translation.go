@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	schema "go.opentelemetry.io/otel/schema/v1.1"
+)
+
+// Translation is the parsed content of a schema file,
+// the revisions are sorted in ascending version order.
+type Translation struct {
+	family    string
+	version   *Version
+	revisions []*revision
+}
+
+// NewTranslation parses the schema file content published at schemaURL.
+func NewTranslation(schemaURL string, content io.Reader) (*Translation, error) {
+	family, version, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	s, err := schema.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	// The schema file may be served from a mirror, so only
+	// the version of its published schema URL needs to match.
+	_, published, err := GetFamilyAndVersion(s.SchemaURL)
+	if err != nil {
+		return nil, err
+	}
+	if !published.Equal(version) {
+		return nil, fmt.Errorf("schema file is for version %s, expected %s: %w", published, version, ErrInvalidVersion)
+	}
+
+	t := &Translation{
+		family:    family,
+		version:   version,
+		revisions: make([]*revision, 0, len(s.Versions)),
+	}
+	for v, def := range s.Versions {
+		rv, err := NewVersion(string(v))
+		if err != nil {
+			return nil, err
+		}
+		t.revisions = append(t.revisions, newRevision(rv, def))
+	}
+	sort.Slice(t.revisions, func(i, j int) bool {
+		return t.revisions[i].version.LessThan(t.revisions[j].version)
+	})
+	return t, nil
+}
+
+// SupportsVersion returns true if the schema file
+// contains the changes required to reach version v.
+func (t *Translation) SupportsVersion(v *Version) bool {
+	return !v.GreaterThan(t.version)
+}
+
+// Translator returns the set of changes to convert signals published
+// with the from version into the version of the target schema URL.
+func (t *Translation) Translator(from *Version, targetURL string) (*Translator, error) {
+	family, to, err := GetFamilyAndVersion(targetURL)
+	if err != nil {
+		return nil, err
+	}
+	if family != t.family {
+		return nil, fmt.Errorf("%q is not part of %q: %w", targetURL, t.family, ErrInvalidFamily)
+	}
+	if !t.SupportsVersion(from) || !t.SupportsVersion(to) {
+		return nil, fmt.Errorf("%s only supports up to version %s: %w", t.family, t.version, ErrInvalidVersion)
+	}
+	tr := &Translator{
+		schemaURL: targetURL,
+		direction: upgrade,
+	}
+	lower, upper := from, to
+	if from.GreaterThan(to) {
+		tr.direction = downgrade
+		lower, upper = to, from
+	}
+	// Changes listed under a version describe how to move from
+	// the previous version to that version, so the lower bound is excluded.
+	for _, rv := range t.revisions {
+		if rv.version.GreaterThan(lower) && !rv.version.GreaterThan(upper) {
+			tr.revisions = append(tr.revisions, rv)
+		}
+	}
+	if tr.direction == downgrade {
+		for i, j := 0, len(tr.revisions)-1; i < j; i, j = i+1, j-1 {
+			tr.revisions[i], tr.revisions[j] = tr.revisions[j], tr.revisions[i]
+		}
+	}
+	return tr, nil
+}
+
+// Translator applies the changes between two versions of a schema family
+// to signals, in the order required by the direction of the translation.
+type Translator struct {
+	schemaURL string
+	direction direction
+	revisions []*revision
+}
+
+// SchemaURL is the schema URL signals conform to once translated.
+func (tr *Translator) SchemaURL() string {
+	return tr.schemaURL
+}
+
+// ApplyResource applies the resource changes to res.
+func (tr *Translator) ApplyResource(res pcommon.Resource) {
+	for _, rv := range tr.revisions {
+		rv.applyResource(res, tr.direction)
+	}
+}
+
+// ApplySpans applies the span and span event changes to spans.
+func (tr *Translator) ApplySpans(spans ptrace.SpanSlice) {
+	for _, rv := range tr.revisions {
+		rv.applySpans(spans, tr.direction)
+	}
+}
+
+// ApplyMetrics applies the metric changes to metrics.
+func (tr *Translator) ApplyMetrics(metrics pmetric.MetricSlice) {
+	for _, rv := range tr.revisions {
+		rv.applyMetrics(metrics, tr.direction)
+	}
+}
+
+// ApplyLogs applies the log changes to logs.
+func (tr *Translator) ApplyLogs(logs plog.LogRecordSlice) {
+	for _, rv := range tr.revisions {
+		rv.applyLogs(logs, tr.direction)
+	}
+}
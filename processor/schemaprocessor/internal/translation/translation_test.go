@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	exampleFamily = "https://example.com/schemas"
+	exampleURL    = exampleFamily + "/1.2.0"
+)
+
+func newExampleTranslation(t *testing.T) *Translation {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", "schema.yaml"))
+	require.NoError(t, err, "Must be able to open test schema")
+	defer f.Close()
+
+	tn, err := NewTranslation(exampleURL, f)
+	require.NoError(t, err, "Must be able to parse test schema")
+	return tn
+}
+
+func newTranslator(t *testing.T, from, to string) *Translator {
+	t.Helper()
+	v, err := NewVersion(from)
+	require.NoError(t, err)
+	tr, err := newExampleTranslation(t).Translator(v, exampleFamily+"/"+to)
+	require.NoError(t, err, "Must create a translator")
+	return tr
+}
+
+func TestNewTranslationErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewTranslation("https://example.com/schemas/1.1.0", strings.NewReader("file_format: 1.1.0\nschema_url: https://example.com/schemas/1.2.0\n"))
+	assert.ErrorIs(t, err, ErrInvalidVersion, "Must reject schema files published for another version")
+
+	_, err = NewTranslation(exampleURL, strings.NewReader("file_format: 1.1.0\nschema_url: https://example.com/schemas/1.2.0\nunknown: field\n"))
+	assert.Error(t, err, "Must reject invalid schema files")
+
+	_, err = NewTranslation("example.com/schemas/1.2.0", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrInvalidFamily, "Must reject invalid schema urls")
+}
+
+func TestTranslatorUnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	tn := newExampleTranslation(t)
+	_, err := tn.Translator(&Version{1, 0, 0}, exampleFamily+"/1.3.0")
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+
+	_, err = tn.Translator(&Version{1, 0, 0}, "https://other.com/schemas/1.1.0")
+	assert.ErrorIs(t, err, ErrInvalidFamily)
+}
+
+func TestTranslatorResource(t *testing.T) {
+	t.Parallel()
+
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("k8s.pod.name", "pod-a")
+	res.Attributes().PutStr("telemetry.auto.version", "1.0")
+	res.Attributes().PutStr("unchanged", "value")
+
+	newTranslator(t, "1.0.0", "1.2.0").ApplyResource(res)
+	assert.Equal(t, map[string]any{
+		"kubernetes.pod.name":          "pod-a",
+		"telemetry.auto_instr.version": "1.0",
+		"unchanged":                    "value",
+	}, res.Attributes().AsRaw())
+
+	newTranslator(t, "1.2.0", "1.0.0").ApplyResource(res)
+	assert.Equal(t, map[string]any{
+		"k8s.pod.name":           "pod-a",
+		"telemetry.auto.version": "1.0",
+		"unchanged":              "value",
+	}, res.Attributes().AsRaw())
+}
+
+func TestTranslatorSpans(t *testing.T) {
+	t.Parallel()
+
+	spans := ptrace.NewSpanSlice()
+	get := spans.AppendEmpty()
+	get.SetName("HTTP GET")
+	get.Attributes().PutStr("peer.service", "db")
+	get.Attributes().PutStr("k8s.pod.name", "pod-a")
+	event := get.Events().AppendEmpty()
+	event.SetName("stacktrace")
+	event.Attributes().PutStr("peer.service", "db")
+	post := spans.AppendEmpty()
+	post.SetName("HTTP POST")
+	post.Attributes().PutStr("peer.service", "db")
+
+	newTranslator(t, "1.0.0", "1.1.0").ApplySpans(spans)
+	assert.Equal(t, map[string]any{"peer.service.name": "db", "kubernetes.pod.name": "pod-a"}, get.Attributes().AsRaw())
+	assert.Equal(t, "stack_trace", event.Name())
+	assert.Equal(t, map[string]any{"peer.service.name": "db"}, event.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"peer.service": "db"}, post.Attributes().AsRaw(), "Must only apply to matching spans")
+
+	newTranslator(t, "1.1.0", "1.0.0").ApplySpans(spans)
+	assert.Equal(t, map[string]any{"peer.service": "db", "k8s.pod.name": "pod-a"}, get.Attributes().AsRaw())
+	assert.Equal(t, "stacktrace", event.Name())
+	assert.Equal(t, map[string]any{"peer.service": "db"}, event.Attributes().AsRaw())
+}
+
+func TestTranslatorLogs(t *testing.T) {
+	t.Parallel()
+
+	logs := plog.NewLogRecordSlice()
+	lr := logs.AppendEmpty()
+	lr.Attributes().PutStr("process.executable_name", "otelcol")
+	lr.Attributes().PutStr("k8s.pod.name", "pod-a")
+
+	newTranslator(t, "1.0.0", "1.1.0").ApplyLogs(logs)
+	assert.Equal(t, map[string]any{"process.executable.name": "otelcol", "kubernetes.pod.name": "pod-a"}, lr.Attributes().AsRaw())
+
+	newTranslator(t, "1.1.0", "1.0.0").ApplyLogs(logs)
+	assert.Equal(t, map[string]any{"process.executable_name": "otelcol", "k8s.pod.name": "pod-a"}, lr.Attributes().AsRaw())
+}
+
+func TestTranslatorMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := pmetric.NewMetricSlice()
+	cpu := metrics.AppendEmpty()
+	cpu.SetName("container.cpu.usage.total")
+	cpu.SetEmptySum().DataPoints().AppendEmpty().Attributes().PutStr("k8s.pod.name", "pod-a")
+	util := metrics.AppendEmpty()
+	util.SetName("system.cpu.utilization")
+	util.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("status", "idle")
+
+	newTranslator(t, "1.0.0", "1.1.0").ApplyMetrics(metrics)
+	assert.Equal(t, "cpu.usage.total", cpu.Name())
+	assert.Equal(t, map[string]any{"kubernetes.pod.name": "pod-a"}, cpu.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"state": "idle"}, util.Gauge().DataPoints().At(0).Attributes().AsRaw())
+
+	newTranslator(t, "1.1.0", "1.0.0").ApplyMetrics(metrics)
+	assert.Equal(t, "container.cpu.usage.total", cpu.Name())
+	assert.Equal(t, map[string]any{"k8s.pod.name": "pod-a"}, cpu.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"status": "idle"}, util.Gauge().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestTranslatorSplitMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := pmetric.NewMetricSlice()
+	paging := metrics.AppendEmpty()
+	paging.SetName("system.paging.operations")
+	paging.SetUnit("{operations}")
+	dps := paging.SetEmptySum().DataPoints()
+	for _, direction := range []string{"in", "out", "sideways"} {
+		dp := dps.AppendEmpty()
+		dp.SetIntValue(int64(len(direction)))
+		dp.Attributes().PutStr("direction", direction)
+		dp.Attributes().PutStr("type", "major")
+	}
+
+	newTranslator(t, "1.1.0", "1.2.0").ApplyMetrics(metrics)
+	require.Equal(t, 3, metrics.Len())
+	byName := make(map[string]pmetric.Metric)
+	for i := 0; i < metrics.Len(); i++ {
+		byName[metrics.At(i).Name()] = metrics.At(i)
+	}
+	require.Contains(t, byName, "system.paging.operations.in")
+	require.Contains(t, byName, "system.paging.operations.out")
+	require.Contains(t, byName, "system.paging.operations", "Must keep data points that did not match")
+
+	in := byName["system.paging.operations.in"]
+	assert.Equal(t, "{operations}", in.Unit())
+	require.Equal(t, 1, in.Sum().DataPoints().Len())
+	assert.Equal(t, int64(2), in.Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, map[string]any{"type": "major"}, in.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, 1, byName["system.paging.operations"].Sum().DataPoints().Len())
+
+	newTranslator(t, "1.2.0", "1.1.0").ApplyMetrics(metrics)
+	require.Equal(t, 1, metrics.Len())
+	merged := metrics.At(0)
+	assert.Equal(t, "system.paging.operations", merged.Name())
+	require.Equal(t, 3, merged.Sum().DataPoints().Len())
+	directions := make(map[string]int64)
+	for i := 0; i < merged.Sum().DataPoints().Len(); i++ {
+		dp := merged.Sum().DataPoints().At(i)
+		v, ok := dp.Attributes().Get("direction")
+		require.True(t, ok, "Must restore the split attribute")
+		directions[v.Str()] = dp.IntValue()
+	}
+	assert.Equal(t, map[string]int64{"in": 2, "out": 3, "sideways": 8}, directions)
+}
+
+func TestTranslatorMergeWithoutExistingMetric(t *testing.T) {
+	t.Parallel()
+
+	metrics := pmetric.NewMetricSlice()
+	out := metrics.AppendEmpty()
+	out.SetName("system.paging.operations.out")
+	out.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1)
+
+	newTranslator(t, "1.2.0", "1.0.0").ApplyMetrics(metrics)
+	require.Equal(t, 1, metrics.Len())
+	assert.Equal(t, "system.paging.operations", metrics.At(0).Name())
+	require.Equal(t, 1, metrics.At(0).Gauge().DataPoints().Len())
+	assert.Equal(t, map[string]any{"direction": "out"}, metrics.At(0).Gauge().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestRenamesChained(t *testing.T) {
+	t.Parallel()
+
+	r := newRenames(map[string]string{"a": "b", "b": "c", "c": "d"})
+	for i := 0; i < 20; i++ {
+		attrs := pcommon.NewMap()
+		attrs.PutStr("a", "1")
+		attrs.PutStr("b", "2")
+		r.applyAttributes(attrs, upgrade)
+		assert.Equal(t, map[string]any{"b": "1", "c": "2"}, attrs.AsRaw())
+
+		r.applyAttributes(attrs, downgrade)
+		assert.Equal(t, map[string]any{"a": "1", "b": "2"}, attrs.AsRaw())
+	}
+}
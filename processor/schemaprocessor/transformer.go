@@ -13,11 +13,17 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
 type transformer struct {
-	targets []string
-	log     *zap.Logger
+	targets   []string
+	log       *zap.Logger
+	cfg       *Config
+	telemetry component.TelemetrySettings
+	manager   *translation.Manager
 }
 
 func newTransformer(
@@ -30,28 +36,134 @@ func newTransformer(
 		return nil, errors.New("invalid configuration provided")
 	}
 	return &transformer{
-		log:     set.Logger,
-		targets: cfg.Targets,
+		log:       set.Logger,
+		targets:   cfg.Targets,
+		cfg:       cfg,
+		telemetry: set.TelemetrySettings,
 	}, nil
 }
 
-func (t transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+func (t *transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	for rl := 0; rl < ld.ResourceLogs().Len(); rl++ {
+		rLog := ld.ResourceLogs().At(rl)
+		resourceURL := t.translateResource(ctx, rLog)
+		for sl := 0; sl < rLog.ScopeLogs().Len(); sl++ {
+			sLog := rLog.ScopeLogs().At(sl)
+			tr := t.scopeTranslator(ctx, sLog.SchemaUrl(), resourceURL)
+			if tr == nil {
+				continue
+			}
+			tr.ApplyLogs(sLog.LogRecords())
+			if sLog.SchemaUrl() != "" {
+				sLog.SetSchemaUrl(tr.SchemaURL())
+			}
+		}
+	}
 	return ld, nil
 }
 
-func (t transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+func (t *transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	for rm := 0; rm < md.ResourceMetrics().Len(); rm++ {
+		rMetric := md.ResourceMetrics().At(rm)
+		resourceURL := t.translateResource(ctx, rMetric)
+		for sm := 0; sm < rMetric.ScopeMetrics().Len(); sm++ {
+			sMetric := rMetric.ScopeMetrics().At(sm)
+			tr := t.scopeTranslator(ctx, sMetric.SchemaUrl(), resourceURL)
+			if tr == nil {
+				continue
+			}
+			tr.ApplyMetrics(sMetric.Metrics())
+			if sMetric.SchemaUrl() != "" {
+				sMetric.SetSchemaUrl(tr.SchemaURL())
+			}
+		}
+	}
 	return md, nil
 }
 
-func (t transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+func (t *transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	for rs := 0; rs < td.ResourceSpans().Len(); rs++ {
+		rSpan := td.ResourceSpans().At(rs)
+		resourceURL := t.translateResource(ctx, rSpan)
+		for ss := 0; ss < rSpan.ScopeSpans().Len(); ss++ {
+			sSpan := rSpan.ScopeSpans().At(ss)
+			tr := t.scopeTranslator(ctx, sSpan.SchemaUrl(), resourceURL)
+			if tr == nil {
+				continue
+			}
+			tr.ApplySpans(sSpan.Spans())
+			if sSpan.SchemaUrl() != "" {
+				sSpan.SetSchemaUrl(tr.SchemaURL())
+			}
+		}
+	}
 	return td, nil
 }
 
+// translateResource converts the resource to the target schema and
+// returns the schema URL the resource was originally published with.
+func (t *transformer) translateResource(ctx context.Context, res alias.Resource) string {
+	schemaURL := res.SchemaUrl()
+	tr := t.translator(ctx, schemaURL)
+	if tr == nil {
+		return schemaURL
+	}
+	tr.ApplyResource(res.Resource())
+	res.SetSchemaUrl(tr.SchemaURL())
+	return schemaURL
+}
+
+// scopeTranslator returns the translator for the scope's schema URL,
+// falling back to the resource's schema URL when the scope has none.
+func (t *transformer) scopeTranslator(ctx context.Context, scopeURL, resourceURL string) *translation.Translator {
+	if scopeURL != "" {
+		return t.translator(ctx, scopeURL)
+	}
+	return t.translator(ctx, resourceURL)
+}
+
+// translator returns the translator for schemaURL, signals that
+// can not be translated are passed through unchanged.
+func (t *transformer) translator(ctx context.Context, schemaURL string) *translation.Translator {
+	if t.manager == nil || schemaURL == "" {
+		return nil
+	}
+	tr, err := t.manager.RequestTranslator(ctx, schemaURL)
+	if err != nil {
+		t.log.Error("Unable to translate schema", zap.String("schema-url", schemaURL), zap.Error(err))
+		return nil
+	}
+	return tr
+}
+
 // start will load the remote file definition if it isn't already cached
 // and resolve the schema translation file
 func (t *transformer) start(ctx context.Context, host component.Host) error {
-	for _, target := range t.targets {
-		t.log.Info("Fetching remote schema url", zap.String("schema-url", target))
+	client, err := t.cfg.HTTPClientSettings.ToClient(host, t.telemetry)
+	if err != nil {
+		return err
+	}
+	files := make(map[string]string, len(t.cfg.SchemaFiles))
+	for _, file := range t.cfg.SchemaFiles {
+		files[file.SchemaURL] = file.Path
+	}
+	provider := translation.NewChainProvider(
+		translation.NewFileProvider(files),
+		translation.NewHTTPProvider(client),
+	)
+
+	manager, err := translation.NewManager(t.targets, provider, t.log)
+	if err != nil {
+		return err
+	}
+	for _, schemaURL := range t.cfg.Prefetch {
+		t.log.Info("Fetching remote schema url", zap.String("schema-url", schemaURL))
+		if err := manager.Prefetch(ctx, schemaURL); err != nil {
+			// Failing to prefetch is not fatal, the schema
+			// will be requested again when it is needed.
+			t.log.Warn("Unable to prefetch schema", zap.String("schema-url", schemaURL), zap.Error(err))
+		}
 	}
+	t.manager = manager
 	return nil
 }
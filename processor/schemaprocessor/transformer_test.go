@@ -7,11 +7,14 @@ import (
 	"context"
 	_ "embed"
 	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -98,3 +101,113 @@ func TestTransformerProcessing(t *testing.T) {
 		assert.Equal(t, in, out, "Must return the same data (subject to change)")
 	})
 }
+
+func TestTransformerTranslation(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(SchemaHandler(t)))
+	t.Cleanup(s.Close)
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{s.URL + "/schemas/1.0.0"}
+	trans, err := newTransformer(context.Background(), cfg, processor.CreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err, "Must not error when creating transformer")
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()))
+
+	t.Run("traces", func(t *testing.T) {
+		in := ptrace.NewTraces()
+		rs := in.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl(s.URL + "/schemas/1.1.0")
+		rs.Resource().Attributes().PutStr("kubernetes.pod.name", "pod-a")
+		rs.Resource().Attributes().PutStr("telemetry.auto_instr.version", "1.0")
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetName("HTTP GET")
+		span.Attributes().PutStr("peer.service.name", "db")
+
+		out, err := trans.processTraces(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing traces")
+
+		rs = out.ResourceSpans().At(0)
+		assert.Equal(t, s.URL+"/schemas/1.0.0", rs.SchemaUrl())
+		assert.Equal(t, map[string]any{
+			"k8s.pod.name":           "pod-a",
+			"telemetry.auto.version": "1.0",
+		}, rs.Resource().Attributes().AsRaw())
+		assert.Equal(t, map[string]any{"peer.service": "db"}, rs.ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		in := pmetric.NewMetrics()
+		rm := in.ResourceMetrics().AppendEmpty()
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.SetSchemaUrl(s.URL + "/schemas/1.1.0")
+		m := sm.Metrics().AppendEmpty()
+		m.SetName("cpu.usage.total")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1)
+
+		out, err := trans.processMetrics(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing metrics")
+
+		sm = out.ResourceMetrics().At(0).ScopeMetrics().At(0)
+		assert.Equal(t, s.URL+"/schemas/1.0.0", sm.SchemaUrl(), "Must update the scope schema url")
+		assert.Equal(t, "container.cpu.usage.total", sm.Metrics().At(0).Name())
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl(s.URL + "/schemas/1.1.0")
+		lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		lr.Attributes().PutStr("process.executable.name", "otelcol")
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing logs")
+
+		lr = out.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+		assert.Equal(t, map[string]any{"process.executable_name": "otelcol"}, lr.Attributes().AsRaw())
+	})
+
+	t.Run("unknown schema", func(t *testing.T) {
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl(s.URL + "/missing/1.1.0")
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("process.executable.name", "otelcol")
+		expected := plog.NewLogs()
+		in.CopyTo(expected)
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err, "Must pass through signals that can not be translated")
+		assert.Equal(t, expected, out)
+	})
+}
+
+func TestTransformerSchemaFiles(t *testing.T) {
+	t.Parallel()
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{"https://opentelemetry.io/schemas/1.1.0"}
+	cfg.SchemaFiles = []SchemaFile{
+		{SchemaURL: "https://opentelemetry.io/schemas/1.1.0", Path: filepath.Join("testdata", "schema.yml")},
+	}
+	trans, err := newTransformer(context.Background(), cfg, processor.CreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err, "Must not error when creating transformer")
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()))
+
+	in := plog.NewLogs()
+	rl := in.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+	rl.Resource().Attributes().PutStr("k8s.node.name", "node-a")
+
+	out, err := trans.processLogs(context.Background(), in)
+	require.NoError(t, err, "Must not error when processing logs")
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.1.0", out.ResourceLogs().At(0).SchemaUrl())
+	assert.Equal(t, map[string]any{"kubernetes.node.name": "node-a"}, out.ResourceLogs().At(0).Resource().Attributes().AsRaw())
+}
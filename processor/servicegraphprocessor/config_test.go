@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/otelcol/otelcoltest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -20,7 +22,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 
 	factories.Processors[typeStr] = NewFactory()
-	factories.Connectors[typeStr] = NewConnectorFactory()
+	factories.Connectors[typeStr] = servicegraphconnector.NewFactory()
 
 	// Test
 	cfg, err := otelcoltest.LoadConfigAndValidate(filepath.Join("testdata", "service-graph-config.yaml"), factories)
@@ -30,16 +32,18 @@ func TestLoadConfig(t *testing.T) {
 	require.NotNil(t, cfg)
 	assert.Equal(t,
 		&Config{
-			MetricsExporter:         "metrics",
-			LatencyHistogramBuckets: []time.Duration{1, 2, 3, 4, 5},
-			Dimensions:              []string{"dimension-1", "dimension-2"},
-			Store: StoreConfig{
-				TTL:      time.Second,
-				MaxItems: 10,
+			Config: servicegraphconnector.Config{
+				LatencyHistogramBuckets: []time.Duration{1, 2, 3, 4, 5},
+				Dimensions:              []string{"dimension-1", "dimension-2"},
+				Store: StoreConfig{
+					TTL:      time.Second,
+					MaxItems: 10,
+				},
+				CacheLoop:                 2 * time.Minute,
+				StoreExpirationLoop:       10 * time.Second,
+				VirtualNodePeerAttributes: []string{"db.name", "rpc.service"},
 			},
-			CacheLoop:                 2 * time.Minute,
-			StoreExpirationLoop:       10 * time.Second,
-			VirtualNodePeerAttributes: []string{"db.name", "rpc.service"},
+			MetricsExporter: "metrics",
 		},
 		cfg.Processors[component.NewID(typeStr)],
 	)
@@ -50,10 +54,10 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 	assert.Equal(t,
-		&Config{
+		&servicegraphconnector.Config{
 			LatencyHistogramBuckets: []time.Duration{1, 2, 3, 4, 5},
 			Dimensions:              []string{"dimension-1", "dimension-2"},
-			Store: StoreConfig{
+			Store: servicegraphconnector.StoreConfig{
 				TTL:      time.Second,
 				MaxItems: 10,
 			},
@@ -62,5 +66,4 @@ func TestLoadConfig(t *testing.T) {
 		},
 		cfg.Connectors[component.NewID(typeStr)],
 	)
-
 }
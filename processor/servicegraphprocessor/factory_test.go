@@ -6,94 +6,35 @@ package servicegraphprocessor
 import (
 	"context"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
-	"go.opentelemetry.io/collector/connector/connectortest"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/processor/processortest"
-)
-
-func TestNewProcessor(t *testing.T) {
-	for _, tc := range []struct {
-		name                            string
-		latencyHistogramBuckets         []time.Duration
-		expectedLatencyHistogramBuckets []float64
-	}{
-		{
-			name:                            "simplest config (use defaults)",
-			expectedLatencyHistogramBuckets: defaultLatencyHistogramBucketsMs,
-		},
-		{
-			name:                            "latency histogram configured with catch-all bucket to check no additional catch-all bucket inserted",
-			latencyHistogramBuckets:         []time.Duration{2 * time.Millisecond},
-			expectedLatencyHistogramBuckets: []float64{2},
-		},
-		{
-			name:                            "full config with no catch-all bucket and check the catch-all bucket is inserted",
-			latencyHistogramBuckets:         []time.Duration{2 * time.Millisecond},
-			expectedLatencyHistogramBuckets: []float64{2},
-		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			// Prepare
-			factory := NewFactory()
-
-			creationParams := processortest.NewNopCreateSettings()
-			cfg := factory.CreateDefaultConfig().(*Config)
-			cfg.LatencyHistogramBuckets = tc.latencyHistogramBuckets
 
-			// Test
-			traceProcessor, err := factory.CreateTracesProcessor(context.Background(), creationParams, cfg, consumertest.NewNop())
-			smp := traceProcessor.(*serviceGraphProcessor)
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector"
+)
 
-			// Verify
-			assert.NoError(t, err)
-			assert.NotNil(t, smp)
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
 
-			assert.Equal(t, tc.expectedLatencyHistogramBuckets, smp.reqDurationBounds)
-		})
-	}
+	// The processor shares the defaults of the connector.
+	assert.Equal(t, servicegraphconnector.NewFactory().CreateDefaultConfig(), &cfg.Config)
+	assert.Empty(t, cfg.MetricsExporter)
 }
 
-func TestNewConnector(t *testing.T) {
-	for _, tc := range []struct {
-		name                            string
-		latencyHistogramBuckets         []time.Duration
-		expectedLatencyHistogramBuckets []float64
-	}{
-		{
-			name:                            "simplest config (use defaults)",
-			expectedLatencyHistogramBuckets: defaultLatencyHistogramBucketsMs,
-		},
-		{
-			name:                            "latency histogram configured with catch-all bucket to check no additional catch-all bucket inserted",
-			latencyHistogramBuckets:         []time.Duration{2 * time.Millisecond},
-			expectedLatencyHistogramBuckets: []float64{2},
-		},
-		{
-			name:                            "full config with no catch-all bucket and check the catch-all bucket is inserted",
-			latencyHistogramBuckets:         []time.Duration{2 * time.Millisecond},
-			expectedLatencyHistogramBuckets: []float64{2},
-		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			// Prepare
-			factory := NewConnectorFactory()
-
-			creationParams := connectortest.NewNopCreateSettings()
-			cfg := factory.CreateDefaultConfig().(*Config)
-			cfg.LatencyHistogramBuckets = tc.latencyHistogramBuckets
+func TestNewProcessor(t *testing.T) {
+	// Prepare
+	factory := NewFactory()
 
-			// Test
-			conn, err := factory.CreateTracesToMetrics(context.Background(), creationParams, cfg, consumertest.NewNop())
-			smc := conn.(*serviceGraphProcessor)
+	creationParams := processortest.NewNopCreateSettings()
+	cfg := factory.CreateDefaultConfig().(*Config)
 
-			// Verify
-			assert.NoError(t, err)
-			assert.NotNil(t, smc)
+	// Test
+	traceProcessor, err := factory.CreateTracesProcessor(context.Background(), creationParams, cfg, consumertest.NewNop())
 
-			assert.Equal(t, tc.expectedLatencyHistogramBuckets, smc.reqDurationBounds)
-		})
-	}
+	// Verify
+	require.NoError(t, err)
+	smp := traceProcessor.(*serviceGraphProcessor)
+	assert.NotNil(t, smp.connector)
 }
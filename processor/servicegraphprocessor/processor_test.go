@@ -6,6 +6,7 @@ package servicegraphprocessor
 import (
 	"context"
 	"crypto/rand"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,7 +15,6 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
-	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/exporter"
@@ -26,9 +26,11 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processortest"
 	semconv "go.opentelemetry.io/collector/semconv/v1.13.0"
-	"go.uber.org/zap/zaptest"
 )
 
+// virtualNodeFeatureGateID is the gate registered by the servicegraph connector.
+const virtualNodeFeatureGateID = "processor.servicegraph.virtualNode"
+
 func TestProcessorStart(t *testing.T) {
 	// Create otlp exporters.
 	otlpID, mexp, texp := newOTLPExporters(t)
@@ -40,8 +42,8 @@ func TestProcessorStart(t *testing.T) {
 		wantErrorMsg    string
 	}{
 		{"export to active otlp metrics exporter", mexp, "otlp", ""},
-		{"unable to find configured exporter in active exporter list", mexp, "prometheus", "failed to find metrics exporter: 'prometheus'; please configure metrics_exporter from one of: [otlp]"},
-		{"export to active otlp traces exporter should error", texp, "otlp", "the exporter \"otlp\" isn't a metrics exporter"},
+		{"unable to find configured exporter in active exporter list", mexp, "prometheus", "failed to find metrics exporter: prometheus"},
+		{"export to active otlp traces exporter should error", texp, "otlp", "failed to find metrics exporter: otlp"},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			// Prepare
@@ -61,260 +63,115 @@ func TestProcessorStart(t *testing.T) {
 			require.NoError(t, err)
 
 			// Test
-			smp := traceProcessor.(*serviceGraphProcessor)
-			err = smp.Start(context.Background(), newMockHost(exporters))
+			err = traceProcessor.Start(context.Background(), newMockHost(exporters))
 
 			// Verify
 			if tc.wantErrorMsg != "" {
-				assert.Error(t, err)
+				assert.EqualError(t, err, tc.wantErrorMsg)
 			} else {
 				assert.NoError(t, err)
+				assert.NoError(t, traceProcessor.Shutdown(context.Background()))
 			}
 		})
 	}
 }
 
-func TestConnectorStart(t *testing.T) {
-	// Create servicegraph processor
-	factory := NewConnectorFactory()
-	cfg := factory.CreateDefaultConfig().(*Config)
-
-	procCreationParams := connectortest.NewNopCreateSettings()
-	traceProcessor, err := factory.CreateTracesToMetrics(context.Background(), procCreationParams, cfg, consumertest.NewNop())
-	require.NoError(t, err)
-
-	// Test
-	smp := traceProcessor.(*serviceGraphProcessor)
-	err = smp.Start(context.Background(), componenttest.NewNopHost())
-
-	// Verify
-	assert.NoError(t, err)
-}
-
 func TestProcessorShutdown(t *testing.T) {
 	// Prepare
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
 
 	// Test
-	next := new(consumertest.TracesSink)
-	p := newProcessor(zaptest.NewLogger(t), cfg)
-	p.tracesConsumer = next
-	err := p.Shutdown(context.Background())
+	traceProcessor, err := factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	err = traceProcessor.Shutdown(context.Background())
 
 	// Verify
 	assert.NoError(t, err)
 }
 
-func TestConnectorShutdown(t *testing.T) {
+func TestProcessorConsume(t *testing.T) {
 	// Prepare
-	factory := NewConnectorFactory()
+	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.MetricsExporter = "mock"
+	cfg.Dimensions = []string{"some-attribute", "non-existing-attribute"}
 
-	// Test
-	next := new(consumertest.MetricsSink)
-	p := newProcessor(zaptest.NewLogger(t), cfg)
-	p.metricsConsumer = next
-	err := p.Shutdown(context.Background())
-
-	// Verify
-	assert.NoError(t, err)
-}
-
-func TestProcessorConsume(t *testing.T) {
-	// set virtual node feature
-	_ = featuregate.GlobalRegistry().Set(virtualNodeFeatureGate.ID(), true)
+	next := new(consumertest.TracesSink)
+	traceProcessor, err := factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, next)
+	require.NoError(t, err)
 
-	for _, tc := range []struct {
-		name          string
-		cfg           Config
-		sampleTraces  ptrace.Traces
-		verifyMetrics func(t *testing.T, md pmetric.Metrics)
-	}{
-		{
-			name: "traces with client and server span",
-			cfg: Config{
-				MetricsExporter: "mock",
-				Dimensions:      []string{"some-attribute", "non-existing-attribute"},
-				Store: StoreConfig{
-					MaxItems: 10,
-					TTL:      time.Nanosecond,
-				},
-			}, sampleTraces: buildSampleTrace(t, "val"),
-			verifyMetrics: verifyHappyCaseMetrics,
-		},
-		{
-			name: "incomplete traces with virtual server span",
-			cfg: Config{
-				MetricsExporter: "mock",
-				Dimensions:      []string{"some-attribute", "non-existing-attribute"},
-				Store: StoreConfig{
-					MaxItems: 10,
-					TTL:      time.Nanosecond,
-				},
-			},
-			sampleTraces: incompleteClientTraces(),
-			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
-				v, ok := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes().Get("server")
-				assert.True(t, ok)
-				assert.Equal(t, "127.10.10.1", v.Str())
-			},
-		},
-		{
-			name: "incomplete traces with virtual client span",
-			cfg: Config{
-				MetricsExporter: "mock",
-				Dimensions:      []string{"some-attribute", "non-existing-attribute"},
-				Store: StoreConfig{
-					MaxItems: 10,
-					TTL:      time.Nanosecond,
-				},
-			},
-			sampleTraces: incompleteServerTraces(false),
-			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
-				v, ok := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes().Get("client")
-				assert.True(t, ok)
-				assert.Equal(t, "user", v.Str())
-			},
-		},
-		{
-			name: "incomplete traces with client span lost",
-			cfg: Config{
-				MetricsExporter: "mock",
-				Dimensions:      []string{"some-attribute", "non-existing-attribute"},
-				Store: StoreConfig{
-					MaxItems: 10,
-					TTL:      time.Nanosecond,
-				},
-			},
-			sampleTraces: incompleteServerTraces(true),
-			verifyMetrics: func(t *testing.T, md pmetric.Metrics) {
-				assert.Equal(t, 0, md.MetricCount())
-			},
+	metricsExporter := newMockMetricsExporter()
+	require.NoError(t, traceProcessor.Start(context.Background(), newMockHost(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeMetrics: {
+			component.NewID("mock"): metricsExporter,
 		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			// Prepare
-			p := newProcessor(zaptest.NewLogger(t), &tc.cfg)
-			p.tracesConsumer = consumertest.NewNop()
-
-			metricsExporter := newMockMetricsExporter()
-
-			mHost := newMockHost(map[component.DataType]map[component.ID]component.Component{
-				component.DataTypeMetrics: {
-					component.NewID("mock"): metricsExporter,
-				},
-			})
-
-			// Start processor
-			assert.NoError(t, p.Start(context.Background(), mHost))
-
-			// Test & verify
-			// The assertion is part of verifyHappyCaseMetrics func.
-			assert.NoError(t, p.ConsumeTraces(context.Background(), tc.sampleTraces))
-			time.Sleep(time.Second * 2)
+	})))
 
-			// Force collection
-			p.store.Expire()
-			md, err := p.buildMetrics()
-			assert.NoError(t, err)
-			tc.verifyMetrics(t, md)
-
-			// Shutdown the processor
-			assert.NoError(t, p.Shutdown(context.Background()))
-		})
-	}
-
-	// unset virtual node feature
-	_ = featuregate.GlobalRegistry().Set(virtualNodeFeatureGate.ID(), false)
-}
-
-func TestConnectorConsume(t *testing.T) {
-	// Prepare
-	cfg := &Config{
-		Dimensions: []string{"some-attribute", "non-existing-attribute"},
-		Store:      StoreConfig{MaxItems: 10},
-	}
-
-	conn := newProcessor(zaptest.NewLogger(t), cfg)
-	conn.metricsConsumer = newMockMetricsExporter()
-
-	assert.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
-
-	// Test & verify
+	// Test
 	td := buildSampleTrace(t, "val")
-	// The assertion is part of verifyHappyCaseMetrics func.
-	assert.NoError(t, conn.ConsumeTraces(context.Background(), td))
-
-	// Force collection
-	conn.store.Expire()
-	md, err := conn.buildMetrics()
-	assert.NoError(t, err)
-	verifyHappyCaseMetrics(t, md)
+	require.NoError(t, traceProcessor.ConsumeTraces(context.Background(), td))
 
-	// Shutdown the conn
-	assert.NoError(t, conn.Shutdown(context.Background()))
-}
-
-func verifyHappyCaseMetrics(t *testing.T, md pmetric.Metrics) {
-	assert.Equal(t, 2, md.MetricCount())
-
-	rms := md.ResourceMetrics()
-	assert.Equal(t, 1, rms.Len())
-
-	sms := rms.At(0).ScopeMetrics()
-	assert.Equal(t, 1, sms.Len())
-
-	ms := sms.At(0).Metrics()
-	assert.Equal(t, 2, ms.Len())
-
-	mCount := ms.At(0)
-	verifyCount(t, mCount)
+	// Verify
+	assert.Equal(t, []ptrace.Traces{td}, next.AllTraces())
+	md := metricsExporter.last()
+	require.Equal(t, 3, md.MetricCount())
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	assert.Equal(t, "traces_service_graph_request_total", ms.At(0).Name())
+	dp := ms.At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(1), dp.IntValue())
+	verifyAttr(t, dp.Attributes(), "client", "some-service")
+	verifyAttr(t, dp.Attributes(), "server", "some-service")
+	verifyAttr(t, dp.Attributes(), "client_some-attribute", "val")
+	assert.Equal(t, "traces_service_graph_request_server_seconds", ms.At(1).Name())
+	assert.Equal(t, "traces_service_graph_request_client_seconds", ms.At(2).Name())
 
-	mDuration := ms.At(1)
-	verifyDuration(t, mDuration)
+	// Shutdown the processor
+	assert.NoError(t, traceProcessor.Shutdown(context.Background()))
 }
 
-func verifyCount(t *testing.T, m pmetric.Metric) {
-	assert.Equal(t, "traces_service_graph_request_total", m.Name())
+func TestProcessorConsumeVirtualNode(t *testing.T) {
+	// set virtual node feature
+	require.NoError(t, featuregate.GlobalRegistry().Set(virtualNodeFeatureGateID, true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(virtualNodeFeatureGateID, false))
+	}()
 
-	assert.Equal(t, pmetric.MetricTypeSum, m.Type())
-	dps := m.Sum().DataPoints()
-	assert.Equal(t, 1, dps.Len())
+	// Prepare
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.MetricsExporter = "mock"
+	cfg.Store = StoreConfig{MaxItems: 10, TTL: time.Nanosecond}
+	cfg.StoreExpirationLoop = 10 * time.Millisecond
 
-	dp := dps.At(0)
-	assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
-	assert.Equal(t, int64(1), dp.IntValue())
+	traceProcessor, err := factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
 
-	attributes := dp.Attributes()
-	assert.Equal(t, 5, attributes.Len())
-	verifyAttr(t, attributes, "client", "some-service")
-	verifyAttr(t, attributes, "server", "some-service")
-	verifyAttr(t, attributes, "connection_type", "")
-	verifyAttr(t, attributes, "failed", "false")
-	verifyAttr(t, attributes, "client_some-attribute", "val")
-}
+	metricsExporter := newMockMetricsExporter()
+	require.NoError(t, traceProcessor.Start(context.Background(), newMockHost(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeMetrics: {
+			component.NewID("mock"): metricsExporter,
+		},
+	})))
+	defer func() {
+		assert.NoError(t, traceProcessor.Shutdown(context.Background()))
+	}()
 
-func verifyDuration(t *testing.T, m pmetric.Metric) {
-	assert.Equal(t, "traces_service_graph_request_duration_seconds", m.Name())
-
-	assert.Equal(t, pmetric.MetricTypeHistogram, m.Type())
-	dps := m.Histogram().DataPoints()
-	assert.Equal(t, 1, dps.Len())
-
-	dp := dps.At(0)
-	assert.Equal(t, float64(1000), dp.Sum()) // Duration: 1sec
-	assert.Equal(t, uint64(1), dp.Count())
-	buckets := pcommon.NewUInt64Slice()
-	buckets.FromRaw([]uint64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0})
-	assert.Equal(t, buckets, dp.BucketCounts())
-
-	attributes := dp.Attributes()
-	assert.Equal(t, 5, attributes.Len())
-	verifyAttr(t, attributes, "client", "some-service")
-	verifyAttr(t, attributes, "server", "some-service")
-	verifyAttr(t, attributes, "connection_type", "")
-	verifyAttr(t, attributes, "client_some-attribute", "val")
+	// Test
+	require.NoError(t, traceProcessor.ConsumeTraces(context.Background(), incompleteClientTraces()))
+
+	// Verify: once the edge expired, the metrics sent with the next traces have a virtual server.
+	assert.Eventually(t, func() bool {
+		if err := traceProcessor.ConsumeTraces(context.Background(), ptrace.NewTraces()); err != nil {
+			return false
+		}
+		md := metricsExporter.last()
+		if md.MetricCount() == 0 {
+			return false
+		}
+		v, ok := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes().Get("server")
+		return ok && v.Str() == "127.10.10.1"
+	}, 5*time.Second, 10*time.Millisecond)
 }
 
 func verifyAttr(t *testing.T, attrs pcommon.Map, k, expected string) {
@@ -389,29 +246,6 @@ func incompleteClientTraces() ptrace.Traces {
 	return traces
 }
 
-func incompleteServerTraces(withParentSpan bool) ptrace.Traces {
-	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
-	tEnd := time.Date(2022, 1, 2, 3, 4, 6, 6, time.UTC)
-
-	traces := ptrace.NewTraces()
-
-	resourceSpans := traces.ResourceSpans().AppendEmpty()
-	resourceSpans.Resource().Attributes().PutStr(semconv.AttributeServiceName, "some-server-service")
-	scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
-	anotherTraceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
-	serverSpanNoClientSpan := scopeSpans.Spans().AppendEmpty()
-	serverSpanNoClientSpan.SetName("server span")
-	serverSpanNoClientSpan.SetSpanID([8]byte{0x19, 0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26})
-	if withParentSpan {
-		serverSpanNoClientSpan.SetParentSpanID([8]byte{0x27, 0x28, 0x29, 0x30, 0x31, 0x32, 0x33, 0x34})
-	}
-	serverSpanNoClientSpan.SetTraceID(anotherTraceID)
-	serverSpanNoClientSpan.SetKind(ptrace.SpanKindServer)
-	serverSpanNoClientSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
-	serverSpanNoClientSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(tEnd))
-	return traces
-}
-
 func newOTLPExporters(t *testing.T) (component.ID, exporter.Metrics, exporter.Traces) {
 	otlpExpFactory := otlpexporter.NewFactory()
 	otlpID := component.NewID("otlp")
@@ -446,11 +280,15 @@ func (m *mockHost) GetExporters() map[component.DataType]map[component.ID]compon
 
 var _ exporter.Metrics = (*mockMetricsExporter)(nil)
 
-func newMockMetricsExporter() exporter.Metrics {
+func newMockMetricsExporter() *mockMetricsExporter {
 	return &mockMetricsExporter{}
 }
 
-type mockMetricsExporter struct{}
+// mockMetricsExporter keeps the last metrics it received.
+type mockMetricsExporter struct {
+	mu      sync.Mutex
+	metrics pmetric.Metrics
+}
 
 func (m *mockMetricsExporter) Start(context.Context, component.Host) error { return nil }
 
@@ -458,90 +296,18 @@ func (m *mockMetricsExporter) Shutdown(context.Context) error { return nil }
 
 func (m *mockMetricsExporter) Capabilities() consumer.Capabilities { return consumer.Capabilities{} }
 
-func (m *mockMetricsExporter) ConsumeMetrics(context.Context, pmetric.Metrics) error {
+func (m *mockMetricsExporter) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics = md
 	return nil
 }
 
-func TestUpdateDurationMetrics(t *testing.T) {
-	p := serviceGraphProcessor{
-		reqTotal:                       make(map[string]int64),
-		reqFailedTotal:                 make(map[string]int64),
-		reqDurationSecondsSum:          make(map[string]float64),
-		reqDurationSecondsCount:        make(map[string]uint64),
-		reqDurationBounds:              defaultLatencyHistogramBucketsMs,
-		reqDurationSecondsBucketCounts: make(map[string][]uint64),
-		keyToMetric:                    make(map[string]metricSeries),
-		config: &Config{
-			Dimensions: []string{},
-		},
+func (m *mockMetricsExporter) last() pmetric.Metrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.metrics == (pmetric.Metrics{}) {
+		return pmetric.NewMetrics()
 	}
-	metricKey := p.buildMetricKey("foo", "bar", "", map[string]string{})
-
-	testCases := []struct {
-		caseStr  string
-		duration float64
-	}{
-
-		{
-			caseStr:  "index 0 latency",
-			duration: 0,
-		},
-		{
-			caseStr:  "out-of-range latency 1",
-			duration: 25_000,
-		},
-		{
-			caseStr:  "out-of-range latency 2",
-			duration: 125_000,
-		},
-	}
-	for _, tc := range testCases {
-		t.Run(tc.caseStr, func(t *testing.T) {
-			p.updateDurationMetrics(metricKey, tc.duration)
-		})
-	}
-}
-
-func TestStaleSeriesCleanup(t *testing.T) {
-	// Prepare
-	cfg := &Config{
-		MetricsExporter: "mock",
-		Dimensions:      []string{"some-attribute", "non-existing-attribute"},
-		Store: StoreConfig{
-			MaxItems: 10,
-			TTL:      time.Second,
-		},
-	}
-
-	mockMetricsExporter := newMockMetricsExporter()
-
-	p := newProcessor(zaptest.NewLogger(t), cfg)
-	p.tracesConsumer = consumertest.NewNop()
-
-	mHost := newMockHost(map[component.DataType]map[component.ID]component.Component{
-		component.DataTypeMetrics: {
-			component.NewID("mock"): mockMetricsExporter,
-		},
-	})
-
-	assert.NoError(t, p.Start(context.Background(), mHost))
-
-	// ConsumeTraces
-	td := buildSampleTrace(t, "first")
-	assert.NoError(t, p.ConsumeTraces(context.Background(), td))
-
-	// Make series stale and force a cache cleanup
-	for key, metric := range p.keyToMetric {
-		metric.lastUpdated = 0
-		p.keyToMetric[key] = metric
-	}
-	p.cleanCache()
-	assert.Equal(t, 0, len(p.keyToMetric))
-
-	// ConsumeTraces with a trace with different attribute value
-	td = buildSampleTrace(t, "second")
-	assert.NoError(t, p.ConsumeTraces(context.Background(), td))
-
-	// Shutdown the processor
-	assert.NoError(t, p.Shutdown(context.Background()))
+	return m.metrics
 }
@@ -4,8 +4,11 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
 	"time"
 
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -212,6 +215,79 @@ type OTTLConditionCfg struct {
 	SpanEventConditions []string       `mapstructure:"spanevent"`
 }
 
+// DecisionCacheCfg holds the configurable settings of the caches remembering the sampling
+// decisions of the traces once they are removed from memory, so that the spans arriving
+// late for those traces get the original decision instead of being handled as a new trace.
+type DecisionCacheCfg struct {
+	// SampledCacheSize is the maximum number of sampled trace IDs to remember.
+	// The cache is disabled when zero.
+	SampledCacheSize int `mapstructure:"sampled_cache_size"`
+	// NonSampledCacheSize is the maximum number of trace IDs not sampled to remember.
+	// The cache is disabled when zero.
+	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
+}
+
+// DistributedCfg holds the configurable settings to run the processor on a cluster of
+// collectors, each of them forwarding the spans of the traces it doesn't own to the
+// peer owning them, so that all the spans of a trace are sampled by the same collector.
+// The distributed mode is enabled when a resolver is set.
+type DistributedCfg struct {
+	// Self is the endpoint of this collector, as returned by the resolver of its peers.
+	Self string `mapstructure:"self"`
+	// Resolver sets how the peers are discovered, this collector included.
+	Resolver PeerResolverCfg `mapstructure:"resolver"`
+	// OTLP sets the OTLP exporter forwarding the spans to the peers, the endpoint is ignored.
+	OTLP otlpexporter.Config `mapstructure:"otlp"`
+}
+
+// PeerResolverCfg holds the configurable settings of the resolver of the peers,
+// only one of them should be set.
+type PeerResolverCfg struct {
+	Static *StaticPeerResolverCfg `mapstructure:"static"`
+	DNS    *DNSPeerResolverCfg    `mapstructure:"dns"`
+}
+
+// StaticPeerResolverCfg holds the configurable settings of the resolver providing a fixed list of peers.
+type StaticPeerResolverCfg struct {
+	Hostnames []string `mapstructure:"hostnames"`
+}
+
+// DNSPeerResolverCfg holds the configurable settings of the resolver
+// periodically resolving the IP addresses of the peers.
+type DNSPeerResolverCfg struct {
+	Hostname string        `mapstructure:"hostname"`
+	Port     string        `mapstructure:"port"`
+	Interval time.Duration `mapstructure:"interval"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+var (
+	errMissingSelf                = errors.New("'self' must be set when a resolver is set for the distributed mode")
+	errMultiplePeerResolvers      = errors.New("only one resolver should be set for the distributed mode")
+	errDistributedWithoutResolver = errors.New("'self' is set but no resolver is set for the distributed mode")
+)
+
+func (cfg *DistributedCfg) enabled() bool {
+	return cfg.Resolver.Static != nil || cfg.Resolver.DNS != nil
+}
+
+// Validate checks if the distributed mode configuration is valid.
+func (cfg *DistributedCfg) Validate() error {
+	if cfg.Resolver.Static != nil && cfg.Resolver.DNS != nil {
+		return errMultiplePeerResolvers
+	}
+	if !cfg.enabled() {
+		if cfg.Self != "" {
+			return errDistributedWithoutResolver
+		}
+		return nil
+	}
+	if cfg.Self == "" {
+		return errMissingSelf
+	}
+	return nil
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	// DecisionWait is the desired wait time from the arrival of the first span of
@@ -226,4 +302,10 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// DecisionCache sets the caches remembering the sampling decisions of the
+	// traces once they are removed from memory.
+	DecisionCache DecisionCacheCfg `mapstructure:"decision_cache"`
+	// Distributed sets the distributed mode, forwarding the spans to the
+	// collector of the cluster owning their trace.
+	Distributed DistributedCfg `mapstructure:"distributed"`
 }
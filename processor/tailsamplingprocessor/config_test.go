@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
@@ -24,6 +25,7 @@ func TestLoadConfig(t *testing.T) {
 
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
+	otlpDefaultCfg := otlpexporter.NewFactory().CreateDefaultConfig().(*otlpexporter.Config)
 
 	sub, err := cm.Sub(component.NewIDWithName(typeStr, "").String())
 	require.NoError(t, err)
@@ -35,6 +37,13 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
+			DecisionCache: DecisionCacheCfg{
+				SampledCacheSize:    1000,
+				NonSampledCacheSize: 5000,
+			},
+			Distributed: DistributedCfg{
+				OTLP: *otlpDefaultCfg,
+			},
 			PolicyCfgs: []PolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
@@ -185,3 +194,95 @@ func TestLoadConfig(t *testing.T) {
 			},
 		})
 }
+
+func TestLoadDistributedConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "tail_sampling_distributed_config.yaml"))
+	require.NoError(t, err)
+
+	otlpDefaultCfg := otlpexporter.NewFactory().CreateDefaultConfig().(*otlpexporter.Config)
+	otlpCfg := *otlpDefaultCfg
+	otlpCfg.TLSSetting.Insecure = true
+
+	tests := []struct {
+		id       component.ID
+		expected DistributedCfg
+	}{
+		{
+			id: component.NewIDWithName(typeStr, "static"),
+			expected: DistributedCfg{
+				Self: "collector-1:4317",
+				Resolver: PeerResolverCfg{
+					Static: &StaticPeerResolverCfg{
+						Hostnames: []string{"collector-1:4317", "collector-2:4317", "collector-3:4317"},
+					},
+				},
+				OTLP: otlpCfg,
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "dns"),
+			expected: DistributedCfg{
+				Self: "10.0.0.1:4317",
+				Resolver: PeerResolverCfg{
+					DNS: &DNSPeerResolverCfg{
+						Hostname: "collectors.observability.svc.cluster.local",
+						Port:     "4317",
+						Interval: 10 * time.Second,
+					},
+				},
+				OTLP: *otlpDefaultCfg,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg.(*Config).Distributed)
+		})
+	}
+}
+
+func TestValidateDistributedConfig(t *testing.T) {
+	static := &StaticPeerResolverCfg{Hostnames: []string{"collector-1"}}
+	dns := &DNSPeerResolverCfg{Hostname: "collectors"}
+	tests := []struct {
+		name        string
+		cfg         DistributedCfg
+		expectedErr error
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "static",
+			cfg:  DistributedCfg{Self: "collector-1", Resolver: PeerResolverCfg{Static: static}},
+		},
+		{
+			name:        "missing self",
+			cfg:         DistributedCfg{Resolver: PeerResolverCfg{DNS: dns}},
+			expectedErr: errMissingSelf,
+		},
+		{
+			name:        "missing resolver",
+			cfg:         DistributedCfg{Self: "collector-1"},
+			expectedErr: errDistributedWithoutResolver,
+		},
+		{
+			name:        "multiple resolvers",
+			cfg:         DistributedCfg{Self: "collector-1", Resolver: PeerResolverCfg{Static: static, DNS: dns}},
+			expectedErr: errMultiplePeerResolvers,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErr, tt.cfg.Validate())
+		})
+	}
+}
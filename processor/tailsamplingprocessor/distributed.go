@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
+)
+
+const (
+	// forwardedHeader is the header set on the spans forwarded to a peer,
+	// so that the peer samples them instead of forwarding them again.
+	forwardedHeader = "x-tail-sampling-forwarded"
+	defaultPeerPort = "4317"
+	// peersChangeTimeout bounds the creation, start and shutdown of the
+	// exporters of the peers when the list of peers changes.
+	peersChangeTimeout = 30 * time.Second
+)
+
+var errNoPeerExporter = errors.New("no exporter for the peer")
+
+// peerExporterFactory creates the exporter forwarding the spans to the given peer.
+type peerExporterFactory func(ctx context.Context, endpoint string) (exporter.Traces, error)
+
+// distributedProcessor assigns every trace to one of the collectors of the cluster,
+// forwards the spans of the traces owned by its peers to them, and samples the traces
+// it owns with the local processor.
+type distributedProcessor struct {
+	ctx         context.Context
+	logger      *zap.Logger
+	self        string
+	resolver    loadbalancing.Resolver
+	newExporter peerExporterFactory
+	local       processor.Traces
+	host        component.Host
+
+	updateLock sync.RWMutex
+	ring       *loadbalancing.HashRing
+	exporters  map[string]exporter.Traces
+}
+
+var _ processor.Traces = (*distributedProcessor)(nil)
+
+func newDistributedProcessor(ctx context.Context, params processor.CreateSettings, cfg DistributedCfg) (*distributedProcessor, error) {
+	resolver, err := newPeerResolver(params.Logger, cfg.Resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	exporterFactory := otlpexporter.NewFactory()
+	exporterSettings := exporter.CreateSettings{
+		ID:                params.ID,
+		TelemetrySettings: params.TelemetrySettings,
+		BuildInfo:         params.BuildInfo,
+	}
+	return &distributedProcessor{
+		ctx:      ctx,
+		logger:   params.Logger,
+		self:     endpointWithPort(cfg.Self),
+		resolver: resolver,
+		newExporter: func(ctx context.Context, endpoint string) (exporter.Traces, error) {
+			return exporterFactory.CreateTracesExporter(ctx, exporterSettings, buildPeerExporterConfig(cfg.OTLP, endpoint))
+		},
+		exporters: map[string]exporter.Traces{},
+	}, nil
+}
+
+func newPeerResolver(logger *zap.Logger, cfg PeerResolverCfg) (loadbalancing.Resolver, error) {
+	switch {
+	case cfg.Static != nil && cfg.DNS != nil:
+		return nil, errMultiplePeerResolvers
+	case cfg.Static != nil:
+		return loadbalancing.NewStaticResolver(cfg.Static.Hostnames)
+	case cfg.DNS != nil:
+		return loadbalancing.NewDNSResolver(logger.With(zap.String("resolver", "dns")), cfg.DNS.Hostname, cfg.DNS.Port, cfg.DNS.Interval, cfg.DNS.Timeout)
+	default:
+		return nil, errDistributedWithoutResolver
+	}
+}
+
+// buildPeerExporterConfig returns the configuration of the exporter of a peer,
+// marking the spans it sends as forwarded.
+func buildPeerExporterConfig(cfg otlpexporter.Config, endpoint string) *otlpexporter.Config {
+	cfg.Endpoint = endpoint
+	headers := make(map[string]configopaque.String, len(cfg.Headers)+1)
+	for k, v := range cfg.Headers {
+		headers[k] = v
+	}
+	headers[forwardedHeader] = "true"
+	cfg.Headers = headers
+	return &cfg
+}
+
+func endpointWithPort(endpoint string) string {
+	if _, _, err := net.SplitHostPort(endpoint); err != nil {
+		// IPv6 addresses are already bracketed, as returned by the DNS resolver
+		return net.JoinHostPort(strings.Trim(endpoint, "[]"), defaultPeerPort)
+	}
+	return endpoint
+}
+
+func (dp *distributedProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// Start is invoked during service startup.
+func (dp *distributedProcessor) Start(ctx context.Context, host component.Host) error {
+	dp.host = host
+	if err := dp.local.Start(ctx, host); err != nil {
+		return err
+	}
+	dp.resolver.OnChange(dp.onPeersChange)
+	return dp.resolver.Start(ctx)
+}
+
+// Shutdown is invoked during service shutdown.
+func (dp *distributedProcessor) Shutdown(ctx context.Context) error {
+	errs := dp.resolver.Shutdown(ctx)
+
+	dp.updateLock.Lock()
+	for endpoint, exp := range dp.exporters {
+		errs = multierr.Append(errs, exp.Shutdown(ctx))
+		delete(dp.exporters, endpoint)
+	}
+	dp.updateLock.Unlock()
+
+	return multierr.Append(errs, dp.local.Shutdown(ctx))
+}
+
+func (dp *distributedProcessor) onPeersChange(resolved []string) {
+	endpoints := make([]string, 0, len(resolved))
+	for _, endpoint := range resolved {
+		endpoints = append(endpoints, endpointWithPort(endpoint))
+	}
+	newRing := loadbalancing.NewHashRing(endpoints)
+
+	dp.updateLock.Lock()
+	defer dp.updateLock.Unlock()
+	if newRing.Equal(dp.ring) {
+		return
+	}
+	dp.ring = newRing
+	dp.logger.Info("peers changed", zap.Strings("peers", endpoints))
+
+	ctx, cancel := context.WithTimeout(context.Background(), peersChangeTimeout)
+	defer cancel()
+	for _, endpoint := range endpoints {
+		if _, exists := dp.exporters[endpoint]; exists || endpoint == dp.self {
+			continue
+		}
+		exp, err := dp.newExporter(ctx, endpoint)
+		if err != nil {
+			dp.logger.Error("failed to create new exporter for peer", zap.String("endpoint", endpoint), zap.Error(err))
+			continue
+		}
+		if err = exp.Start(ctx, dp.host); err != nil {
+			dp.logger.Error("failed to start new exporter for peer", zap.String("endpoint", endpoint), zap.Error(err))
+			continue
+		}
+		dp.exporters[endpoint] = exp
+	}
+	for existing, exp := range dp.exporters {
+		if !endpointFound(existing, endpoints) {
+			_ = exp.Shutdown(ctx)
+			delete(dp.exporters, existing)
+		}
+	}
+}
+
+func endpointFound(endpoint string, endpoints []string) bool {
+	for _, candidate := range endpoints {
+		if candidate == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// ConsumeTraces is required by the processor.Traces interface.
+func (dp *distributedProcessor) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if len(client.FromContext(ctx).Metadata.Get(forwardedHeader)) > 0 {
+		// the spans were forwarded by a peer, this collector owns them
+		return dp.local.ConsumeTraces(ctx, td)
+	}
+
+	local := ptrace.NewTraces()
+	byPeer := map[string]ptrace.Traces{}
+	dp.updateLock.RLock()
+	for _, trace := range batchpersignal.SplitTraces(td) {
+		traceID := trace.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID()
+		peer := dp.ring.EndpointFor(traceID[:])
+		if peer == "" || peer == dp.self {
+			trace.ResourceSpans().MoveAndAppendTo(local.ResourceSpans())
+			continue
+		}
+		forwarded, ok := byPeer[peer]
+		if !ok {
+			forwarded = ptrace.NewTraces()
+			byPeer[peer] = forwarded
+		}
+		trace.ResourceSpans().MoveAndAppendTo(forwarded.ResourceSpans())
+	}
+	exporters := make(map[string]exporter.Traces, len(byPeer))
+	for peer := range byPeer {
+		exporters[peer] = dp.exporters[peer]
+	}
+	dp.updateLock.RUnlock()
+
+	for peer, forwarded := range byPeer {
+		spanCount := forwarded.SpanCount()
+		if err := dp.forward(ctx, exporters[peer], forwarded); err != nil {
+			// sampling the spans here breaks their trace, but doesn't lose them
+			dp.logger.Warn("failed to forward spans to their peer, sampling them locally",
+				zap.String("endpoint", peer), zap.Error(err))
+			stats.Record(dp.ctx, statForwardingErrorCount.M(int64(spanCount)))
+			forwarded.ResourceSpans().MoveAndAppendTo(local.ResourceSpans())
+			continue
+		}
+		stats.Record(dp.ctx, statSpansForwardedCount.M(int64(spanCount)))
+	}
+
+	if local.ResourceSpans().Len() == 0 {
+		return nil
+	}
+	return dp.local.ConsumeTraces(ctx, local)
+}
+
+func (dp *distributedProcessor) forward(ctx context.Context, exp exporter.Traces, td ptrace.Traces) error {
+	if exp == nil {
+		return errNoPeerExporter
+	}
+	return exp.ConsumeTraces(ctx, td)
+}
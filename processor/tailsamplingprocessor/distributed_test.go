@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
+)
+
+func newTestDistributedProcessor(t *testing.T, self string, hostnames []string, newExporter peerExporterFactory) (*distributedProcessor, *mockComponent) {
+	resolver, err := loadbalancing.NewStaticResolver(hostnames)
+	require.NoError(t, err)
+	local := &mockComponent{}
+	return &distributedProcessor{
+		ctx:         context.Background(),
+		logger:      zap.NewNop(),
+		self:        endpointWithPort(self),
+		resolver:    resolver,
+		newExporter: newExporter,
+		local:       local,
+		exporters:   map[string]exporter.Traces{},
+	}, local
+}
+
+// mockPeerExporters creates a mockComponent for every peer.
+type mockPeerExporters struct {
+	mu        sync.Mutex
+	exporters map[string]*mockComponent
+	err       error
+}
+
+func (m *mockPeerExporters) newExporter(_ context.Context, endpoint string) (exporter.Traces, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	exp := &mockComponent{err: m.err}
+	m.exporters[endpoint] = exp
+	return exp, nil
+}
+
+func tracesWithIDs(ids ...pcommon.TraceID) ptrace.Traces {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, id := range ids {
+		spans.AppendEmpty().SetTraceID(id)
+	}
+	return td
+}
+
+func traceIDsOf(sink *consumertest.TracesSink) []pcommon.TraceID {
+	var ids []pcommon.TraceID
+	for _, td := range sink.AllTraces() {
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			ilss := td.ResourceSpans().At(i).ScopeSpans()
+			for j := 0; j < ilss.Len(); j++ {
+				for k := 0; k < ilss.At(j).Spans().Len(); k++ {
+					ids = append(ids, ilss.At(j).Spans().At(k).TraceID())
+				}
+			}
+		}
+	}
+	return ids
+}
+
+func TestDistributedProcessorForwardsToOwner(t *testing.T) {
+	// prepare
+	exporters := &mockPeerExporters{exporters: map[string]*mockComponent{}}
+	dp, local := newTestDistributedProcessor(t, "collector-1", []string{"collector-1", "collector-2:4317"}, exporters.newExporter)
+	require.NoError(t, dp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, dp.Shutdown(context.Background()))
+	}()
+
+	ring := loadbalancing.NewHashRing([]string{"collector-1:4317", "collector-2:4317"})
+	var ids, ownedIDs, forwardedIDs []pcommon.TraceID
+	for i := 1; i <= 20; i++ {
+		id := pcommon.TraceID([16]byte{byte(i), byte(i * 3)})
+		ids = append(ids, id)
+		if ring.EndpointFor(id[:]) == "collector-1:4317" {
+			ownedIDs = append(ownedIDs, id)
+		} else {
+			forwardedIDs = append(forwardedIDs, id)
+		}
+	}
+	require.NotEmpty(t, ownedIDs)
+	require.NotEmpty(t, forwardedIDs)
+
+	// test
+	require.NoError(t, dp.ConsumeTraces(context.Background(), tracesWithIDs(ids...)))
+
+	// verify
+	require.Len(t, exporters.exporters, 1, "no exporter should be created for this collector")
+	peer := exporters.exporters["collector-2:4317"]
+	require.NotNil(t, peer)
+	assert.True(t, peer.started)
+	assert.ElementsMatch(t, ownedIDs, traceIDsOf(&local.TracesSink))
+	assert.ElementsMatch(t, forwardedIDs, traceIDsOf(&peer.TracesSink))
+}
+
+func TestDistributedProcessorSamplesForwardedSpans(t *testing.T) {
+	// prepare
+	exporters := &mockPeerExporters{exporters: map[string]*mockComponent{}}
+	dp, local := newTestDistributedProcessor(t, "collector-1", []string{"collector-1", "collector-2"}, exporters.newExporter)
+	require.NoError(t, dp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, dp.Shutdown(context.Background()))
+	}()
+
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{forwardedHeader: {"true"}}),
+	})
+	var ids []pcommon.TraceID
+	for i := 1; i <= 20; i++ {
+		ids = append(ids, pcommon.TraceID([16]byte{byte(i)}))
+	}
+
+	// test
+	require.NoError(t, dp.ConsumeTraces(ctx, tracesWithIDs(ids...)))
+
+	// verify
+	assert.ElementsMatch(t, ids, traceIDsOf(&local.TracesSink))
+	assert.Empty(t, exporters.exporters["collector-2:4317"].AllTraces())
+}
+
+func TestDistributedProcessorForwardingFailure(t *testing.T) {
+	// prepare
+	exporters := &mockPeerExporters{exporters: map[string]*mockComponent{}, err: errors.New("unavailable")}
+	dp, local := newTestDistributedProcessor(t, "collector-1", []string{"collector-1", "collector-2"}, exporters.newExporter)
+	require.NoError(t, dp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, dp.Shutdown(context.Background()))
+	}()
+
+	var ids []pcommon.TraceID
+	for i := 1; i <= 20; i++ {
+		ids = append(ids, pcommon.TraceID([16]byte{byte(i)}))
+	}
+
+	// test
+	require.NoError(t, dp.ConsumeTraces(context.Background(), tracesWithIDs(ids...)))
+
+	// verify
+	assert.ElementsMatch(t, ids, traceIDsOf(&local.TracesSink), "the spans that can't be forwarded are sampled locally")
+}
+
+func TestDistributedProcessorPeersChange(t *testing.T) {
+	// prepare
+	exporters := &mockPeerExporters{exporters: map[string]*mockComponent{}}
+	dp, local := newTestDistributedProcessor(t, "collector-1", []string{"collector-1", "collector-2"}, exporters.newExporter)
+	require.NoError(t, dp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, dp.Shutdown(context.Background()))
+	}()
+	peer := exporters.exporters["collector-2:4317"]
+	require.NotNil(t, peer)
+
+	// test
+	dp.onPeersChange([]string{"collector-1"})
+
+	// verify
+	assert.True(t, peer.stopped)
+	assert.Empty(t, dp.exporters)
+
+	var ids []pcommon.TraceID
+	for i := 1; i <= 20; i++ {
+		ids = append(ids, pcommon.TraceID([16]byte{byte(i)}))
+	}
+	require.NoError(t, dp.ConsumeTraces(context.Background(), tracesWithIDs(ids...)))
+	assert.ElementsMatch(t, ids, traceIDsOf(&local.TracesSink), "this collector owns all the traces")
+}
+
+func TestDistributedProcessorShutdown(t *testing.T) {
+	// prepare
+	exporters := &mockPeerExporters{exporters: map[string]*mockComponent{}}
+	dp, local := newTestDistributedProcessor(t, "collector-1", []string{"collector-1", "collector-2", "collector-3"}, exporters.newExporter)
+	require.NoError(t, dp.Start(context.Background(), componenttest.NewNopHost()))
+
+	// test
+	require.NoError(t, dp.Shutdown(context.Background()))
+
+	// verify
+	assert.Len(t, exporters.exporters, 2)
+	for _, exp := range exporters.exporters {
+		assert.True(t, exp.stopped)
+	}
+	assert.True(t, local.started)
+	assert.True(t, local.stopped)
+}
+
+func TestBuildPeerExporterConfig(t *testing.T) {
+	cfg := otlpexporter.Config{}
+	cfg.Headers = map[string]configopaque.String{"authorization": "token"}
+
+	peerCfg := buildPeerExporterConfig(cfg, "collector-2:4317")
+
+	assert.Equal(t, "collector-2:4317", peerCfg.Endpoint)
+	assert.Equal(t, map[string]configopaque.String{"authorization": "token", forwardedHeader: "true"}, peerCfg.Headers)
+	assert.Equal(t, map[string]configopaque.String{"authorization": "token"}, cfg.Headers)
+}
+
+func TestEndpointWithPort(t *testing.T) {
+	assert.Equal(t, "collector-1:4317", endpointWithPort("collector-1"))
+	assert.Equal(t, "collector-1:55690", endpointWithPort("collector-1:55690"))
+	assert.Equal(t, "10.0.0.1:4317", endpointWithPort("10.0.0.1"))
+	assert.Equal(t, "[::1]:4317", endpointWithPort("[::1]"))
+	assert.Equal(t, "[::1]:55690", endpointWithPort("[::1]:55690"))
+}
+
+// mockComponent is a traces consumer recording its lifecycle, used both
+// as the local processor and as the exporters of the peers.
+type mockComponent struct {
+	consumertest.TracesSink
+	err     error
+	started bool
+	stopped bool
+}
+
+func (m *mockComponent) Start(context.Context, component.Host) error {
+	m.started = true
+	return nil
+}
+
+func (m *mockComponent) Shutdown(context.Context) error {
+	m.stopped = true
+	return nil
+}
+
+func (m *mockComponent) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if m.err != nil {
+		return m.err
+	}
+	return m.TracesSink.ConsumeTraces(ctx, td)
+}
@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/processor"
 )
 
@@ -38,9 +39,15 @@ func NewFactory() processor.Factory {
 }
 
 func createDefaultConfig() component.Config {
+	otlpFactory := otlpexporter.NewFactory()
+	otlpDefaultCfg := otlpFactory.CreateDefaultConfig().(*otlpexporter.Config)
+
 	return &Config{
 		DecisionWait: 30 * time.Second,
 		NumTraces:    50000,
+		Distributed: DistributedCfg{
+			OTLP: *otlpDefaultCfg,
+		},
 	}
 }
 
@@ -51,5 +58,16 @@ func createTracesProcessor(
 	nextConsumer consumer.Traces,
 ) (processor.Traces, error) {
 	tCfg := cfg.(*Config)
-	return newTracesProcessor(ctx, params.TelemetrySettings, nextConsumer, *tCfg)
+	if !tCfg.Distributed.enabled() {
+		return newTracesProcessor(ctx, params.TelemetrySettings, nextConsumer, *tCfg)
+	}
+
+	dp, err := newDistributedProcessor(ctx, params, tCfg.Distributed)
+	if err != nil {
+		return nil, err
+	}
+	if dp.local, err = newTracesProcessor(ctx, params.TelemetrySettings, nextConsumer, *tCfg); err != nil {
+		return nil, err
+	}
+	return dp, nil
 }
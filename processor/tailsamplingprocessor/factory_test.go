@@ -15,6 +15,8 @@ import (
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loadbalancing"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -44,3 +46,34 @@ func TestCreateProcessor(t *testing.T) {
 	assert.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
 	assert.NoError(t, tp.Shutdown(context.Background()))
 }
+
+func TestCreateDistributedProcessor(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.PolicyCfgs = testPolicy
+	cfg.Distributed.Self = "localhost:4317"
+	cfg.Distributed.Resolver.Static = &StaticPeerResolverCfg{Hostnames: []string{"localhost:4317", "localhost:14317"}}
+	cfg.Distributed.OTLP.TLSSetting.Insecure = true
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	dp, ok := tp.(*distributedProcessor)
+	require.True(t, ok)
+
+	require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Len(t, dp.exporters, 1)
+	assert.NotNil(t, dp.exporters["localhost:14317"])
+	assert.NoError(t, tp.Shutdown(context.Background()))
+}
+
+func TestCreateDistributedProcessorWithInvalidResolver(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.PolicyCfgs = testPolicy
+	cfg.Distributed.Self = "localhost:4317"
+	cfg.Distributed.Resolver.Static = &StaticPeerResolverCfg{}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	assert.ErrorIs(t, err, loadbalancing.ErrNoEndpoints)
+	assert.Nil(t, tp)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache defines a bounded cache keyed by trace ID, used to remember
+// the sampling decisions of the traces once they are removed from memory.
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/golang/groupcache/lru"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ErrInvalidSize occurs when an invalid cache size is specified.
+var ErrInvalidSize = errors.New("invalid cache size, it must be greater than zero")
+
+// Cache holds a bounded number of values keyed by trace ID, the least
+// recently used entries being evicted first once the cache is full.
+// It is safe for concurrent use.
+type Cache[V any] interface {
+	// Get returns the value stored for the given trace ID, and whether it was found.
+	Get(id pcommon.TraceID) (V, bool)
+	// Put stores the value for the given trace ID, possibly evicting the oldest entry.
+	Put(id pcommon.TraceID, v V)
+	// Delete removes the value stored for the given trace ID, if any.
+	Delete(id pcommon.TraceID)
+}
+
+type lruCache[V any] struct {
+	mu    sync.Mutex
+	cache *lru.Cache
+}
+
+var _ Cache[bool] = (*lruCache[bool])(nil)
+
+// NewLRUCache returns a Cache holding up to size entries.
+func NewLRUCache[V any](size int) (Cache[V], error) {
+	if size <= 0 {
+		return nil, ErrInvalidSize
+	}
+	return &lruCache[V]{cache: lru.New(size)}, nil
+}
+
+func (c *lruCache[V]) Get(id pcommon.TraceID) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.cache.Get(id); ok {
+		return v.(V), true
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *lruCache[V]) Put(id pcommon.TraceID, v V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Add(id, v)
+}
+
+func (c *lruCache[V]) Delete(id pcommon.TraceID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Remove(id)
+}
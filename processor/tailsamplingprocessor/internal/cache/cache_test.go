@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestNewLRUCache(t *testing.T) {
+	_, err := NewLRUCache[bool](0)
+	assert.ErrorIs(t, err, ErrInvalidSize)
+
+	_, err = NewLRUCache[bool](-1)
+	assert.ErrorIs(t, err, ErrInvalidSize)
+
+	c, err := NewLRUCache[bool](1)
+	require.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestLRUCacheGetPutDelete(t *testing.T) {
+	c, err := NewLRUCache[string](2)
+	require.NoError(t, err)
+
+	id1 := pcommon.TraceID([16]byte{1})
+	id2 := pcommon.TraceID([16]byte{2})
+
+	_, ok := c.Get(id1)
+	assert.False(t, ok)
+
+	c.Put(id1, "first")
+	c.Put(id2, "second")
+	v, ok := c.Get(id1)
+	assert.True(t, ok)
+	assert.Equal(t, "first", v)
+
+	c.Delete(id1)
+	v, ok = c.Get(id1)
+	assert.False(t, ok)
+	assert.Equal(t, "", v)
+
+	v, ok = c.Get(id2)
+	assert.True(t, ok)
+	assert.Equal(t, "second", v)
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c, err := NewLRUCache[bool](2)
+	require.NoError(t, err)
+
+	id1 := pcommon.TraceID([16]byte{1})
+	id2 := pcommon.TraceID([16]byte{2})
+	id3 := pcommon.TraceID([16]byte{3})
+
+	c.Put(id1, true)
+	c.Put(id2, true)
+	// id1 becomes the most recently used entry
+	_, ok := c.Get(id1)
+	require.True(t, ok)
+	c.Put(id3, true)
+
+	_, ok = c.Get(id1)
+	assert.True(t, ok)
+	_, ok = c.Get(id2)
+	assert.False(t, ok)
+	_, ok = c.Get(id3)
+	assert.True(t, ok)
+}
+
+func TestLRUCacheConcurrentAccess(t *testing.T) {
+	c, err := NewLRUCache[int](10)
+	require.NoError(t, err)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := pcommon.TraceID([16]byte{byte(i)})
+			for j := 0; j < 100; j++ {
+				c.Put(id, j)
+				c.Get(id)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < 10; i++ {
+		v, ok := c.Get(pcommon.TraceID([16]byte{byte(i)}))
+		assert.True(t, ok)
+		assert.Equal(t, 99, v)
+	}
+}
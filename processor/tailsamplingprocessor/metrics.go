@@ -30,6 +30,12 @@ var (
 	statDroppedTooEarlyCount    = stats.Int64("sampling_trace_dropped_too_early", "Count of traces that needed to be dropped the configured wait time", stats.UnitDimensionless)
 	statNewTraceIDReceivedCount = stats.Int64("new_trace_id_received", "Counts the arrival of new traces", stats.UnitDimensionless)
 	statTracesOnMemoryGauge     = stats.Int64("sampling_traces_on_memory", "Tracks the number of traces current on memory", stats.UnitDimensionless)
+
+	statDecisionCacheHitCount  = stats.Int64("sampling_decision_cache_hit", "Count of the lookups of traces not in memory whose decision was found in the decision cache", stats.UnitDimensionless)
+	statDecisionCacheMissCount = stats.Int64("sampling_decision_cache_miss", "Count of the lookups of traces not in memory whose decision was not found in the decision cache", stats.UnitDimensionless)
+
+	statSpansForwardedCount  = stats.Int64("sampling_spans_forwarded", "Count of spans forwarded to the peer owning their trace", stats.UnitDimensionless)
+	statForwardingErrorCount = stats.Int64("sampling_forwarding_errors", "Count of spans that failed to be forwarded to the peer owning their trace, and were sampled locally", stats.UnitDimensionless)
 )
 
 // SamplingProcessorMetricViews return the metrics views according to given telemetry level.
@@ -105,6 +111,33 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Aggregation: view.LastValue(),
 	}
 
+	countDecisionCacheHitView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statDecisionCacheHitCount.Name()),
+		Measure:     statDecisionCacheHitCount,
+		Description: statDecisionCacheHitCount.Description(),
+		TagKeys:     []tag.Key{tagSampledKey},
+		Aggregation: view.Sum(),
+	}
+	countDecisionCacheMissView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statDecisionCacheMissCount.Name()),
+		Measure:     statDecisionCacheMissCount,
+		Description: statDecisionCacheMissCount.Description(),
+		Aggregation: view.Sum(),
+	}
+
+	countSpansForwardedView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statSpansForwardedCount.Name()),
+		Measure:     statSpansForwardedCount,
+		Description: statSpansForwardedCount.Description(),
+		Aggregation: view.Sum(),
+	}
+	countForwardingErrorView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statForwardingErrorCount.Name()),
+		Measure:     statForwardingErrorCount,
+		Description: statForwardingErrorCount.Description(),
+		Aggregation: view.Sum(),
+	}
+
 	return []*view.View{
 		decisionLatencyView,
 		overallDecisionLatencyView,
@@ -119,5 +152,11 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		countTraceDroppedTooEarlyView,
 		countTraceIDArrivalView,
 		trackTracesOnMemorylView,
+
+		countDecisionCacheHitView,
+		countDecisionCacheMissView,
+
+		countSpansForwardedView,
+		countForwardingErrorView,
 	}
 }
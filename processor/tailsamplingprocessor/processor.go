@@ -21,6 +21,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -50,6 +51,10 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+	// sampledIDCache and nonSampledIDCache remember the time of the decisions taken
+	// for the traces removed from memory, they are nil when disabled.
+	sampledIDCache    cache.Cache[time.Time]
+	nonSampledIDCache cache.Cache[time.Time]
 }
 
 const (
@@ -63,6 +68,19 @@ func newTracesProcessor(ctx context.Context, settings component.TelemetrySetting
 		return nil, component.ErrNilNextConsumer
 	}
 
+	var sampledIDCache, nonSampledIDCache cache.Cache[time.Time]
+	var err error
+	if cfg.DecisionCache.SampledCacheSize != 0 {
+		if sampledIDCache, err = cache.NewLRUCache[time.Time](cfg.DecisionCache.SampledCacheSize); err != nil {
+			return nil, fmt.Errorf("invalid sampled_cache_size: %w", err)
+		}
+	}
+	if cfg.DecisionCache.NonSampledCacheSize != 0 {
+		if nonSampledIDCache, err = cache.NewLRUCache[time.Time](cfg.DecisionCache.NonSampledCacheSize); err != nil {
+			return nil, fmt.Errorf("invalid non_sampled_cache_size: %w", err)
+		}
+	}
+
 	numDecisionBatches := uint64(cfg.DecisionWait.Seconds())
 	inBatcher, err := idbatcher.New(numDecisionBatches, cfg.ExpectedNewTracesPerSec, uint64(2*runtime.NumCPU()))
 	if err != nil {
@@ -89,14 +107,16 @@ func newTracesProcessor(ctx context.Context, settings component.TelemetrySetting
 	}
 
 	tsp := &tailSamplingSpanProcessor{
-		ctx:             ctx,
-		nextConsumer:    nextConsumer,
-		maxNumTraces:    cfg.NumTraces,
-		logger:          settings.Logger,
-		decisionBatcher: inBatcher,
-		policies:        policies,
-		tickerFrequency: time.Second,
-		numTracesOnMap:  &atomic.Uint64{},
+		ctx:               ctx,
+		nextConsumer:      nextConsumer,
+		maxNumTraces:      cfg.NumTraces,
+		logger:            settings.Logger,
+		decisionBatcher:   inBatcher,
+		policies:          policies,
+		tickerFrequency:   time.Second,
+		numTracesOnMap:    &atomic.Uint64{},
+		sampledIDCache:    sampledIDCache,
+		nonSampledIDCache: nonSampledIDCache,
 	}
 
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
@@ -185,6 +205,8 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace.ReceivedBatches = ptrace.NewTraces()
 		trace.Unlock()
 
+		tsp.cacheDecision(id, decision, trace.DecisionTime)
+
 		if decision == sampling.Sampled {
 			_ = tsp.nextConsumer.ConsumeTraces(policy.ctx, allSpans)
 		}
@@ -326,6 +348,12 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 		}
 		d, loaded := tsp.idToTrace.Load(id)
 		if !loaded {
+			if decision, decisionTime, ok := tsp.cachedDecision(id); ok {
+				// The trace was removed from memory after its decision was taken,
+				// the late spans follow the original decision.
+				tsp.releaseLateSpans(decision, decisionTime, resourceSpans, spans)
+				continue
+			}
 			spanCount := &atomic.Int64{}
 			spanCount.Store(lenSpans)
 			d, loaded = tsp.idToTrace.LoadOrStore(id, &sampling.TraceData{
@@ -365,29 +393,66 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 			actualData.Unlock()
 		} else {
 			actualData.Unlock()
-
-			switch finalDecision {
-			case sampling.Sampled:
-				// Forward the spans to the policy destinations
-				traceTd := ptrace.NewTraces()
-				appendToTraces(traceTd, resourceSpans, spans)
-				if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, traceTd); err != nil {
-					tsp.logger.Warn(
-						"Error sending late arrived spans to destination",
-						zap.Error(err))
-				}
-			case sampling.NotSampled:
-				stats.Record(tsp.ctx, statLateSpanArrivalAfterDecision.M(int64(time.Since(actualData.DecisionTime)/time.Second)))
-			default:
-				tsp.logger.Warn("Encountered unexpected sampling decision",
-					zap.Int("decision", int(finalDecision)))
-			}
+			tsp.releaseLateSpans(finalDecision, actualData.DecisionTime, resourceSpans, spans)
 		}
 	}
 
 	stats.Record(tsp.ctx, statNewTraceIDReceivedCount.M(newTraceIDs))
 }
 
+// releaseLateSpans applies the decision already taken for a trace to the spans arriving late.
+func (tsp *tailSamplingSpanProcessor) releaseLateSpans(decision sampling.Decision, decisionTime time.Time, resourceSpans ptrace.ResourceSpans, spans []*ptrace.Span) {
+	switch decision {
+	case sampling.Sampled:
+		// Forward the spans to the policy destinations
+		traceTd := ptrace.NewTraces()
+		appendToTraces(traceTd, resourceSpans, spans)
+		if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, traceTd); err != nil {
+			tsp.logger.Warn(
+				"Error sending late arrived spans to destination",
+				zap.Error(err))
+		}
+	case sampling.NotSampled:
+		stats.Record(tsp.ctx, statLateSpanArrivalAfterDecision.M(int64(time.Since(decisionTime)/time.Second)))
+	default:
+		tsp.logger.Warn("Encountered unexpected sampling decision",
+			zap.Int("decision", int(decision)))
+	}
+}
+
+// cacheDecision remembers the decision taken for a trace, so that it can be applied
+// to the spans arriving after the trace is removed from memory.
+func (tsp *tailSamplingSpanProcessor) cacheDecision(id pcommon.TraceID, decision sampling.Decision, decisionTime time.Time) {
+	switch {
+	case decision == sampling.Sampled && tsp.sampledIDCache != nil:
+		tsp.sampledIDCache.Put(id, decisionTime)
+	case decision == sampling.NotSampled && tsp.nonSampledIDCache != nil:
+		tsp.nonSampledIDCache.Put(id, decisionTime)
+	}
+}
+
+// cachedDecision returns the decision remembered for a trace that is not in memory,
+// along with the time it was taken, and whether it was found.
+func (tsp *tailSamplingSpanProcessor) cachedDecision(id pcommon.TraceID) (sampling.Decision, time.Time, bool) {
+	if tsp.sampledIDCache == nil && tsp.nonSampledIDCache == nil {
+		return sampling.Unspecified, time.Time{}, false
+	}
+	if tsp.sampledIDCache != nil {
+		if decisionTime, ok := tsp.sampledIDCache.Get(id); ok {
+			_ = stats.RecordWithTags(tsp.ctx, []tag.Mutator{tag.Upsert(tagSampledKey, "true")}, statDecisionCacheHitCount.M(int64(1)))
+			return sampling.Sampled, decisionTime, true
+		}
+	}
+	if tsp.nonSampledIDCache != nil {
+		if decisionTime, ok := tsp.nonSampledIDCache.Get(id); ok {
+			_ = stats.RecordWithTags(tsp.ctx, []tag.Mutator{tag.Upsert(tagSampledKey, "false")}, statDecisionCacheHitCount.M(int64(1)))
+			return sampling.NotSampled, decisionTime, true
+		}
+	}
+	stats.Record(tsp.ctx, statDecisionCacheMissCount.M(int64(1)))
+	return sampling.Unspecified, time.Time{}, false
+}
+
 func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
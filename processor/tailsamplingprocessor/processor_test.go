@@ -21,6 +21,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -626,6 +627,95 @@ func TestMultipleBatchesAreCombinedIntoOne(t *testing.T) {
 	}
 }
 
+func TestLateSpansOfEvictedTracesFollowCachedDecision(t *testing.T) {
+	tests := []struct {
+		name              string
+		decision          sampling.Decision
+		expectedSpanCount int
+	}{
+		{
+			name:              "sampled",
+			decision:          sampling.Sampled,
+			expectedSpanCount: 1,
+		},
+		{
+			name:              "not sampled",
+			decision:          sampling.NotSampled,
+			expectedSpanCount: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Only one trace fits in memory, the decision of the first trace
+			// is only kept by the decision cache once the second one arrives.
+			const maxSize = 1
+			sampledIDCache, err := cache.NewLRUCache[time.Time](10)
+			require.NoError(t, err)
+			nonSampledIDCache, err := cache.NewLRUCache[time.Time](10)
+			require.NoError(t, err)
+			msp := new(consumertest.TracesSink)
+			mpe := &mockPolicyEvaluator{}
+			tsp := &tailSamplingSpanProcessor{
+				ctx:               context.Background(),
+				nextConsumer:      msp,
+				maxNumTraces:      maxSize,
+				logger:            zap.NewNop(),
+				decisionBatcher:   newSyncIDBatcher(1),
+				policies:          []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+				deleteChan:        make(chan pcommon.TraceID, maxSize),
+				policyTicker:      &manualTTicker{},
+				tickerFrequency:   100 * time.Millisecond,
+				numTracesOnMap:    &atomic.Uint64{},
+				sampledIDCache:    sampledIDCache,
+				nonSampledIDCache: nonSampledIDCache,
+			}
+			require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, tsp.Shutdown(context.Background()))
+			}()
+
+			mpe.NextDecision = tt.decision
+			traceIDs, batches := generateIdsAndBatches(2)
+
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+			tsp.samplingPolicyOnTick()
+			tsp.samplingPolicyOnTick()
+			require.EqualValues(t, 1, mpe.EvaluationCount)
+
+			// The second trace evicts the first one from memory
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[1]))
+			_, ok := tsp.idToTrace.Load(traceIDs[0])
+			require.False(t, ok)
+			msp.Reset()
+
+			// The late span gets the original decision, without being evaluated as a new trace
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceIDs[0])))
+			_, ok = tsp.idToTrace.Load(traceIDs[0])
+			require.False(t, ok)
+			require.EqualValues(t, tt.expectedSpanCount, msp.SpanCount())
+			tsp.samplingPolicyOnTick()
+			tsp.samplingPolicyOnTick()
+			require.EqualValues(t, 2, mpe.EvaluationCount, "only the second trace should have been evaluated")
+		})
+	}
+}
+
+func TestNewTracesProcessorInvalidDecisionCache(t *testing.T) {
+	for _, decisionCache := range []DecisionCacheCfg{
+		{SampledCacheSize: -1},
+		{NonSampledCacheSize: -1},
+	} {
+		cfg := Config{
+			DecisionWait:  defaultTestDecisionWait,
+			NumTraces:     100,
+			PolicyCfgs:    testPolicy,
+			DecisionCache: decisionCache,
+		}
+		_, err := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
+		require.ErrorIs(t, err, cache.ErrInvalidSize)
+	}
+}
+
 func collectSpanIds(trace ptrace.Traces) []pcommon.SpanID {
 	var spanIDs []pcommon.SpanID
 
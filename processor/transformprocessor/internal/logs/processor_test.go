@@ -317,6 +317,110 @@ func Test_ProcessLogs_LogContext(t *testing.T) {
 				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("json_test", "pass")
 			},
 		},
+		{
+			statement: `merge_maps(attributes, ParseKeyValue("kv_test=pass"), "insert") where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("kv_test", "pass")
+			},
+		},
+		{
+			statement: `merge_maps(attributes, ExtractPatterns(body, "^operation(?P<pattern_test>\\w+)$"), "insert") where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("pattern_test", "A")
+			},
+		},
+		{
+			statement: `set(attributes["xml_test"], ParseXML("<test>pass</test>")["content"]) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("xml_test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["hash_test"], SHA256(body, salt="salt")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("hash_test", "f65f167846e54df5656b6989175fa0c70c96dedf1f9f25c5e3f755d4bdde957e")
+			},
+		},
+		{
+			statement: `set(attributes["encoding_test"], Base64Decode(Base64Encode(body))) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("encoding_test", "operationA")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Split(attributes["flags"], "|")[1]) where IsString(body) and Len(attributes["flags"]) > 3`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "B")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where IsMap(body) or IsList(body)`,
+			want:      func(td plog.Logs) {},
+		},
+		{
+			statement: `set(attributes["keys"], Keys(attributes)) where body == "operationB"`,
+			want: func(td plog.Logs) {
+				keys := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutEmptySlice("keys")
+				for _, k := range []string{"http.method", "http.path", "http.url", "flags", "total.string"} {
+					keys.AppendEmpty().SetStr(k)
+				}
+			},
+		},
+		{
+			statement: `set(attributes["values"], Values({"a": 1, "b": "two"})) where body == "operationB"`,
+			want: func(td plog.Logs) {
+				values := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutEmptySlice("values")
+				values.AppendEmpty().SetInt(1)
+				values.AppendEmpty().SetStr("two")
+			},
+		},
+		{
+			statement: `set(attributes["total.double"], Double(attributes["total.string"])) where body == "operationB"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutDouble("total.double", 345678)
+			},
+		},
+		{
+			statement: `set(attributes["test"], String(dropped_attributes_count)) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "1")
+			},
+		},
+		{
+			statement: `merge_maps(attributes, {"map_test": "pass", "list": [1, 2]}, "insert") where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("map_test", "pass")
+				l := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutEmptySlice("list")
+				l.AppendEmpty().SetInt(1)
+				l.AppendEmpty().SetInt(2)
+			},
+		},
+		{
+			statement: `set(attributes["map_test"], {"nested": {"test": "pass"}}) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutEmptyMap("map_test").PutEmptyMap("nested").PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(time, Time("2023-05-26 12:34:56", "%Y-%m-%d %H:%M:%S")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetTimestamp(pcommon.NewTimestampFromTime(time.Date(2023, 5, 26, 12, 34, 56, 0, time.UTC)))
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where Now() - time > Duration("24h")`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "pass")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], FormatTime(time, "%Y-%m-%d")) where UnixSeconds(time) == 1581452772`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "2020-02-11")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("test", "2020-02-11")
+			},
+		},
 		{
 			statement: `limit(attributes, 0, []) where body == "operationA"`,
 			want: func(td plog.Logs) {
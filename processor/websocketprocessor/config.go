@@ -4,24 +4,50 @@
 package websocketprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/websocketprocessor"
 
 import (
+	"errors"
+
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"golang.org/x/time/rate"
 )
 
-const defaultPort = 12001
+// defaultEndpoint only accepts local connections, as clients can
+// read any data passing through the processor.
+const defaultEndpoint = "localhost:12001"
+
+var (
+	errMissingEndpoint = errors.New("endpoint must be specified")
+	errInvalidLimit    = errors.New("limit must be greater than 0")
+)
 
 type Config struct {
-	// Port indicates the port used by the web socket listener started by this processor.
-	// Defaults to 12001.
-	Port int `mapstructure:"port"`
+	// HTTPServerSettings configures the address the web socket listener started by
+	// this processor binds to, along with TLS and authentication of its clients.
+	// The endpoint defaults to localhost:12001.
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
 	// Limit is a float that indicates the maximum number of messages repeated
 	// through the websocket by this processor in messages per second. Defaults to 1.
 	Limit rate.Limit `mapstructure:"limit"`
 }
 
+var _ component.Config = (*Config)(nil)
+
 func createDefaultConfig() component.Config {
 	return &Config{
-		Port:  defaultPort,
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: defaultEndpoint,
+		},
 		Limit: 1,
 	}
 }
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errMissingEndpoint
+	}
+	if cfg.Limit <= 0 {
+		return errInvalidLimit
+	}
+	return nil
+}
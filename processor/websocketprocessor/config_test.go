@@ -7,10 +7,40 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/config/confighttp"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
-	assert.Equal(t, 12001, cfg.Port)
+	assert.Equal(t, "localhost:12001", cfg.Endpoint)
 	assert.EqualValues(t, 1, cfg.Limit)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  error
+	}{
+		{
+			name: "valid",
+			cfg:  &Config{HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: "0.0.0.0:8080"}, Limit: 0.5},
+		},
+		{
+			name: "missing endpoint",
+			cfg:  &Config{Limit: 1},
+			err:  errMissingEndpoint,
+		},
+		{
+			name: "zero limit",
+			cfg:  &Config{HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: "localhost:8080"}},
+			err:  errInvalidLimit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.err, tt.cfg.Validate())
+		})
+	}
 }
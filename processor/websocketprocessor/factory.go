@@ -4,8 +4,14 @@
 package websocketprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/websocketprocessor"
 
 import (
+	"context"
+
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 )
 
 const (
@@ -13,9 +19,81 @@ const (
 	stability = component.StabilityLevelDevelopment
 )
 
+var processorCapabilities = consumer.Capabilities{MutatesData: false}
+
+// processors holds one websocket processor per configuration, so that
+// the traces, metrics and logs pipelines share the same listener.
+var processors = sharedcomponent.NewSharedComponents()
+
 func NewFactory() processor.Factory {
 	return processor.NewFactory(
 		typeStr,
 		createDefaultConfig,
+		processor.WithTraces(createTracesProcessor, stability),
+		processor.WithMetrics(createMetricsProcessor, stability),
+		processor.WithLogs(createLogsProcessor, stability),
 	)
 }
+
+func createTracesProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (processor.Traces, error) {
+	shared := getOrAddProcessor(set, cfg.(*Config))
+	wp := shared.Unwrap().(*wsprocessor)
+	return processorhelper.NewTracesProcessor(
+		ctx,
+		set,
+		cfg,
+		nextConsumer,
+		wp.processTraces,
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(shared.Start),
+		processorhelper.WithShutdown(shared.Shutdown))
+}
+
+func createMetricsProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (processor.Metrics, error) {
+	shared := getOrAddProcessor(set, cfg.(*Config))
+	wp := shared.Unwrap().(*wsprocessor)
+	return processorhelper.NewMetricsProcessor(
+		ctx,
+		set,
+		cfg,
+		nextConsumer,
+		wp.processMetrics,
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(shared.Start),
+		processorhelper.WithShutdown(shared.Shutdown))
+}
+
+func createLogsProcessor(
+	ctx context.Context,
+	set processor.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (processor.Logs, error) {
+	shared := getOrAddProcessor(set, cfg.(*Config))
+	wp := shared.Unwrap().(*wsprocessor)
+	return processorhelper.NewLogsProcessor(
+		ctx,
+		set,
+		cfg,
+		nextConsumer,
+		wp.processLogs,
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(shared.Start),
+		processorhelper.WithShutdown(shared.Shutdown))
+}
+
+func getOrAddProcessor(set processor.CreateSettings, cfg *Config) *sharedcomponent.SharedComponent {
+	return processors.GetOrAdd(cfg, func() component.Component {
+		return newProcessor(set.TelemetrySettings, cfg)
+	})
+}
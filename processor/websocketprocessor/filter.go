@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package websocketprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/websocketprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+)
+
+// connFilter holds the OTTL conditions requested by a websocket client.
+// A condition is parsed for every signal, a signal for which it can not
+// be parsed is not sent to the client.
+type connFilter struct {
+	span      expr.BoolExpr[ottlspan.TransformContext]
+	dataPoint expr.BoolExpr[ottldatapoint.TransformContext]
+	log       expr.BoolExpr[ottllog.TransformContext]
+}
+
+// newConnFilter parses the conditions, a nil filter is returned when there are none.
+// An error is returned if the conditions can not be parsed for any signal.
+func newConnFilter(conditions []string, set component.TelemetrySettings) (*connFilter, error) {
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+	var (
+		f    connFilter
+		err  error
+		errs error
+	)
+	f.span, err = filterottl.NewBoolExprForSpan(conditions, filterottl.StandardSpanFuncs(), ottl.IgnoreError, set)
+	errs = multierr.Append(errs, err)
+	f.dataPoint, err = filterottl.NewBoolExprForDataPoint(conditions, filterottl.StandardDataPointFuncs(), ottl.IgnoreError, set)
+	errs = multierr.Append(errs, err)
+	f.log, err = filterottl.NewBoolExprForLog(conditions, filterottl.StandardLogFuncs(), ottl.IgnoreError, set)
+	errs = multierr.Append(errs, err)
+
+	if f.span == nil && f.dataPoint == nil && f.log == nil {
+		return nil, errs
+	}
+	return &f, nil
+}
+
+// filterTraces returns a copy of td holding only the spans matching the conditions.
+func (f *connFilter) filterTraces(ctx context.Context, td ptrace.Traces) ptrace.Traces {
+	out := ptrace.NewTraces()
+	if f.span == nil {
+		return out
+	}
+	td.CopyTo(out)
+	out.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				matched, err := f.span.Eval(ctx, ottlspan.NewTransformContext(span, ss.Scope(), rs.Resource()))
+				return err != nil || !matched
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	return out
+}
+
+// filterMetrics returns a copy of md holding only the data points matching the conditions.
+func (f *connFilter) filterMetrics(ctx context.Context, md pmetric.Metrics) pmetric.Metrics {
+	out := pmetric.NewMetrics()
+	if f.dataPoint == nil {
+		return out
+	}
+	md.CopyTo(out)
+	out.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				matches := func(dp interface{}) bool {
+					tCtx := ottldatapoint.NewTransformContext(dp, m, sm.Metrics(), sm.Scope(), rm.Resource())
+					matched, err := f.dataPoint.Eval(ctx, tCtx)
+					return err == nil && matched
+				}
+				return removeDataPoints(m, matches) == 0
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return out
+}
+
+// filterLogs returns a copy of ld holding only the log records matching the conditions.
+func (f *connFilter) filterLogs(ctx context.Context, ld plog.Logs) plog.Logs {
+	out := plog.NewLogs()
+	if f.log == nil {
+		return out
+	}
+	ld.CopyTo(out)
+	out.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				matched, err := f.log.Eval(ctx, ottllog.NewTransformContext(lr, sl.Scope(), rl.Resource()))
+				return err != nil || !matched
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	return out
+}
+
+// removeDataPoints keeps the data points of m for which keep returns true
+// and returns the number of data points left.
+func removeDataPoints(m pmetric.Metric, keep func(dp interface{}) bool) int {
+	//exhaustive:enforce
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return !keep(dp) })
+		return dps.Len()
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return !keep(dp) })
+		return dps.Len()
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool { return !keep(dp) })
+		return dps.Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool { return !keep(dp) })
+		return dps.Len()
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		dps.RemoveIf(func(dp pmetric.SummaryDataPoint) bool { return !keep(dp) })
+		return dps.Len()
+	case pmetric.MetricTypeEmpty:
+	}
+	return 0
+}
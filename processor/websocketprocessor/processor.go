@@ -0,0 +1,271 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package websocketprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/websocketprocessor"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+	"golang.org/x/time/rate"
+)
+
+var (
+	tracesMarshaler  = &ptrace.JSONMarshaler{}
+	metricsMarshaler = &pmetric.JSONMarshaler{}
+	logsMarshaler    = &plog.JSONMarshaler{}
+)
+
+// wsprocessor passes telemetry through unchanged and sends a
+// rate limited copy of it as OTLP JSON to the connected websocket clients.
+type wsprocessor struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+
+	listener   net.Listener
+	server     *http.Server
+	shutdownWG sync.WaitGroup
+
+	mu    sync.Mutex
+	conns map[*connection]struct{}
+}
+
+// connection is a websocket client, messages that can not be
+// written as fast as they are produced are dropped.
+type connection struct {
+	conn    *websocket.Conn
+	filter  *connFilter
+	limiter *rate.Limiter
+	msgs    chan []byte
+}
+
+// ready reports whether the rate limit of the connection allows sending a message
+// now, without using up the allowance. It is checked before any data is filtered
+// or marshaled for the connection.
+func (c *connection) ready() bool {
+	return c.limiter.Tokens() >= 1
+}
+
+func (c *connection) send(msg []byte) {
+	select {
+	case c.msgs <- msg:
+	default:
+	}
+}
+
+func newProcessor(set component.TelemetrySettings, cfg *Config) *wsprocessor {
+	return &wsprocessor{
+		config:    cfg,
+		telemetry: set,
+		conns:     make(map[*connection]struct{}),
+	}
+}
+
+func (w *wsprocessor) Start(_ context.Context, host component.Host) error {
+	ln, err := w.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", w.config.Endpoint, err)
+	}
+	w.listener = ln
+	w.server, err = w.config.HTTPServerSettings.ToServer(host, w.telemetry, w)
+	if err != nil {
+		return multierr.Append(err, ln.Close())
+	}
+	w.server.ReadHeaderTimeout = 20 * time.Second
+	w.shutdownWG.Add(1)
+	go func() {
+		defer w.shutdownWG.Done()
+		if errHTTP := w.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+func (w *wsprocessor) Shutdown(ctx context.Context) error {
+	if w.server == nil {
+		return nil
+	}
+	err := w.server.Shutdown(ctx)
+	// Websocket connections are hijacked from the server,
+	// so they have to be closed separately.
+	w.mu.Lock()
+	for c := range w.conns {
+		_ = c.conn.Close()
+	}
+	w.mu.Unlock()
+	w.shutdownWG.Wait()
+	return err
+}
+
+// ServeHTTP parses the OTTL conditions given as `condition` query
+// parameters before upgrading the request to a websocket connection.
+func (w *wsprocessor) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	filter, err := newConnFilter(req.URL.Query()["condition"], w.telemetry)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	websocket.Handler(func(conn *websocket.Conn) {
+		w.handleConn(conn, filter)
+	}).ServeHTTP(rw, req)
+}
+
+func (w *wsprocessor) handleConn(conn *websocket.Conn, filter *connFilter) {
+	c := &connection{
+		conn:    conn,
+		filter:  filter,
+		limiter: rate.NewLimiter(w.config.Limit, 1),
+		msgs:    make(chan []byte, 1),
+	}
+	w.mu.Lock()
+	w.conns[c] = struct{}{}
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		delete(w.conns, c)
+		w.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	closed := make(chan struct{})
+	go func() {
+		// Clients are not expected to send anything, reading
+		// is only used to detect that the connection was closed.
+		_, _ = io.Copy(io.Discard, conn)
+		close(closed)
+	}()
+	for {
+		select {
+		case msg := <-c.msgs:
+			if _, err := conn.Write(msg); err != nil {
+				w.telemetry.Logger.Debug("Failed to write to websocket", zap.Error(err))
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+func (w *wsprocessor) connections() []*connection {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	conns := make([]*connection, 0, len(w.conns))
+	for c := range w.conns {
+		conns = append(conns, c)
+	}
+	return conns
+}
+
+func (w *wsprocessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	var unfiltered []byte
+	for _, c := range w.connections() {
+		if !c.ready() {
+			continue
+		}
+		data := td
+		if c.filter != nil {
+			data = c.filter.filterTraces(ctx, td)
+			if data.ResourceSpans().Len() == 0 {
+				continue
+			}
+		}
+		if !c.limiter.Allow() {
+			continue
+		}
+		if c.filter == nil && unfiltered != nil {
+			c.send(unfiltered)
+			continue
+		}
+		msg, err := tracesMarshaler.MarshalTraces(data)
+		if err != nil {
+			w.telemetry.Logger.Error("Failed to marshal traces", zap.Error(err))
+			continue
+		}
+		if c.filter == nil {
+			unfiltered = msg
+		}
+		c.send(msg)
+	}
+	return td, nil
+}
+
+func (w *wsprocessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	var unfiltered []byte
+	for _, c := range w.connections() {
+		if !c.ready() {
+			continue
+		}
+		data := md
+		if c.filter != nil {
+			data = c.filter.filterMetrics(ctx, md)
+			if data.ResourceMetrics().Len() == 0 {
+				continue
+			}
+		}
+		if !c.limiter.Allow() {
+			continue
+		}
+		if c.filter == nil && unfiltered != nil {
+			c.send(unfiltered)
+			continue
+		}
+		msg, err := metricsMarshaler.MarshalMetrics(data)
+		if err != nil {
+			w.telemetry.Logger.Error("Failed to marshal metrics", zap.Error(err))
+			continue
+		}
+		if c.filter == nil {
+			unfiltered = msg
+		}
+		c.send(msg)
+	}
+	return md, nil
+}
+
+func (w *wsprocessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	var unfiltered []byte
+	for _, c := range w.connections() {
+		if !c.ready() {
+			continue
+		}
+		data := ld
+		if c.filter != nil {
+			data = c.filter.filterLogs(ctx, ld)
+			if data.ResourceLogs().Len() == 0 {
+				continue
+			}
+		}
+		if !c.limiter.Allow() {
+			continue
+		}
+		if c.filter == nil && unfiltered != nil {
+			c.send(unfiltered)
+			continue
+		}
+		msg, err := logsMarshaler.MarshalLogs(data)
+		if err != nil {
+			w.telemetry.Logger.Error("Failed to marshal logs", zap.Error(err))
+			continue
+		}
+		if c.filter == nil {
+			unfiltered = msg
+		}
+		c.send(msg)
+	}
+	return ld, nil
+}
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package websocketprocessor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"golang.org/x/net/websocket"
+	"golang.org/x/time/rate"
+)
+
+func startProcessor(t *testing.T, limit rate.Limit) *wsprocessor {
+	cfg := &Config{HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: "localhost:0"}, Limit: limit}
+	w := newProcessor(componenttest.NewNopTelemetrySettings(), cfg)
+	require.NoError(t, w.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, w.Shutdown(context.Background()))
+	})
+	return w
+}
+
+func dial(t *testing.T, w *wsprocessor, conditions ...string) *websocket.Conn {
+	expected := len(w.connections()) + 1
+	query := url.Values{"condition": conditions}
+	addr := fmt.Sprintf("ws://%s/?%s", w.listener.Addr().String(), query.Encode())
+	conn, err := websocket.Dial(addr, "", "http://localhost/")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	require.Eventually(t, func() bool {
+		return len(w.connections()) == expected
+	}, 5*time.Second, 10*time.Millisecond)
+	return conn
+}
+
+func receive(t *testing.T, conn *websocket.Conn) []byte {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var msg []byte
+	require.NoError(t, websocket.Message.Receive(conn, &msg))
+	return msg
+}
+
+func assertNothingReceived(t *testing.T, conn *websocket.Conn) {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+	var msg []byte
+	assert.Error(t, websocket.Message.Receive(conn, &msg))
+}
+
+func testTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetName("GET /cart")
+	spans.AppendEmpty().SetName("POST /pay")
+	return td
+}
+
+func TestProcessorPassesDataThrough(t *testing.T) {
+	w := startProcessor(t, rate.Inf)
+	dial(t, w)
+
+	td := testTraces()
+	out, err := w.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, td, out)
+	assert.Equal(t, 2, out.SpanCount())
+}
+
+func TestProcessorSendsTraces(t *testing.T) {
+	w := startProcessor(t, rate.Inf)
+	first := dial(t, w)
+	second := dial(t, w)
+
+	_, err := w.processTraces(context.Background(), testTraces())
+	require.NoError(t, err)
+
+	for _, conn := range []*websocket.Conn{first, second} {
+		td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(receive(t, conn))
+		require.NoError(t, err)
+		assert.Equal(t, testTraces(), td)
+	}
+}
+
+func TestProcessorSendsMetrics(t *testing.T) {
+	w := startProcessor(t, rate.Inf)
+	conn := dial(t, w)
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(3)
+	_, err := w.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	received, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(receive(t, conn))
+	require.NoError(t, err)
+	assert.Equal(t, md, received)
+}
+
+func TestProcessorSendsLogs(t *testing.T) {
+	w := startProcessor(t, rate.Inf)
+	conn := dial(t, w)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+	_, err := w.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	received, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(receive(t, conn))
+	require.NoError(t, err)
+	assert.Equal(t, ld, received)
+}
+
+func TestProcessorRateLimit(t *testing.T) {
+	w := startProcessor(t, rate.Every(time.Hour))
+	conn := dial(t, w)
+
+	for i := 0; i < 3; i++ {
+		_, err := w.processTraces(context.Background(), testTraces())
+		require.NoError(t, err)
+	}
+	receive(t, conn)
+	assertNothingReceived(t, conn)
+}
+
+func TestProcessorFiltersConnection(t *testing.T) {
+	w := startProcessor(t, rate.Inf)
+	filtered := dial(t, w, `name == "POST /pay"`)
+	unfiltered := dial(t, w)
+
+	_, err := w.processTraces(context.Background(), testTraces())
+	require.NoError(t, err)
+
+	td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(receive(t, filtered))
+	require.NoError(t, err)
+	require.Equal(t, 1, td.SpanCount())
+	assert.Equal(t, "POST /pay", td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+
+	td, err = (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(receive(t, unfiltered))
+	require.NoError(t, err)
+	assert.Equal(t, 2, td.SpanCount())
+}
+
+func TestProcessorFilterSkipsUnsupportedSignals(t *testing.T) {
+	w := startProcessor(t, rate.Inf)
+	// kind is only known to spans, so the condition
+	// can not be parsed for logs.
+	conn := dial(t, w, `kind == SPAN_KIND_SERVER`)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	_, err := w.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assertNothingReceived(t, conn)
+}
+
+func TestProcessorFilterNoMatch(t *testing.T) {
+	w := startProcessor(t, rate.Inf)
+	conn := dial(t, w, `name == "DELETE /cart"`)
+
+	_, err := w.processTraces(context.Background(), testTraces())
+	require.NoError(t, err)
+	assertNothingReceived(t, conn)
+}
+
+func TestProcessorRejectsInvalidCondition(t *testing.T) {
+	w := startProcessor(t, rate.Inf)
+
+	query := url.Values{"condition": {"name =="}}
+	resp, err := http.Get(fmt.Sprintf("http://%s/?%s", w.listener.Addr().String(), query.Encode()))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestProcessorRemovesClosedConnections(t *testing.T) {
+	w := startProcessor(t, rate.Inf)
+	conn := dial(t, w)
+	require.NoError(t, conn.Close())
+	assert.Eventually(t, func() bool {
+		return len(w.connections()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestProcessorFilterNoMatchKeepsRateLimit(t *testing.T) {
+	w := startProcessor(t, rate.Every(time.Hour))
+	conn := dial(t, w, `name == "POST /pay"`)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("GET /cart")
+	_, err := w.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assertNothingReceived(t, conn)
+
+	_, err = w.processTraces(context.Background(), testTraces())
+	require.NoError(t, err)
+	received, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(receive(t, conn))
+	require.NoError(t, err)
+	assert.Equal(t, 1, received.SpanCount())
+}
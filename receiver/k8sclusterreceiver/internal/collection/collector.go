@@ -10,6 +10,7 @@ import (
 	agentmetricspb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/metrics/v1"
 	quotav1 "github.com/openshift/api/quota/v1"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
@@ -17,6 +18,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,11 +30,15 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/cronjob"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/demonset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/deployment"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/endpoints"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/hpa"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/ingress"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/jobs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/namespace"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/node"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/persistentvolume"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/persistentvolumeclaim"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/pod"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/replicaset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/replicationcontroller"
@@ -48,21 +54,25 @@ import (
 // an interface to interact with refactored code from SignalFx Agent which is
 // confined to the collection package.
 type DataCollector struct {
+	settings                 receiver.CreateSettings
 	logger                   *zap.Logger
 	metricsStore             *metricsStore
 	metadataStore            *metadata.Store
+	metricsBuilderConfig     metadata.MetricsBuilderConfig
 	nodeConditionsToReport   []string
 	allocatableTypesToReport []string
 }
 
 // NewDataCollector returns a DataCollector.
-func NewDataCollector(logger *zap.Logger, nodeConditionsToReport, allocatableTypesToReport []string) *DataCollector {
+func NewDataCollector(set receiver.CreateSettings, metricsBuilderConfig metadata.MetricsBuilderConfig, nodeConditionsToReport, allocatableTypesToReport []string) *DataCollector {
 	return &DataCollector{
-		logger: logger,
+		settings: set,
+		logger:   set.Logger,
 		metricsStore: &metricsStore{
 			metricsCache: make(map[types.UID]pmetric.Metrics),
 		},
 		metadataStore:            &metadata.Store{},
+		metricsBuilderConfig:     metricsBuilderConfig,
 		nodeConditionsToReport:   nodeConditionsToReport,
 		allocatableTypesToReport: allocatableTypesToReport,
 	}
@@ -112,6 +122,12 @@ func (dc *DataCollector) SyncMetrics(obj interface{}) {
 		md = ocsToMetrics(replicationcontroller.GetMetrics(o))
 	case *corev1.ResourceQuota:
 		md = ocsToMetrics(resourcequota.GetMetrics(o))
+	case *corev1.PersistentVolume:
+		md = persistentvolume.GetMetrics(dc.settings, dc.metricsBuilderConfig, o)
+	case *corev1.PersistentVolumeClaim:
+		md = persistentvolumeclaim.GetMetrics(dc.settings, dc.metricsBuilderConfig, o)
+	case *corev1.Endpoints:
+		md = endpoints.GetMetrics(dc.settings, dc.metricsBuilderConfig, o)
 	case *appsv1.Deployment:
 		md = ocsToMetrics(deployment.GetMetrics(o))
 	case *appsv1.ReplicaSet:
@@ -130,6 +146,8 @@ func (dc *DataCollector) SyncMetrics(obj interface{}) {
 		md = ocsToMetrics(hpa.GetMetrics(o))
 	case *autoscalingv2beta2.HorizontalPodAutoscaler:
 		md = ocsToMetrics(hpa.GetMetricsBeta(o))
+	case *networkingv1.Ingress:
+		md = ingress.GetMetrics(dc.settings, dc.metricsBuilderConfig, o)
 	case *quotav1.ClusterResourceQuota:
 		md = ocsToMetrics(clusterresourcequota.GetMetrics(o))
 	default:
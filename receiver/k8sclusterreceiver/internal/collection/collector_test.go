@@ -5,12 +5,15 @@ package collection
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -294,3 +297,28 @@ func podWithAdditionalLabels(labels map[string]string, pod *corev1.Pod) interfac
 
 	return pod
 }
+
+func TestDataCollectorSyncMetricsWithDisabledMetric(t *testing.T) {
+	mbc := metadata.DefaultMetricsBuilderConfig()
+	mbc.Metrics.K8sPersistentvolumePhase.Enabled = false
+	dc := NewDataCollector(receivertest.NewNopCreateSettings(), mbc, nil, nil)
+
+	dc.SyncMetrics(&corev1.PersistentVolume{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test-pv",
+			UID:  types.UID("test-pv-uid"),
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("1Gi"),
+			},
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+	})
+	md := dc.CollectMetricData(time.Now())
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	require.Equal(t, "k8s.persistentvolume.capacity", metrics.At(0).Name())
+}
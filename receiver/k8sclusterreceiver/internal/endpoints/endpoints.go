@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package endpoints // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/endpoints"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+)
+
+// GetMetrics returns endpoint count metrics for the service backed by the given
+// Endpoints object. Endpoints objects share the name of the service they belong to.
+func GetMetrics(set receiver.CreateSettings, metricsBuilderConfig metadata.MetricsBuilderConfig, ep *corev1.Endpoints) pmetric.Metrics {
+	mb := metadata.NewMetricsBuilder(metricsBuilderConfig, set)
+	ts := pcommon.NewTimestampFromTime(time.Now())
+
+	var ready, notReady int64
+	for _, subset := range ep.Subsets {
+		ready += int64(len(subset.Addresses))
+		notReady += int64(len(subset.NotReadyAddresses))
+	}
+	mb.RecordK8sServiceEndpointsReadyDataPoint(ts, ready)
+	mb.RecordK8sServiceEndpointsNotReadyDataPoint(ts, notReady)
+
+	return mb.Emit(
+		metadata.WithK8sNamespaceName(ep.Namespace),
+		metadata.WithK8sServiceName(ep.Name),
+	)
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package endpoints
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+)
+
+func TestEndpointsMetrics(t *testing.T) {
+	ep := newEndpoints("1")
+
+	md := GetMetrics(receivertest.NewNopCreateSettings(), metadata.DefaultMetricsBuilderConfig(), ep)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	require.Equal(t,
+		map[string]interface{}{
+			"k8s.namespace.name": "test-namespace",
+			"k8s.service.name":   "test-service-1",
+		},
+		rm.Resource().Attributes().AsRaw(),
+	)
+
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	values := map[string]int64{}
+	for i := 0; i < ms.Len(); i++ {
+		values[ms.At(i).Name()] = ms.At(i).Gauge().DataPoints().At(0).IntValue()
+	}
+	require.Equal(t,
+		map[string]int64{
+			"k8s.service.endpoints.ready":     3,
+			"k8s.service.endpoints.not_ready": 1,
+		},
+		values,
+	)
+}
+
+func newEndpoints(id string) *corev1.Endpoints {
+	return &corev1.Endpoints{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test-service-" + id,
+			UID:       types.UID("test-endpoints-" + id + "-uid"),
+			Namespace: "test-namespace",
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{
+					{IP: "10.0.0.1"},
+					{IP: "10.0.0.2"},
+				},
+				NotReadyAddresses: []corev1.EndpointAddress{
+					{IP: "10.0.0.3"},
+				},
+				Ports: []corev1.EndpointPort{{Port: 8080}},
+			},
+			{
+				Addresses: []corev1.EndpointAddress{
+					{IP: "10.0.0.4"},
+				},
+				Ports: []corev1.EndpointPort{{Port: 9090}},
+			},
+		},
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/ingress"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+)
+
+// GetMetrics returns metrics for a given ingress. Backends referencing
+// a resource instead of a service are not counted.
+func GetMetrics(set receiver.CreateSettings, metricsBuilderConfig metadata.MetricsBuilderConfig, ing *networkingv1.Ingress) pmetric.Metrics {
+	mb := metadata.NewMetricsBuilder(metricsBuilderConfig, set)
+	ts := pcommon.NewTimestampFromTime(time.Now())
+
+	for service, count := range backendsByService(ing) {
+		mb.RecordK8sIngressBackendsDataPoint(ts, count, service)
+	}
+
+	return mb.Emit(
+		metadata.WithK8sNamespaceName(ing.Namespace),
+		metadata.WithK8sIngressName(ing.Name),
+		metadata.WithK8sIngressUID(string(ing.UID)),
+	)
+}
+
+func backendsByService(ing *networkingv1.Ingress) map[string]int64 {
+	out := map[string]int64{}
+	if b := ing.Spec.DefaultBackend; b != nil && b.Service != nil {
+		out[b.Service.Name]++
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil {
+				out[path.Backend.Service.Name]++
+			}
+		}
+	}
+	return out
+}
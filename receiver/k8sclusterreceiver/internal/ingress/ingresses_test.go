@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+)
+
+func TestIngressMetrics(t *testing.T) {
+	ing := newIngress("1")
+
+	md := GetMetrics(receivertest.NewNopCreateSettings(), metadata.DefaultMetricsBuilderConfig(), ing)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	require.Equal(t,
+		map[string]interface{}{
+			"k8s.namespace.name": "test-namespace",
+			"k8s.ingress.name":   "test-ingress-1",
+			"k8s.ingress.uid":    "test-ingress-1-uid",
+		},
+		rm.Resource().Attributes().AsRaw(),
+	)
+
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, ms.Len())
+	require.Equal(t, "k8s.ingress.backends", ms.At(0).Name())
+	dps := ms.At(0).Gauge().DataPoints()
+	backends := map[string]int64{}
+	for i := 0; i < dps.Len(); i++ {
+		service, ok := dps.At(i).Attributes().Get("service")
+		require.True(t, ok)
+		backends[service.Str()] = dps.At(i).IntValue()
+	}
+	require.Equal(t,
+		map[string]int64{
+			"default-service": 1,
+			"api-service":     2,
+			"web-service":     1,
+		},
+		backends,
+	)
+}
+
+func newIngress(id string) *networkingv1.Ingress {
+	serviceBackend := func(name string) networkingv1.IngressBackend {
+		return networkingv1.IngressBackend{
+			Service: &networkingv1.IngressServiceBackend{
+				Name: name,
+				Port: networkingv1.ServiceBackendPort{Number: 80},
+			},
+		}
+	}
+	defaultBackend := serviceBackend("default-service")
+	return &networkingv1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test-ingress-" + id,
+			UID:       types.UID("test-ingress-" + id + "-uid"),
+			Namespace: "test-namespace",
+		},
+		Spec: networkingv1.IngressSpec{
+			DefaultBackend: &defaultBackend,
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{Path: "/", Backend: serviceBackend("web-service")},
+								{Path: "/api", Backend: serviceBackend("api-service")},
+								{Path: "/static", Backend: networkingv1.IngressBackend{
+									Resource: &corev1.TypedLocalObjectReference{Kind: "StorageBucket", Name: "static-assets"},
+								}},
+							},
+						},
+					},
+				},
+				{
+					Host: "api.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{Path: "/", Backend: serviceBackend("api-service")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
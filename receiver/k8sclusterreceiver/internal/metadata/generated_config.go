@@ -0,0 +1,128 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import "go.opentelemetry.io/collector/confmap"
+
+// MetricConfig provides common config for a particular metric.
+type MetricConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms, confmap.WithErrorUnused())
+	if err != nil {
+		return err
+	}
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsConfig provides config for k8s_cluster metrics.
+type MetricsConfig struct {
+	K8sIngressBackends               MetricConfig `mapstructure:"k8s.ingress.backends"`
+	K8sPersistentvolumeCapacity      MetricConfig `mapstructure:"k8s.persistentvolume.capacity"`
+	K8sPersistentvolumePhase         MetricConfig `mapstructure:"k8s.persistentvolume.phase"`
+	K8sPersistentvolumeclaimCapacity MetricConfig `mapstructure:"k8s.persistentvolumeclaim.capacity"`
+	K8sPersistentvolumeclaimPhase    MetricConfig `mapstructure:"k8s.persistentvolumeclaim.phase"`
+	K8sPersistentvolumeclaimRequest  MetricConfig `mapstructure:"k8s.persistentvolumeclaim.request"`
+	K8sServiceEndpointsNotReady      MetricConfig `mapstructure:"k8s.service.endpoints.not_ready"`
+	K8sServiceEndpointsReady         MetricConfig `mapstructure:"k8s.service.endpoints.ready"`
+}
+
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		K8sIngressBackends: MetricConfig{
+			Enabled: true,
+		},
+		K8sPersistentvolumeCapacity: MetricConfig{
+			Enabled: true,
+		},
+		K8sPersistentvolumePhase: MetricConfig{
+			Enabled: true,
+		},
+		K8sPersistentvolumeclaimCapacity: MetricConfig{
+			Enabled: true,
+		},
+		K8sPersistentvolumeclaimPhase: MetricConfig{
+			Enabled: true,
+		},
+		K8sPersistentvolumeclaimRequest: MetricConfig{
+			Enabled: true,
+		},
+		K8sServiceEndpointsNotReady: MetricConfig{
+			Enabled: true,
+		},
+		K8sServiceEndpointsReady: MetricConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// ResourceAttributeConfig provides common config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// ResourceAttributesConfig provides config for k8s_cluster resource attributes.
+type ResourceAttributesConfig struct {
+	K8sIngressName               ResourceAttributeConfig `mapstructure:"k8s.ingress.name"`
+	K8sIngressUID                ResourceAttributeConfig `mapstructure:"k8s.ingress.uid"`
+	K8sNamespaceName             ResourceAttributeConfig `mapstructure:"k8s.namespace.name"`
+	K8sPersistentvolumeName      ResourceAttributeConfig `mapstructure:"k8s.persistentvolume.name"`
+	K8sPersistentvolumeUID       ResourceAttributeConfig `mapstructure:"k8s.persistentvolume.uid"`
+	K8sPersistentvolumeclaimName ResourceAttributeConfig `mapstructure:"k8s.persistentvolumeclaim.name"`
+	K8sPersistentvolumeclaimUID  ResourceAttributeConfig `mapstructure:"k8s.persistentvolumeclaim.uid"`
+	K8sServiceName               ResourceAttributeConfig `mapstructure:"k8s.service.name"`
+	K8sStorageclassName          ResourceAttributeConfig `mapstructure:"k8s.storageclass.name"`
+}
+
+func DefaultResourceAttributesConfig() ResourceAttributesConfig {
+	return ResourceAttributesConfig{
+		K8sIngressName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sIngressUID: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sNamespaceName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sPersistentvolumeName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sPersistentvolumeUID: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sPersistentvolumeclaimName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sPersistentvolumeclaimUID: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sServiceName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sStorageclassName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// MetricsBuilderConfig is a configuration for k8s_cluster metrics builder.
+type MetricsBuilderConfig struct {
+	Metrics            MetricsConfig            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            DefaultMetricsConfig(),
+		ResourceAttributes: DefaultResourceAttributesConfig(),
+	}
+}
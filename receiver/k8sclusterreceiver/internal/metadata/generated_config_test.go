@@ -0,0 +1,96 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestMetricsBuilderConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		want MetricsBuilderConfig
+	}{
+		{
+			name: "default",
+			want: DefaultMetricsBuilderConfig(),
+		},
+		{
+			name: "all_set",
+			want: MetricsBuilderConfig{
+				Metrics: MetricsConfig{
+					K8sIngressBackends:               MetricConfig{Enabled: true},
+					K8sPersistentvolumeCapacity:      MetricConfig{Enabled: true},
+					K8sPersistentvolumePhase:         MetricConfig{Enabled: true},
+					K8sPersistentvolumeclaimCapacity: MetricConfig{Enabled: true},
+					K8sPersistentvolumeclaimPhase:    MetricConfig{Enabled: true},
+					K8sPersistentvolumeclaimRequest:  MetricConfig{Enabled: true},
+					K8sServiceEndpointsNotReady:      MetricConfig{Enabled: true},
+					K8sServiceEndpointsReady:         MetricConfig{Enabled: true},
+				},
+				ResourceAttributes: ResourceAttributesConfig{
+					K8sIngressName:               ResourceAttributeConfig{Enabled: true},
+					K8sIngressUID:                ResourceAttributeConfig{Enabled: true},
+					K8sNamespaceName:             ResourceAttributeConfig{Enabled: true},
+					K8sPersistentvolumeName:      ResourceAttributeConfig{Enabled: true},
+					K8sPersistentvolumeUID:       ResourceAttributeConfig{Enabled: true},
+					K8sPersistentvolumeclaimName: ResourceAttributeConfig{Enabled: true},
+					K8sPersistentvolumeclaimUID:  ResourceAttributeConfig{Enabled: true},
+					K8sServiceName:               ResourceAttributeConfig{Enabled: true},
+					K8sStorageclassName:          ResourceAttributeConfig{Enabled: true},
+				},
+			},
+		},
+		{
+			name: "none_set",
+			want: MetricsBuilderConfig{
+				Metrics: MetricsConfig{
+					K8sIngressBackends:               MetricConfig{Enabled: false},
+					K8sPersistentvolumeCapacity:      MetricConfig{Enabled: false},
+					K8sPersistentvolumePhase:         MetricConfig{Enabled: false},
+					K8sPersistentvolumeclaimCapacity: MetricConfig{Enabled: false},
+					K8sPersistentvolumeclaimPhase:    MetricConfig{Enabled: false},
+					K8sPersistentvolumeclaimRequest:  MetricConfig{Enabled: false},
+					K8sServiceEndpointsNotReady:      MetricConfig{Enabled: false},
+					K8sServiceEndpointsReady:         MetricConfig{Enabled: false},
+				},
+				ResourceAttributes: ResourceAttributesConfig{
+					K8sIngressName:               ResourceAttributeConfig{Enabled: false},
+					K8sIngressUID:                ResourceAttributeConfig{Enabled: false},
+					K8sNamespaceName:             ResourceAttributeConfig{Enabled: false},
+					K8sPersistentvolumeName:      ResourceAttributeConfig{Enabled: false},
+					K8sPersistentvolumeUID:       ResourceAttributeConfig{Enabled: false},
+					K8sPersistentvolumeclaimName: ResourceAttributeConfig{Enabled: false},
+					K8sPersistentvolumeclaimUID:  ResourceAttributeConfig{Enabled: false},
+					K8sServiceName:               ResourceAttributeConfig{Enabled: false},
+					K8sStorageclassName:          ResourceAttributeConfig{Enabled: false},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadMetricsBuilderConfig(t, tt.name)
+			if diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(MetricConfig{}, ResourceAttributeConfig{})); diff != "" {
+				t.Errorf("Config mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func loadMetricsBuilderConfig(t *testing.T, name string) MetricsBuilderConfig {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub(name)
+	require.NoError(t, err)
+	cfg := DefaultMetricsBuilderConfig()
+	require.NoError(t, component.UnmarshalConfig(sub, &cfg))
+	return cfg
+}
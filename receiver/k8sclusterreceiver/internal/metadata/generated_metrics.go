@@ -0,0 +1,659 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+type metricK8sIngressBackends struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.ingress.backends metric with initial data.
+func (m *metricK8sIngressBackends) init() {
+	m.data.SetName("k8s.ingress.backends")
+	m.data.SetDescription("Number of ingress backends (rule paths and the default backend) routing traffic to a service.")
+	m.data.SetUnit("{backend}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricK8sIngressBackends) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, serviceAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("service", serviceAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sIngressBackends) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sIngressBackends) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sIngressBackends(cfg MetricConfig) metricK8sIngressBackends {
+	m := metricK8sIngressBackends{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sPersistentvolumeCapacity struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.persistentvolume.capacity metric with initial data.
+func (m *metricK8sPersistentvolumeCapacity) init() {
+	m.data.SetName("k8s.persistentvolume.capacity")
+	m.data.SetDescription("Storage capacity of the persistent volume.")
+	m.data.SetUnit("By")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPersistentvolumeCapacity) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPersistentvolumeCapacity) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPersistentvolumeCapacity) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPersistentvolumeCapacity(cfg MetricConfig) metricK8sPersistentvolumeCapacity {
+	m := metricK8sPersistentvolumeCapacity{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sPersistentvolumePhase struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.persistentvolume.phase metric with initial data.
+func (m *metricK8sPersistentvolumePhase) init() {
+	m.data.SetName("k8s.persistentvolume.phase")
+	m.data.SetDescription("Current phase of the persistent volume (1 - Pending, 2 - Available, 3 - Bound, 4 - Released, 5 - Failed)")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPersistentvolumePhase) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPersistentvolumePhase) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPersistentvolumePhase) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPersistentvolumePhase(cfg MetricConfig) metricK8sPersistentvolumePhase {
+	m := metricK8sPersistentvolumePhase{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sPersistentvolumeclaimCapacity struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.persistentvolumeclaim.capacity metric with initial data.
+func (m *metricK8sPersistentvolumeclaimCapacity) init() {
+	m.data.SetName("k8s.persistentvolumeclaim.capacity")
+	m.data.SetDescription("Storage capacity of the volume bound to the claim. Only sent once the claim is bound.")
+	m.data.SetUnit("By")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPersistentvolumeclaimCapacity) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPersistentvolumeclaimCapacity) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPersistentvolumeclaimCapacity) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPersistentvolumeclaimCapacity(cfg MetricConfig) metricK8sPersistentvolumeclaimCapacity {
+	m := metricK8sPersistentvolumeclaimCapacity{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sPersistentvolumeclaimPhase struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.persistentvolumeclaim.phase metric with initial data.
+func (m *metricK8sPersistentvolumeclaimPhase) init() {
+	m.data.SetName("k8s.persistentvolumeclaim.phase")
+	m.data.SetDescription("Current phase of the persistent volume claim (1 - Pending, 2 - Bound, 3 - Lost)")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPersistentvolumeclaimPhase) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPersistentvolumeclaimPhase) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPersistentvolumeclaimPhase) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPersistentvolumeclaimPhase(cfg MetricConfig) metricK8sPersistentvolumeclaimPhase {
+	m := metricK8sPersistentvolumeclaimPhase{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sPersistentvolumeclaimRequest struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.persistentvolumeclaim.request metric with initial data.
+func (m *metricK8sPersistentvolumeclaimRequest) init() {
+	m.data.SetName("k8s.persistentvolumeclaim.request")
+	m.data.SetDescription("Storage requested by the persistent volume claim.")
+	m.data.SetUnit("By")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPersistentvolumeclaimRequest) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPersistentvolumeclaimRequest) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPersistentvolumeclaimRequest) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPersistentvolumeclaimRequest(cfg MetricConfig) metricK8sPersistentvolumeclaimRequest {
+	m := metricK8sPersistentvolumeclaimRequest{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sServiceEndpointsNotReady struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.service.endpoints.not_ready metric with initial data.
+func (m *metricK8sServiceEndpointsNotReady) init() {
+	m.data.SetName("k8s.service.endpoints.not_ready")
+	m.data.SetDescription("Number of endpoint addresses of the service that are not ready to receive traffic.")
+	m.data.SetUnit("{endpoint}")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sServiceEndpointsNotReady) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sServiceEndpointsNotReady) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sServiceEndpointsNotReady) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sServiceEndpointsNotReady(cfg MetricConfig) metricK8sServiceEndpointsNotReady {
+	m := metricK8sServiceEndpointsNotReady{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sServiceEndpointsReady struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.service.endpoints.ready metric with initial data.
+func (m *metricK8sServiceEndpointsReady) init() {
+	m.data.SetName("k8s.service.endpoints.ready")
+	m.data.SetDescription("Number of endpoint addresses of the service that are ready to receive traffic.")
+	m.data.SetUnit("{endpoint}")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sServiceEndpointsReady) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sServiceEndpointsReady) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sServiceEndpointsReady) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sServiceEndpointsReady(cfg MetricConfig) metricK8sServiceEndpointsReady {
+	m := metricK8sServiceEndpointsReady{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user config.
+type MetricsBuilder struct {
+	startTime                              pcommon.Timestamp   // start time that will be applied to all recorded data points.
+	metricsCapacity                        int                 // maximum observed number of metrics per resource.
+	resourceCapacity                       int                 // maximum observed number of resource attributes.
+	metricsBuffer                          pmetric.Metrics     // accumulates metrics data before emitting.
+	buildInfo                              component.BuildInfo // contains version information
+	resourceAttributesConfig               ResourceAttributesConfig
+	metricK8sIngressBackends               metricK8sIngressBackends
+	metricK8sPersistentvolumeCapacity      metricK8sPersistentvolumeCapacity
+	metricK8sPersistentvolumePhase         metricK8sPersistentvolumePhase
+	metricK8sPersistentvolumeclaimCapacity metricK8sPersistentvolumeclaimCapacity
+	metricK8sPersistentvolumeclaimPhase    metricK8sPersistentvolumeclaimPhase
+	metricK8sPersistentvolumeclaimRequest  metricK8sPersistentvolumeclaimRequest
+	metricK8sServiceEndpointsNotReady      metricK8sServiceEndpointsNotReady
+	metricK8sServiceEndpointsReady         metricK8sServiceEndpointsReady
+}
+
+// metricBuilderOption applies changes to default metrics builder.
+type metricBuilderOption func(*MetricsBuilder)
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) metricBuilderOption {
+	return func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	}
+}
+
+func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.CreateSettings, options ...metricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		startTime:                              pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                          pmetric.NewMetrics(),
+		buildInfo:                              settings.BuildInfo,
+		resourceAttributesConfig:               mbc.ResourceAttributes,
+		metricK8sIngressBackends:               newMetricK8sIngressBackends(mbc.Metrics.K8sIngressBackends),
+		metricK8sPersistentvolumeCapacity:      newMetricK8sPersistentvolumeCapacity(mbc.Metrics.K8sPersistentvolumeCapacity),
+		metricK8sPersistentvolumePhase:         newMetricK8sPersistentvolumePhase(mbc.Metrics.K8sPersistentvolumePhase),
+		metricK8sPersistentvolumeclaimCapacity: newMetricK8sPersistentvolumeclaimCapacity(mbc.Metrics.K8sPersistentvolumeclaimCapacity),
+		metricK8sPersistentvolumeclaimPhase:    newMetricK8sPersistentvolumeclaimPhase(mbc.Metrics.K8sPersistentvolumeclaimPhase),
+		metricK8sPersistentvolumeclaimRequest:  newMetricK8sPersistentvolumeclaimRequest(mbc.Metrics.K8sPersistentvolumeclaimRequest),
+		metricK8sServiceEndpointsNotReady:      newMetricK8sServiceEndpointsNotReady(mbc.Metrics.K8sServiceEndpointsNotReady),
+		metricK8sServiceEndpointsReady:         newMetricK8sServiceEndpointsReady(mbc.Metrics.K8sServiceEndpointsReady),
+	}
+	for _, op := range options {
+		op(mb)
+	}
+	return mb
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+	if mb.resourceCapacity < rm.Resource().Attributes().Len() {
+		mb.resourceCapacity = rm.Resource().Attributes().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption func(ResourceAttributesConfig, pmetric.ResourceMetrics)
+
+// WithK8sIngressName sets provided value as "k8s.ingress.name" attribute for current resource.
+func WithK8sIngressName(val string) ResourceMetricsOption {
+	return func(rac ResourceAttributesConfig, rm pmetric.ResourceMetrics) {
+		if rac.K8sIngressName.Enabled {
+			rm.Resource().Attributes().PutStr("k8s.ingress.name", val)
+		}
+	}
+}
+
+// WithK8sIngressUID sets provided value as "k8s.ingress.uid" attribute for current resource.
+func WithK8sIngressUID(val string) ResourceMetricsOption {
+	return func(rac ResourceAttributesConfig, rm pmetric.ResourceMetrics) {
+		if rac.K8sIngressUID.Enabled {
+			rm.Resource().Attributes().PutStr("k8s.ingress.uid", val)
+		}
+	}
+}
+
+// WithK8sNamespaceName sets provided value as "k8s.namespace.name" attribute for current resource.
+func WithK8sNamespaceName(val string) ResourceMetricsOption {
+	return func(rac ResourceAttributesConfig, rm pmetric.ResourceMetrics) {
+		if rac.K8sNamespaceName.Enabled {
+			rm.Resource().Attributes().PutStr("k8s.namespace.name", val)
+		}
+	}
+}
+
+// WithK8sPersistentvolumeName sets provided value as "k8s.persistentvolume.name" attribute for current resource.
+func WithK8sPersistentvolumeName(val string) ResourceMetricsOption {
+	return func(rac ResourceAttributesConfig, rm pmetric.ResourceMetrics) {
+		if rac.K8sPersistentvolumeName.Enabled {
+			rm.Resource().Attributes().PutStr("k8s.persistentvolume.name", val)
+		}
+	}
+}
+
+// WithK8sPersistentvolumeUID sets provided value as "k8s.persistentvolume.uid" attribute for current resource.
+func WithK8sPersistentvolumeUID(val string) ResourceMetricsOption {
+	return func(rac ResourceAttributesConfig, rm pmetric.ResourceMetrics) {
+		if rac.K8sPersistentvolumeUID.Enabled {
+			rm.Resource().Attributes().PutStr("k8s.persistentvolume.uid", val)
+		}
+	}
+}
+
+// WithK8sPersistentvolumeclaimName sets provided value as "k8s.persistentvolumeclaim.name" attribute for current resource.
+func WithK8sPersistentvolumeclaimName(val string) ResourceMetricsOption {
+	return func(rac ResourceAttributesConfig, rm pmetric.ResourceMetrics) {
+		if rac.K8sPersistentvolumeclaimName.Enabled {
+			rm.Resource().Attributes().PutStr("k8s.persistentvolumeclaim.name", val)
+		}
+	}
+}
+
+// WithK8sPersistentvolumeclaimUID sets provided value as "k8s.persistentvolumeclaim.uid" attribute for current resource.
+func WithK8sPersistentvolumeclaimUID(val string) ResourceMetricsOption {
+	return func(rac ResourceAttributesConfig, rm pmetric.ResourceMetrics) {
+		if rac.K8sPersistentvolumeclaimUID.Enabled {
+			rm.Resource().Attributes().PutStr("k8s.persistentvolumeclaim.uid", val)
+		}
+	}
+}
+
+// WithK8sServiceName sets provided value as "k8s.service.name" attribute for current resource.
+func WithK8sServiceName(val string) ResourceMetricsOption {
+	return func(rac ResourceAttributesConfig, rm pmetric.ResourceMetrics) {
+		if rac.K8sServiceName.Enabled {
+			rm.Resource().Attributes().PutStr("k8s.service.name", val)
+		}
+	}
+}
+
+// WithK8sStorageclassName sets provided value as "k8s.storageclass.name" attribute for current resource.
+func WithK8sStorageclassName(val string) ResourceMetricsOption {
+	return func(rac ResourceAttributesConfig, rm pmetric.ResourceMetrics) {
+		if rac.K8sStorageclassName.Enabled {
+			rm.Resource().Attributes().PutStr("k8s.storageclass.name", val)
+		}
+	}
+}
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return func(_ ResourceAttributesConfig, rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).Type() {
+			case pmetric.MetricTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	}
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	rm.Resource().Attributes().EnsureCapacity(mb.resourceCapacity)
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName("otelcol/k8sclusterreceiver")
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricK8sIngressBackends.emit(ils.Metrics())
+	mb.metricK8sPersistentvolumeCapacity.emit(ils.Metrics())
+	mb.metricK8sPersistentvolumePhase.emit(ils.Metrics())
+	mb.metricK8sPersistentvolumeclaimCapacity.emit(ils.Metrics())
+	mb.metricK8sPersistentvolumeclaimPhase.emit(ils.Metrics())
+	mb.metricK8sPersistentvolumeclaimRequest.emit(ils.Metrics())
+	mb.metricK8sServiceEndpointsNotReady.emit(ils.Metrics())
+	mb.metricK8sServiceEndpointsReady.emit(ils.Metrics())
+
+	for _, op := range rmo {
+		op(mb.resourceAttributesConfig, rm)
+	}
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user config, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(rmo...)
+	metrics := mb.metricsBuffer
+	mb.metricsBuffer = pmetric.NewMetrics()
+	return metrics
+}
+
+// RecordK8sIngressBackendsDataPoint adds a data point to k8s.ingress.backends metric.
+func (mb *MetricsBuilder) RecordK8sIngressBackendsDataPoint(ts pcommon.Timestamp, val int64, serviceAttributeValue string) {
+	mb.metricK8sIngressBackends.recordDataPoint(mb.startTime, ts, val, serviceAttributeValue)
+}
+
+// RecordK8sPersistentvolumeCapacityDataPoint adds a data point to k8s.persistentvolume.capacity metric.
+func (mb *MetricsBuilder) RecordK8sPersistentvolumeCapacityDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPersistentvolumeCapacity.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sPersistentvolumePhaseDataPoint adds a data point to k8s.persistentvolume.phase metric.
+func (mb *MetricsBuilder) RecordK8sPersistentvolumePhaseDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPersistentvolumePhase.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sPersistentvolumeclaimCapacityDataPoint adds a data point to k8s.persistentvolumeclaim.capacity metric.
+func (mb *MetricsBuilder) RecordK8sPersistentvolumeclaimCapacityDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPersistentvolumeclaimCapacity.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sPersistentvolumeclaimPhaseDataPoint adds a data point to k8s.persistentvolumeclaim.phase metric.
+func (mb *MetricsBuilder) RecordK8sPersistentvolumeclaimPhaseDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPersistentvolumeclaimPhase.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sPersistentvolumeclaimRequestDataPoint adds a data point to k8s.persistentvolumeclaim.request metric.
+func (mb *MetricsBuilder) RecordK8sPersistentvolumeclaimRequestDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPersistentvolumeclaimRequest.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sServiceEndpointsNotReadyDataPoint adds a data point to k8s.service.endpoints.not_ready metric.
+func (mb *MetricsBuilder) RecordK8sServiceEndpointsNotReadyDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sServiceEndpointsNotReady.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sServiceEndpointsReadyDataPoint adds a data point to k8s.service.endpoints.ready metric.
+func (mb *MetricsBuilder) RecordK8sServiceEndpointsReadyDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sServiceEndpointsReady.recordDataPoint(mb.startTime, ts, val)
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op(mb)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentvolume // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/persistentvolume"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+)
+
+// GetMetrics returns metrics for a given persistent volume.
+func GetMetrics(set receiver.CreateSettings, metricsBuilderConfig metadata.MetricsBuilderConfig, pv *corev1.PersistentVolume) pmetric.Metrics {
+	mb := metadata.NewMetricsBuilder(metricsBuilderConfig, set)
+	ts := pcommon.NewTimestampFromTime(time.Now())
+
+	mb.RecordK8sPersistentvolumePhaseDataPoint(ts, phaseToInt(pv.Status.Phase))
+	if capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
+		mb.RecordK8sPersistentvolumeCapacityDataPoint(ts, capacity.Value())
+	}
+
+	rmo := []metadata.ResourceMetricsOption{
+		metadata.WithK8sPersistentvolumeName(pv.Name),
+		metadata.WithK8sPersistentvolumeUID(string(pv.UID)),
+	}
+	if pv.Spec.StorageClassName != "" {
+		rmo = append(rmo, metadata.WithK8sStorageclassName(pv.Spec.StorageClassName))
+	}
+	return mb.Emit(rmo...)
+}
+
+func phaseToInt(phase corev1.PersistentVolumePhase) int64 {
+	switch phase {
+	case corev1.VolumePending:
+		return 1
+	case corev1.VolumeAvailable:
+		return 2
+	case corev1.VolumeBound:
+		return 3
+	case corev1.VolumeReleased:
+		return 4
+	case corev1.VolumeFailed:
+		return 5
+	default:
+		return 1
+	}
+}
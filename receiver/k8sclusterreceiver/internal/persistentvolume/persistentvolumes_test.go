@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentvolume
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+)
+
+func TestPersistentVolumeMetrics(t *testing.T) {
+	pv := newPersistentVolume("1")
+
+	md := GetMetrics(receivertest.NewNopCreateSettings(), metadata.DefaultMetricsBuilderConfig(), pv)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	require.Equal(t,
+		map[string]interface{}{
+			"k8s.persistentvolume.name": "test-pv-1",
+			"k8s.persistentvolume.uid":  "test-pv-1-uid",
+			"k8s.storageclass.name":     "standard",
+		},
+		rm.Resource().Attributes().AsRaw(),
+	)
+
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	values := map[string]int64{}
+	for i := 0; i < ms.Len(); i++ {
+		values[ms.At(i).Name()] = ms.At(i).Gauge().DataPoints().At(0).IntValue()
+	}
+	require.Equal(t,
+		map[string]int64{
+			"k8s.persistentvolume.phase":    4,
+			"k8s.persistentvolume.capacity": 5 * 1024 * 1024 * 1024,
+		},
+		values,
+	)
+}
+
+func TestPhaseToInt(t *testing.T) {
+	for phase, expected := range map[corev1.PersistentVolumePhase]int64{
+		corev1.VolumePending:   1,
+		corev1.VolumeAvailable: 2,
+		corev1.VolumeBound:     3,
+		corev1.VolumeReleased:  4,
+		corev1.VolumeFailed:    5,
+	} {
+		require.Equal(t, expected, phaseToInt(phase), phase)
+	}
+}
+
+func newPersistentVolume(id string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test-pv-" + id,
+			UID:  types.UID("test-pv-" + id + "-uid"),
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			StorageClassName: "standard",
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("5Gi"),
+			},
+		},
+		Status: corev1.PersistentVolumeStatus{
+			Phase: corev1.VolumeReleased,
+		},
+	}
+}
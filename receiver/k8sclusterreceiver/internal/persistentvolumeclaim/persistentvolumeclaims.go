@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentvolumeclaim // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/persistentvolumeclaim"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+)
+
+// GetMetrics returns metrics for a given persistent volume claim.
+func GetMetrics(set receiver.CreateSettings, metricsBuilderConfig metadata.MetricsBuilderConfig, pvc *corev1.PersistentVolumeClaim) pmetric.Metrics {
+	mb := metadata.NewMetricsBuilder(metricsBuilderConfig, set)
+	ts := pcommon.NewTimestampFromTime(time.Now())
+
+	mb.RecordK8sPersistentvolumeclaimPhaseDataPoint(ts, phaseToInt(pvc.Status.Phase))
+	if request, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		mb.RecordK8sPersistentvolumeclaimRequestDataPoint(ts, request.Value())
+	}
+	if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		mb.RecordK8sPersistentvolumeclaimCapacityDataPoint(ts, capacity.Value())
+	}
+
+	rmo := []metadata.ResourceMetricsOption{
+		metadata.WithK8sNamespaceName(pvc.Namespace),
+		metadata.WithK8sPersistentvolumeclaimName(pvc.Name),
+		metadata.WithK8sPersistentvolumeclaimUID(string(pvc.UID)),
+	}
+	if pvc.Spec.VolumeName != "" {
+		rmo = append(rmo, metadata.WithK8sPersistentvolumeName(pvc.Spec.VolumeName))
+	}
+	return mb.Emit(rmo...)
+}
+
+func phaseToInt(phase corev1.PersistentVolumeClaimPhase) int64 {
+	switch phase {
+	case corev1.ClaimPending:
+		return 1
+	case corev1.ClaimBound:
+		return 2
+	case corev1.ClaimLost:
+		return 3
+	default:
+		return 1
+	}
+}
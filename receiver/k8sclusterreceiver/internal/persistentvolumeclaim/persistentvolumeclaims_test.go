@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentvolumeclaim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+)
+
+func TestPersistentVolumeClaimMetrics(t *testing.T) {
+	pvc := newPersistentVolumeClaim("1")
+
+	md := GetMetrics(receivertest.NewNopCreateSettings(), metadata.DefaultMetricsBuilderConfig(), pvc)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	require.Equal(t,
+		map[string]interface{}{
+			"k8s.namespace.name":             "test-namespace",
+			"k8s.persistentvolumeclaim.name": "test-pvc-1",
+			"k8s.persistentvolumeclaim.uid":  "test-pvc-1-uid",
+			"k8s.persistentvolume.name":      "test-pv-1",
+		},
+		rm.Resource().Attributes().AsRaw(),
+	)
+
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, ms.Len())
+	values := map[string]int64{}
+	for i := 0; i < ms.Len(); i++ {
+		values[ms.At(i).Name()] = ms.At(i).Gauge().DataPoints().At(0).IntValue()
+	}
+	require.Equal(t,
+		map[string]int64{
+			"k8s.persistentvolumeclaim.phase":    2,
+			"k8s.persistentvolumeclaim.request":  10 * 1024 * 1024 * 1024,
+			"k8s.persistentvolumeclaim.capacity": 20 * 1024 * 1024 * 1024,
+		},
+		values,
+	)
+}
+
+func TestPendingPersistentVolumeClaimMetrics(t *testing.T) {
+	pvc := newPersistentVolumeClaim("1")
+	pvc.Spec.VolumeName = ""
+	pvc.Status = corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending}
+
+	md := GetMetrics(receivertest.NewNopCreateSettings(), metadata.DefaultMetricsBuilderConfig(), pvc)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	_, ok := rm.Resource().Attributes().Get("k8s.persistentvolume.name")
+	require.False(t, ok)
+
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	for i := 0; i < ms.Len(); i++ {
+		require.NotEqual(t, "k8s.persistentvolumeclaim.capacity", ms.At(i).Name())
+		if ms.At(i).Name() == "k8s.persistentvolumeclaim.phase" {
+			require.Equal(t, int64(1), ms.At(i).Gauge().DataPoints().At(0).IntValue())
+		}
+	}
+}
+
+func newPersistentVolumeClaim(id string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test-pvc-" + id,
+			UID:       types.UID("test-pvc-" + id + "-uid"),
+			Namespace: "test-namespace",
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			VolumeName: "test-pv-" + id,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10Gi"),
+				},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase: corev1.ClaimBound,
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("20Gi"),
+			},
+		},
+	}
+}
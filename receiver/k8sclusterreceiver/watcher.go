@@ -13,6 +13,7 @@ import (
 	quotaclientset "github.com/openshift/client-go/quota/clientset/versioned"
 	quotainformersv1 "github.com/openshift/client-go/quota/informers/externalversions"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/zap"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -53,10 +54,10 @@ type resourceWatcher struct {
 type metadataConsumer func(metadata []*experimentalmetricmetadata.MetadataUpdate) error
 
 // newResourceWatcher creates a Kubernetes resource watcher.
-func newResourceWatcher(logger *zap.Logger, cfg *Config) *resourceWatcher {
+func newResourceWatcher(set receiver.CreateSettings, cfg *Config) *resourceWatcher {
 	return &resourceWatcher{
-		logger:                   logger,
-		dataCollector:            collection.NewDataCollector(logger, cfg.NodeConditionTypesToReport, cfg.AllocatableTypesToReport),
+		logger:                   set.Logger,
+		dataCollector:            collection.NewDataCollector(set, cfg.MetricsBuilderConfig, cfg.NodeConditionTypesToReport, cfg.AllocatableTypesToReport),
 		initialSyncDone:          &atomic.Bool{},
 		initialSyncTimedOut:      &atomic.Bool{},
 		initialTimeout:           defaultInitialSyncTimeout,
@@ -102,6 +103,9 @@ func (rw *resourceWatcher) prepareSharedInformerFactory() error {
 		"ReplicationController":   {gvk.ReplicationController},
 		"ResourceQuota":           {gvk.ResourceQuota},
 		"Service":                 {gvk.Service},
+		"Endpoints":               {gvk.Endpoints},
+		"PersistentVolume":        {gvk.PersistentVolume},
+		"PersistentVolumeClaim":   {gvk.PersistentVolumeClaim},
 		"DaemonSet":               {gvk.DaemonSet},
 		"Deployment":              {gvk.Deployment},
 		"ReplicaSet":              {gvk.ReplicaSet},
@@ -109,6 +113,7 @@ func (rw *resourceWatcher) prepareSharedInformerFactory() error {
 		"Job":                     {gvk.Job},
 		"CronJob":                 {gvk.CronJob, gvk.CronJobBeta},
 		"HorizontalPodAutoscaler": {gvk.HorizontalPodAutoscaler, gvk.HorizontalPodAutoscalerBeta},
+		"Ingress":                 {gvk.Ingress},
 	}
 
 	for kind, gvks := range supportedKinds {
@@ -171,6 +176,12 @@ func (rw *resourceWatcher) setupInformerForKind(kind schema.GroupVersionKind, fa
 		rw.setupInformer(kind, factory.Core().V1().ResourceQuotas().Informer())
 	case gvk.Service:
 		rw.setupInformer(kind, factory.Core().V1().Services().Informer())
+	case gvk.Endpoints:
+		rw.setupInformer(kind, factory.Core().V1().Endpoints().Informer())
+	case gvk.PersistentVolume:
+		rw.setupInformer(kind, factory.Core().V1().PersistentVolumes().Informer())
+	case gvk.PersistentVolumeClaim:
+		rw.setupInformer(kind, factory.Core().V1().PersistentVolumeClaims().Informer())
 	case gvk.DaemonSet:
 		rw.setupInformer(kind, factory.Apps().V1().DaemonSets().Informer())
 	case gvk.Deployment:
@@ -189,6 +200,8 @@ func (rw *resourceWatcher) setupInformerForKind(kind schema.GroupVersionKind, fa
 		rw.setupInformer(kind, factory.Autoscaling().V2().HorizontalPodAutoscalers().Informer())
 	case gvk.HorizontalPodAutoscalerBeta:
 		rw.setupInformer(kind, factory.Autoscaling().V2beta2().HorizontalPodAutoscalers().Informer())
+	case gvk.Ingress:
+		rw.setupInformer(kind, factory.Networking().V1().Ingresses().Informer())
 	default:
 		rw.logger.Error("Could not setup an informer for provided group version kind",
 			zap.String("group version kind", kind.String()))
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/collection"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/gvk"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
 )
 
 func TestSetupMetadataExporters(t *testing.T) {
@@ -146,6 +148,9 @@ func TestPrepareSharedInformerFactory(t *testing.T) {
 							gvkToAPIResource(gvk.ReplicationController),
 							gvkToAPIResource(gvk.ResourceQuota),
 							gvkToAPIResource(gvk.Service),
+							gvkToAPIResource(gvk.Endpoints),
+							gvkToAPIResource(gvk.PersistentVolume),
+							gvkToAPIResource(gvk.PersistentVolumeClaim),
 						},
 					},
 					{
@@ -181,6 +186,12 @@ func TestPrepareSharedInformerFactory(t *testing.T) {
 							gvkToAPIResource(gvk.HorizontalPodAutoscalerBeta),
 						},
 					},
+					{
+						GroupVersion: "networking.k8s.io/v1",
+						APIResources: []metav1.APIResource{
+							gvkToAPIResource(gvk.Ingress),
+						},
+					},
 				}
 				return client
 			}(),
@@ -194,7 +205,7 @@ func TestPrepareSharedInformerFactory(t *testing.T) {
 			rw := &resourceWatcher{
 				client:        newFakeClientWithAllResources(),
 				logger:        obsLogger,
-				dataCollector: collection.NewDataCollector(zap.NewNop(), []string{}, []string{}),
+				dataCollector: collection.NewDataCollector(receivertest.NewNopCreateSettings(), metadata.DefaultMetricsBuilderConfig(), []string{}, []string{}),
 			}
 
 			assert.NoError(t, rw.prepareSharedInformerFactory())
@@ -4,6 +4,7 @@
 package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -60,6 +61,10 @@ type Config struct {
 
 	// Controls the way the messages are marked as consumed
 	MessageMarking MessageMarking `mapstructure:"message_marking"`
+
+	// The name of the kafka topic that messages failing permanently are published to,
+	// so that they do not block their partition. Disabled when empty.
+	DeadLetterTopic string `mapstructure:"dead_letter_topic"`
 }
 
 const (
@@ -71,5 +76,8 @@ var _ component.Config = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.DeadLetterTopic != "" && cfg.DeadLetterTopic == cfg.Topic {
+		return errors.New("dead_letter_topic must be different from topic")
+	}
 	return nil
 }
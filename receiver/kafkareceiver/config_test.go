@@ -86,6 +86,7 @@ func TestLoadConfig(t *testing.T) {
 						Backoff: time.Second * 5,
 					},
 				},
+				DeadLetterTopic: "logs_dead_letter",
 				AutoCommit: AutoCommit{
 					Enable:   true,
 					Interval: 1 * time.Second,
@@ -108,3 +109,12 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig_deadLetterTopic(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DeadLetterTopic = "otlp_spans_dead_letter"
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.DeadLetterTopic = cfg.Topic
+	assert.EqualError(t, component.ValidateConfig(cfg), "dead_letter_topic must be different from topic")
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Shopify/sarama"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+)
+
+// Headers added to the messages published to the dead letter topic,
+// they record where the message comes from and why it failed.
+const (
+	deadLetterHeaderTopic     = "otel_dead_letter_topic"
+	deadLetterHeaderPartition = "otel_dead_letter_partition"
+	deadLetterHeaderOffset    = "otel_dead_letter_offset"
+	deadLetterHeaderError     = "otel_dead_letter_error"
+)
+
+// deadLetterQueue publishes the messages whose processing failed permanently to a dedicated topic.
+type deadLetterQueue struct {
+	topic       string
+	newProducer func() (sarama.SyncProducer, error)
+	producer    sarama.SyncProducer
+}
+
+// newDeadLetterQueue returns nil when no dead letter topic is configured, the
+// producer shares the brokers and the client configuration of the consumer.
+func newDeadLetterQueue(config Config, c *sarama.Config) *deadLetterQueue {
+	if config.DeadLetterTopic == "" {
+		return nil
+	}
+	return &deadLetterQueue{
+		topic: config.DeadLetterTopic,
+		newProducer: func() (sarama.SyncProducer, error) {
+			producerConfig := *c
+			producerConfig.Producer.Return.Successes = true
+			producerConfig.Producer.RequiredAcks = sarama.WaitForAll
+			return sarama.NewSyncProducer(config.Brokers, &producerConfig)
+		},
+	}
+}
+
+func (d *deadLetterQueue) start() error {
+	if d == nil {
+		return nil
+	}
+	producer, err := d.newProducer()
+	if err != nil {
+		return fmt.Errorf("failed to create the dead letter producer: %w", err)
+	}
+	d.producer = producer
+	return nil
+}
+
+func (d *deadLetterQueue) shutdown() error {
+	if d == nil || d.producer == nil {
+		return nil
+	}
+	return d.producer.Close()
+}
+
+func (d *deadLetterQueue) publish(message *sarama.ConsumerMessage, cause error) error {
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+4)
+	for _, header := range message.Headers {
+		if header != nil {
+			headers = append(headers, *header)
+		}
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(deadLetterHeaderTopic), Value: []byte(message.Topic)},
+		sarama.RecordHeader{Key: []byte(deadLetterHeaderPartition), Value: []byte(strconv.FormatInt(int64(message.Partition), 10))},
+		sarama.RecordHeader{Key: []byte(deadLetterHeaderOffset), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+		sarama.RecordHeader{Key: []byte(deadLetterHeaderError), Value: []byte(cause.Error())},
+	)
+	msg := &sarama.ProducerMessage{
+		Topic:   d.topic,
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}
+	if message.Key != nil {
+		msg.Key = sarama.ByteEncoder(message.Key)
+	}
+	_, _, err := d.producer.SendMessage(msg)
+	return err
+}
+
+// messageErrorHandler decides what happens to a message whose processing failed.
+type messageErrorHandler struct {
+	logger            *zap.Logger
+	statsTags         []tag.Mutator
+	autocommitEnabled bool
+	messageMarking    MessageMarking
+	deadLetter        *deadLetterQueue
+}
+
+// handle returns nil when the consumption of the claim can go on with the next message.
+// Permanent errors are published to the dead letter topic when one is configured and the
+// message is marked, other errors leave the message unmarked unless message_marking.on_error
+// is set so that it is consumed again.
+func (h messageErrorHandler) handle(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage, err error) error {
+	if h.deadLetter != nil && consumererror.IsPermanent(err) {
+		dlqErr := h.deadLetter.publish(message, err)
+		if dlqErr == nil {
+			_ = stats.RecordWithTags(session.Context(), h.statsTags, statDeadLetterMessages.M(1))
+			session.MarkMessage(message, "")
+			if !h.autocommitEnabled {
+				session.Commit()
+			}
+			return nil
+		}
+		h.logger.Error("failed to publish message to the dead letter topic",
+			zap.String("topic", h.deadLetter.topic), zap.Error(dlqErr))
+	}
+	if h.messageMarking.After && h.messageMarking.OnError {
+		session.MarkMessage(message, "")
+	}
+	return err
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+)
+
+func TestNewDeadLetterQueue_disabled(t *testing.T) {
+	dlq := newDeadLetterQueue(Config{}, sarama.NewConfig())
+	assert.Nil(t, dlq)
+	assert.NoError(t, dlq.start())
+	assert.NoError(t, dlq.shutdown())
+}
+
+func TestDeadLetterQueuePublish(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		assert.Equal(t, "dead_letter", msg.Topic)
+		assert.Equal(t, sarama.ByteEncoder("key"), msg.Key)
+		assert.Equal(t, sarama.ByteEncoder("value"), msg.Value)
+		assert.Equal(t, []sarama.RecordHeader{
+			{Key: []byte("origin"), Value: []byte("test")},
+			{Key: []byte(deadLetterHeaderTopic), Value: []byte("spans")},
+			{Key: []byte(deadLetterHeaderPartition), Value: []byte("3")},
+			{Key: []byte(deadLetterHeaderOffset), Value: []byte("42")},
+			{Key: []byte(deadLetterHeaderError), Value: []byte("Permanent error: bad message")},
+		}, msg.Headers)
+		return nil
+	})
+	dlq := &deadLetterQueue{
+		topic:       "dead_letter",
+		newProducer: func() (sarama.SyncProducer, error) { return producer, nil },
+	}
+	require.NoError(t, dlq.start())
+
+	err := dlq.publish(&sarama.ConsumerMessage{
+		Topic:     "spans",
+		Partition: 3,
+		Offset:    42,
+		Key:       []byte("key"),
+		Value:     []byte("value"),
+		Headers:   []*sarama.RecordHeader{{Key: []byte("origin"), Value: []byte("test")}},
+	}, consumererror.NewPermanent(errors.New("bad message")))
+	require.NoError(t, err)
+	require.NoError(t, dlq.shutdown())
+}
+
+func TestDeadLetterQueueStart_error(t *testing.T) {
+	dlq := &deadLetterQueue{
+		topic:       "dead_letter",
+		newProducer: func() (sarama.SyncProducer, error) { return nil, errors.New("no brokers") },
+	}
+	assert.EqualError(t, dlq.start(), "failed to create the dead letter producer: no brokers")
+	assert.NoError(t, dlq.shutdown())
+}
+
+func TestMessageErrorHandler(t *testing.T) {
+	permanentErr := consumererror.NewPermanent(errors.New("permanent"))
+	retryableErr := errors.New("retryable")
+	tests := []struct {
+		name           string
+		err            error
+		messageMarking MessageMarking
+		deadLetter     func(producer *mocks.SyncProducer)
+		expectedErr    error
+		expectedMarked bool
+	}{
+		{
+			name:        "no dead letter topic",
+			err:         permanentErr,
+			expectedErr: permanentErr,
+		},
+		{
+			name:           "no dead letter topic marked on error",
+			err:            retryableErr,
+			messageMarking: MessageMarking{After: true, OnError: true},
+			expectedErr:    retryableErr,
+			expectedMarked: true,
+		},
+		{
+			name:           "permanent error published",
+			err:            permanentErr,
+			messageMarking: MessageMarking{After: true},
+			deadLetter: func(producer *mocks.SyncProducer) {
+				producer.ExpectSendMessageAndSucceed()
+			},
+			expectedMarked: true,
+		},
+		{
+			name:           "retryable error not published",
+			err:            retryableErr,
+			messageMarking: MessageMarking{After: true},
+			deadLetter:     func(producer *mocks.SyncProducer) {},
+			expectedErr:    retryableErr,
+		},
+		{
+			name:           "dead letter publish failure",
+			err:            permanentErr,
+			messageMarking: MessageMarking{After: true},
+			deadLetter: func(producer *mocks.SyncProducer) {
+				producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+			},
+			expectedErr: permanentErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := messageErrorHandler{
+				logger:         zap.NewNop(),
+				messageMarking: tt.messageMarking,
+			}
+			if tt.deadLetter != nil {
+				producer := mocks.NewSyncProducer(t, nil)
+				tt.deadLetter(producer)
+				h.deadLetter = &deadLetterQueue{topic: "dead_letter", producer: producer}
+				defer func() {
+					require.NoError(t, producer.Close())
+				}()
+			}
+			session := &markingConsumerGroupSession{testConsumerGroupSession: testConsumerGroupSession{ctx: context.Background()}}
+
+			err := h.handle(session, &sarama.ConsumerMessage{Value: []byte("value")}, tt.err)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedMarked, len(session.marked) == 1)
+		})
+	}
+}
+
+func TestTracesConsumerGroupHandler_dead_letter(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	producer := mocks.NewSyncProducer(t, nil)
+	producer.ExpectSendMessageAndSucceed()
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverCreateSettings: receivertest.NewNopCreateSettings()})
+	require.NoError(t, err)
+	c := tracesConsumerGroupHandler{
+		unmarshaler:    newPdataTracesUnmarshaler(&ptrace.ProtoUnmarshaler{}, defaultEncoding),
+		logger:         zap.NewNop(),
+		ready:          make(chan bool),
+		nextConsumer:   consumertest.NewNop(),
+		obsrecv:        obsrecv,
+		messageMarking: MessageMarking{After: true},
+		deadLetter:     &deadLetterQueue{topic: "dead_letter", producer: producer},
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	session := &markingConsumerGroupSession{testConsumerGroupSession: testConsumerGroupSession{ctx: context.Background()}}
+	go func() {
+		// the claim goes on after the message that cannot be unmarshaled
+		assert.NoError(t, c.ConsumeClaim(session, groupClaim))
+		wg.Done()
+	}()
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Partition: testPartition, Value: []byte("!@#")}
+	close(groupClaim.messageChan)
+	wg.Wait()
+
+	assert.Len(t, session.marked, 1)
+	viewData, err := view.RetrieveData(statDeadLetterMessages.Name())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(viewData))
+	assert.Equal(t, float64(1), viewData[0].Data.(*view.SumData).Value)
+}
+
+// markingConsumerGroupSession records the messages that are marked.
+type markingConsumerGroupSession struct {
+	testConsumerGroupSession
+	mu     sync.Mutex
+	marked []*sarama.ConsumerMessage
+}
+
+func (s *markingConsumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marked = append(s.marked, msg)
+}
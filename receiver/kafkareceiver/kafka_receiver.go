@@ -6,6 +6,7 @@ package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collect
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -14,8 +15,10 @@ import (
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
@@ -40,6 +43,7 @@ type kafkaTracesConsumer struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	deadLetter        *deadLetterQueue
 }
 
 // kafkaMetricsConsumer uses sarama to consume and handle messages from kafka.
@@ -54,6 +58,7 @@ type kafkaMetricsConsumer struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	deadLetter        *deadLetterQueue
 }
 
 // kafkaLogsConsumer uses sarama to consume and handle messages from kafka.
@@ -68,6 +73,7 @@ type kafkaLogsConsumer struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	deadLetter        *deadLetterQueue
 }
 
 var _ receiver.Traces = (*kafkaTracesConsumer)(nil)
@@ -114,10 +120,14 @@ func newTracesReceiver(config Config, set receiver.CreateSettings, unmarshalers
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
+		deadLetter:        newDeadLetterQueue(config, c),
 	}, nil
 }
 
 func (c *kafkaTracesConsumer) Start(_ context.Context, host component.Host) error {
+	if err := c.deadLetter.start(); err != nil {
+		return err
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancelConsumeLoop = cancel
 	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
@@ -136,6 +146,7 @@ func (c *kafkaTracesConsumer) Start(_ context.Context, host component.Host) erro
 		obsrecv:           obsrecv,
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
+		deadLetter:        c.deadLetter,
 	}
 	go func() {
 		if err := c.consumeLoop(ctx, consumerGroup); err != nil {
@@ -164,7 +175,7 @@ func (c *kafkaTracesConsumer) consumeLoop(ctx context.Context, handler sarama.Co
 
 func (c *kafkaTracesConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	return multierr.Append(c.consumerGroup.Close(), c.deadLetter.shutdown())
 }
 
 func newMetricsReceiver(config Config, set receiver.CreateSettings, unmarshalers map[string]MetricsUnmarshaler, nextConsumer consumer.Metrics) (*kafkaMetricsConsumer, error) {
@@ -207,10 +218,14 @@ func newMetricsReceiver(config Config, set receiver.CreateSettings, unmarshalers
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
+		deadLetter:        newDeadLetterQueue(config, c),
 	}, nil
 }
 
 func (c *kafkaMetricsConsumer) Start(_ context.Context, host component.Host) error {
+	if err := c.deadLetter.start(); err != nil {
+		return err
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancelConsumeLoop = cancel
 	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
@@ -229,6 +244,7 @@ func (c *kafkaMetricsConsumer) Start(_ context.Context, host component.Host) err
 		obsrecv:           obsrecv,
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
+		deadLetter:        c.deadLetter,
 	}
 	go func() {
 		if err := c.consumeLoop(ctx, metricsConsumerGroup); err != nil {
@@ -257,7 +273,7 @@ func (c *kafkaMetricsConsumer) consumeLoop(ctx context.Context, handler sarama.C
 
 func (c *kafkaMetricsConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	return multierr.Append(c.consumerGroup.Close(), c.deadLetter.shutdown())
 }
 
 func newLogsReceiver(config Config, set receiver.CreateSettings, unmarshalers map[string]LogsUnmarshaler, nextConsumer consumer.Logs) (*kafkaLogsConsumer, error) {
@@ -300,6 +316,7 @@ func newLogsReceiver(config Config, set receiver.CreateSettings, unmarshalers ma
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
+		deadLetter:        newDeadLetterQueue(config, c),
 	}, nil
 }
 
@@ -331,6 +348,9 @@ func getLogsUnmarshaler(encoding string, unmarshalers map[string]LogsUnmarshaler
 }
 
 func (c *kafkaLogsConsumer) Start(_ context.Context, host component.Host) error {
+	if err := c.deadLetter.start(); err != nil {
+		return err
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancelConsumeLoop = cancel
 	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
@@ -350,6 +370,7 @@ func (c *kafkaLogsConsumer) Start(_ context.Context, host component.Host) error
 		obsrecv:           obsrecv,
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
+		deadLetter:        c.deadLetter,
 	}
 	go func() {
 		if err := c.consumeLoop(ctx, logsConsumerGroup); err != nil {
@@ -378,7 +399,7 @@ func (c *kafkaLogsConsumer) consumeLoop(ctx context.Context, handler sarama.Cons
 
 func (c *kafkaLogsConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	return multierr.Append(c.consumerGroup.Close(), c.deadLetter.shutdown())
 }
 
 type tracesConsumerGroupHandler struct {
@@ -394,6 +415,7 @@ type tracesConsumerGroupHandler struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	deadLetter        *deadLetterQueue
 }
 
 type metricsConsumerGroupHandler struct {
@@ -409,6 +431,7 @@ type metricsConsumerGroupHandler struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	deadLetter        *deadLetterQueue
 }
 
 type logsConsumerGroupHandler struct {
@@ -424,6 +447,7 @@ type logsConsumerGroupHandler struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	deadLetter        *deadLetterQueue
 }
 
 var _ sarama.ConsumerGroupHandler = (*tracesConsumerGroupHandler)(nil)
@@ -450,6 +474,17 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 	if !c.autocommitEnabled {
 		defer session.Commit()
 	}
+	statsTags := []tag.Mutator{
+		tag.Upsert(tagInstanceName, c.id.String()),
+		tag.Upsert(tagPartition, strconv.Itoa(int(claim.Partition()))),
+	}
+	errorHandler := messageErrorHandler{
+		logger:            c.logger,
+		statsTags:         statsTags,
+		autocommitEnabled: c.autocommitEnabled,
+		messageMarking:    c.messageMarking,
+		deadLetter:        c.deadLetter,
+	}
 	for {
 		select {
 		case message, ok := <-claim.Messages():
@@ -465,7 +500,6 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 			}
 
 			ctx := c.obsrecv.StartTracesOp(session.Context())
-			statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())}
 			_ = stats.RecordWithTags(ctx, statsTags,
 				statMessageCount.M(1),
 				statMessageOffset.M(message.Offset),
@@ -474,20 +508,21 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 			traces, err := c.unmarshaler.Unmarshal(message.Value)
 			if err != nil {
 				c.logger.Error("failed to unmarshal message", zap.Error(err))
-				if c.messageMarking.After && c.messageMarking.OnError {
-					session.MarkMessage(message, "")
+				// the message would fail the same way every time it is consumed
+				if err = errorHandler.handle(session, message, consumererror.NewPermanent(err)); err != nil {
+					return err
 				}
-				return err
+				continue
 			}
 
 			spanCount := traces.SpanCount()
 			err = c.nextConsumer.ConsumeTraces(session.Context(), traces)
 			c.obsrecv.EndTracesOp(ctx, c.unmarshaler.Encoding(), spanCount, err)
 			if err != nil {
-				if c.messageMarking.After && c.messageMarking.OnError {
-					session.MarkMessage(message, "")
+				if err = errorHandler.handle(session, message, err); err != nil {
+					return err
 				}
-				return err
+				continue
 			}
 			if c.messageMarking.After {
 				session.MarkMessage(message, "")
@@ -525,6 +560,17 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 	if !c.autocommitEnabled {
 		defer session.Commit()
 	}
+	statsTags := []tag.Mutator{
+		tag.Upsert(tagInstanceName, c.id.String()),
+		tag.Upsert(tagPartition, strconv.Itoa(int(claim.Partition()))),
+	}
+	errorHandler := messageErrorHandler{
+		logger:            c.logger,
+		statsTags:         statsTags,
+		autocommitEnabled: c.autocommitEnabled,
+		messageMarking:    c.messageMarking,
+		deadLetter:        c.deadLetter,
+	}
 	for {
 		select {
 		case message, ok := <-claim.Messages():
@@ -540,7 +586,6 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 			}
 
 			ctx := c.obsrecv.StartMetricsOp(session.Context())
-			statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())}
 			_ = stats.RecordWithTags(ctx, statsTags,
 				statMessageCount.M(1),
 				statMessageOffset.M(message.Offset),
@@ -549,20 +594,21 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 			metrics, err := c.unmarshaler.Unmarshal(message.Value)
 			if err != nil {
 				c.logger.Error("failed to unmarshal message", zap.Error(err))
-				if c.messageMarking.After && c.messageMarking.OnError {
-					session.MarkMessage(message, "")
+				// the message would fail the same way every time it is consumed
+				if err = errorHandler.handle(session, message, consumererror.NewPermanent(err)); err != nil {
+					return err
 				}
-				return err
+				continue
 			}
 
 			dataPointCount := metrics.DataPointCount()
 			err = c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
 			c.obsrecv.EndMetricsOp(ctx, c.unmarshaler.Encoding(), dataPointCount, err)
 			if err != nil {
-				if c.messageMarking.After && c.messageMarking.OnError {
-					session.MarkMessage(message, "")
+				if err = errorHandler.handle(session, message, err); err != nil {
+					return err
 				}
-				return err
+				continue
 			}
 			if c.messageMarking.After {
 				session.MarkMessage(message, "")
@@ -604,6 +650,17 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 	if !c.autocommitEnabled {
 		defer session.Commit()
 	}
+	statsTags := []tag.Mutator{
+		tag.Upsert(tagInstanceName, c.id.String()),
+		tag.Upsert(tagPartition, strconv.Itoa(int(claim.Partition()))),
+	}
+	errorHandler := messageErrorHandler{
+		logger:            c.logger,
+		statsTags:         statsTags,
+		autocommitEnabled: c.autocommitEnabled,
+		messageMarking:    c.messageMarking,
+		deadLetter:        c.deadLetter,
+	}
 	for {
 		select {
 		case message, ok := <-claim.Messages():
@@ -619,9 +676,7 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 			}
 
 			ctx := c.obsrecv.StartLogsOp(session.Context())
-			_ = stats.RecordWithTags(
-				ctx,
-				[]tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())},
+			_ = stats.RecordWithTags(ctx, statsTags,
 				statMessageCount.M(1),
 				statMessageOffset.M(message.Offset),
 				statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
@@ -629,20 +684,21 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 			logs, err := c.unmarshaler.Unmarshal(message.Value)
 			if err != nil {
 				c.logger.Error("failed to unmarshal message", zap.Error(err))
-				if c.messageMarking.After && c.messageMarking.OnError {
-					session.MarkMessage(message, "")
+				// the message would fail the same way every time it is consumed
+				if err = errorHandler.handle(session, message, consumererror.NewPermanent(err)); err != nil {
+					return err
 				}
-				return err
+				continue
 			}
 
 			err = c.nextConsumer.ConsumeLogs(session.Context(), logs)
 			// TODO
 			c.obsrecv.EndLogsOp(ctx, c.unmarshaler.Encoding(), logs.LogRecordCount(), err)
 			if err != nil {
-				if c.messageMarking.After && c.messageMarking.OnError {
-					session.MarkMessage(message, "")
+				if err = errorHandler.handle(session, message, err); err != nil {
+					return err
 				}
-				return err
+				continue
 			}
 			if c.messageMarking.After {
 				session.MarkMessage(message, "")
@@ -11,6 +11,7 @@ import (
 
 var (
 	tagInstanceName, _ = tag.NewKey("name")
+	tagPartition, _    = tag.NewKey("partition")
 
 	statMessageCount     = stats.Int64("kafka_receiver_messages", "Number of received messages", stats.UnitDimensionless)
 	statMessageOffset    = stats.Int64("kafka_receiver_current_offset", "Current message offset", stats.UnitDimensionless)
@@ -18,11 +19,14 @@ var (
 
 	statPartitionStart = stats.Int64("kafka_receiver_partition_start", "Number of started partitions", stats.UnitDimensionless)
 	statPartitionClose = stats.Int64("kafka_receiver_partition_close", "Number of finished partitions", stats.UnitDimensionless)
+
+	statDeadLetterMessages = stats.Int64("kafka_receiver_dead_letter_messages", "Number of messages published to the dead letter topic", stats.UnitDimensionless)
 )
 
 // MetricViews return metric views for Kafka receiver.
 func MetricViews() []*view.View {
 	tagKeys := []tag.Key{tagInstanceName}
+	partitionTagKeys := []tag.Key{tagInstanceName, tagPartition}
 
 	countMessages := &view.View{
 		Name:        statMessageCount.Name(),
@@ -36,7 +40,7 @@ func MetricViews() []*view.View {
 		Name:        statMessageOffset.Name(),
 		Measure:     statMessageOffset,
 		Description: statMessageOffset.Description(),
-		TagKeys:     tagKeys,
+		TagKeys:     partitionTagKeys,
 		Aggregation: view.LastValue(),
 	}
 
@@ -44,7 +48,7 @@ func MetricViews() []*view.View {
 		Name:        statMessageOffsetLag.Name(),
 		Measure:     statMessageOffsetLag,
 		Description: statMessageOffsetLag.Description(),
-		TagKeys:     tagKeys,
+		TagKeys:     partitionTagKeys,
 		Aggregation: view.LastValue(),
 	}
 
@@ -64,11 +68,20 @@ func MetricViews() []*view.View {
 		Aggregation: view.Sum(),
 	}
 
+	countDeadLetterMessages := &view.View{
+		Name:        statDeadLetterMessages.Name(),
+		Measure:     statDeadLetterMessages,
+		Description: statDeadLetterMessages.Description(),
+		TagKeys:     partitionTagKeys,
+		Aggregation: view.Sum(),
+	}
+
 	return []*view.View{
 		countMessages,
 		lastValueOffset,
 		lastValueOffsetLag,
 		countPartitionStart,
 		countPartitionClose,
+		countDeadLetterMessages,
 	}
 }
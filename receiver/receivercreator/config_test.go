@@ -107,14 +107,39 @@ func TestLoadConfig(t *testing.T) {
 					component.NewIDWithName("mock_observer", "with_name"),
 				},
 				ResourceAttributes: map[observer.EndpointType]map[string]string{
-					observer.ContainerType: {"container.key": "container.value"},
-					observer.PodType:       {"pod.key": "pod.value"},
-					observer.PortType:      {"port.key": "port.value"},
-					observer.HostPortType:  {"hostport.key": "hostport.value"},
-					observer.K8sNodeType:   {"k8s.node.key": "k8s.node.value"},
+					observer.ContainerType:  {"container.key": "container.value"},
+					observer.PodType:        {"pod.key": "pod.value"},
+					observer.PortType:       {"port.key": "port.value"},
+					observer.HostPortType:   {"hostport.key": "hostport.value"},
+					observer.K8sNodeType:    {"k8s.node.key": "k8s.node.value"},
+					observer.K8sServiceType: {"k8s.service.key": "k8s.service.value"},
+					observer.K8sIngressType: {"k8s.ingress.key": "k8s.ingress.value"},
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "discovery"),
+			expected: func() component.Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.WatchObservers = []component.ID{component.NewID("mock_observer")}
+				cfg.Discovery = DiscoveryConfig{
+					Enabled: true,
+					Receivers: map[component.Type]discoveryTemplate{
+						"redis": {
+							Config: userConfigMap{
+								endpointConfigKey:     "`endpoint`:6379",
+								"collection_interval": "10s",
+							},
+							ResourceAttributes: map[string]interface{}{"discovered": "true"},
+							OverridableKeys:    []string{"collection_interval"},
+						},
+						"postgresql": {},
+					},
+					Namespaces: []string{"default"},
+				}
+				return cfg
+			}(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -282,3 +307,14 @@ func (*nopWithoutEndpointFactory) CreateTracesReceiver(
 		cfg:            cfg,
 	}, nil
 }
+
+func TestInvalidDiscovery(t *testing.T) {
+	factories, err := otelcoltest.NopFactories()
+	require.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[metadata.Type] = factory
+	cfg, err := otelcoltest.LoadConfigAndValidate(filepath.Join("testdata", "invalid-discovery.yaml"), factories)
+	require.Contains(t, err.Error(), "error reading configuration for \"receiver_creator\": discovery is enabled but no receivers are allowed")
+	require.Nil(t, cfg)
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receivercreator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/receivercreator"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+const (
+	// discoveryAnnotationPrefix is the prefix of the pod annotations driving the discovery.
+	discoveryAnnotationPrefix = "io.opentelemetry.discovery.metrics/"
+	// scraperAnnotation is the pod annotation selecting the receiver type to create.
+	scraperAnnotation = discoveryAnnotationPrefix + "scraper"
+	// configAnnotation is the pod annotation holding the receiver config as YAML.
+	configAnnotation = discoveryAnnotationPrefix + "config"
+	// discoveredReceiverName is the name of the receivers created from pod annotations.
+	discoveredReceiverName = "discovery"
+)
+
+// DiscoveryConfig configures the creation of receivers from pod annotations.
+type DiscoveryConfig struct {
+	// Enabled turns on the creation of receivers from the discovery annotations of pod endpoints.
+	Enabled bool `mapstructure:"enabled"`
+	// Receivers is the allowlist of receiver types the scraper annotation can select. Each of them
+	// maps to the template the config annotation is merged over.
+	Receivers map[component.Type]discoveryTemplate `mapstructure:"receivers"`
+	// Namespaces restricts the discovery to the pods of these namespaces. Pods of all namespaces
+	// are discovered when empty.
+	Namespaces []string `mapstructure:"namespaces"`
+}
+
+// discoveryTemplate is the base configuration of an allowlisted receiver type.
+type discoveryTemplate struct {
+	// Config is the receiver config the config annotation is merged over.
+	Config userConfigMap `mapstructure:"config"`
+	// ResourceAttributes is a map of resource attributes to add to the discovered receiver's resource metrics.
+	ResourceAttributes map[string]interface{} `mapstructure:"resource_attributes"`
+	// OverridableKeys is the list of top level keys of the receiver config the config annotation can set.
+	// The config annotation can't set any key when empty.
+	OverridableKeys []string `mapstructure:"overridable_keys"`
+}
+
+// templateFromAnnotations builds a receiver template from the discovery annotations of the endpoint.
+// The ok flag is false when discovery is disabled, the endpoint isn't a pod, the pod isn't in one of
+// the discovered namespaces or the pod doesn't have the scraper annotation.
+func (cfg DiscoveryConfig) templateFromAnnotations(e observer.Endpoint) (template receiverTemplate, ok bool, err error) {
+	if !cfg.Enabled {
+		return template, false, nil
+	}
+	pod, isPod := e.Details.(*observer.Pod)
+	if !isPod {
+		return template, false, nil
+	}
+	if len(cfg.Namespaces) > 0 && !contains(cfg.Namespaces, pod.Namespace) {
+		return template, false, nil
+	}
+	scraper, found := pod.Annotations[scraperAnnotation]
+	if !found {
+		return template, false, nil
+	}
+
+	receiverType := component.Type(scraper)
+	allowed, found := cfg.Receivers[receiverType]
+	if !found {
+		return template, false, fmt.Errorf("receiver %q of annotation %q is not allowed for discovery", scraper, scraperAnnotation)
+	}
+
+	conf := confmap.NewFromStringMap(allowed.Config)
+	if rawCfg, found := pod.Annotations[configAnnotation]; found {
+		annotationCfg := map[string]interface{}{}
+		if err = yaml.Unmarshal([]byte(rawCfg), &annotationCfg); err != nil {
+			return template, false, fmt.Errorf("unable to parse annotation %q: %w", configAnnotation, err)
+		}
+		for key := range annotationCfg {
+			if !contains(allowed.OverridableKeys, key) {
+				return template, false, fmt.Errorf("key %q of annotation %q is not overridable for receiver %q", key, configAnnotation, scraper)
+			}
+		}
+		if err = conf.Merge(confmap.NewFromStringMap(annotationCfg)); err != nil {
+			return template, false, fmt.Errorf("unable to merge annotation %q: %w", configAnnotation, err)
+		}
+	}
+
+	return receiverTemplate{
+		receiverConfig: receiverConfig{
+			id:         component.NewIDWithName(receiverType, discoveredReceiverName),
+			config:     conf.ToStringMap(),
+			endpointID: e.ID,
+		},
+		ResourceAttributes: allowed.ResourceAttributes,
+	}, true, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
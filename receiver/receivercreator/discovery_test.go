@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receivercreator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func annotatedPodEndpoint(annotations map[string]string) observer.Endpoint {
+	return observer.Endpoint{
+		ID:     "pod-1",
+		Target: "1.2.3.4",
+		Details: &observer.Pod{
+			UID:         "uid-1",
+			Namespace:   "default",
+			Name:        "pod-1",
+			Annotations: annotations,
+		},
+	}
+}
+
+func TestTemplateFromAnnotations(t *testing.T) {
+	discovery := DiscoveryConfig{
+		Enabled: true,
+		Receivers: map[component.Type]discoveryTemplate{
+			"redis": {
+				Config: userConfigMap{
+					"endpoint":            "`endpoint`:6379",
+					"collection_interval": "10s",
+					"tls": map[string]interface{}{
+						"insecure": true,
+					},
+				},
+				ResourceAttributes: map[string]interface{}{"discovered": "true"},
+				OverridableKeys:    []string{"collection_interval", "password", "tls"},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name          string
+		discovery     DiscoveryConfig
+		endpoint      observer.Endpoint
+		expected      receiverTemplate
+		expectedOK    bool
+		expectedError string
+	}{
+		{
+			name:      "disabled",
+			discovery: DiscoveryConfig{Receivers: discovery.Receivers},
+			endpoint:  annotatedPodEndpoint(map[string]string{scraperAnnotation: "redis"}),
+		},
+		{
+			name:      "not a pod",
+			discovery: discovery,
+			endpoint:  portEndpoint,
+		},
+		{
+			name: "namespace not discovered",
+			discovery: DiscoveryConfig{
+				Enabled:    true,
+				Receivers:  discovery.Receivers,
+				Namespaces: []string{"monitoring"},
+			},
+			endpoint: annotatedPodEndpoint(map[string]string{scraperAnnotation: "redis"}),
+		},
+		{
+			name:      "no scraper annotation",
+			discovery: discovery,
+			endpoint:  annotatedPodEndpoint(map[string]string{configAnnotation: "collection_interval: 20s"}),
+		},
+		{
+			name:          "receiver not allowed",
+			discovery:     discovery,
+			endpoint:      annotatedPodEndpoint(map[string]string{scraperAnnotation: "postgresql"}),
+			expectedError: `receiver "postgresql" of annotation "io.opentelemetry.discovery.metrics/scraper" is not allowed for discovery`,
+		},
+		{
+			name:      "invalid config annotation",
+			discovery: discovery,
+			endpoint: annotatedPodEndpoint(map[string]string{
+				scraperAnnotation: "redis",
+				configAnnotation:  "collection_interval: [",
+			}),
+			expectedError: `unable to parse annotation "io.opentelemetry.discovery.metrics/config"`,
+		},
+		{
+			name:      "key not overridable",
+			discovery: discovery,
+			endpoint: annotatedPodEndpoint(map[string]string{
+				scraperAnnotation: "redis",
+				configAnnotation:  "endpoint: attacker.example.com:6379",
+			}),
+			expectedError: `key "endpoint" of annotation "io.opentelemetry.discovery.metrics/config" is not overridable for receiver "redis"`,
+		},
+		{
+			name: "no overridable keys",
+			discovery: DiscoveryConfig{
+				Enabled: true,
+				Receivers: map[component.Type]discoveryTemplate{
+					"redis": {Config: userConfigMap{"endpoint": "`endpoint`:6379"}},
+				},
+			},
+			endpoint: annotatedPodEndpoint(map[string]string{
+				scraperAnnotation: "redis",
+				configAnnotation:  "collection_interval: 20s",
+			}),
+			expectedError: `key "collection_interval" of annotation "io.opentelemetry.discovery.metrics/config" is not overridable for receiver "redis"`,
+		},
+		{
+			name: "namespace discovered",
+			discovery: DiscoveryConfig{
+				Enabled:    true,
+				Receivers:  discovery.Receivers,
+				Namespaces: []string{"monitoring", "default"},
+			},
+			endpoint: annotatedPodEndpoint(map[string]string{scraperAnnotation: "redis"}),
+			expected: receiverTemplate{
+				receiverConfig: receiverConfig{
+					id: component.NewIDWithName("redis", discoveredReceiverName),
+					config: userConfigMap{
+						"endpoint":            "`endpoint`:6379",
+						"collection_interval": "10s",
+						"tls": map[string]interface{}{
+							"insecure": true,
+						},
+					},
+					endpointID: "pod-1",
+				},
+				ResourceAttributes: map[string]interface{}{"discovered": "true"},
+			},
+			expectedOK: true,
+		},
+		{
+			name:      "template only",
+			discovery: discovery,
+			endpoint:  annotatedPodEndpoint(map[string]string{scraperAnnotation: "redis"}),
+			expected: receiverTemplate{
+				receiverConfig: receiverConfig{
+					id: component.NewIDWithName("redis", discoveredReceiverName),
+					config: userConfigMap{
+						"endpoint":            "`endpoint`:6379",
+						"collection_interval": "10s",
+						"tls": map[string]interface{}{
+							"insecure": true,
+						},
+					},
+					endpointID: "pod-1",
+				},
+				ResourceAttributes: map[string]interface{}{"discovered": "true"},
+			},
+			expectedOK: true,
+		},
+		{
+			name:      "config annotation merged over template",
+			discovery: discovery,
+			endpoint: annotatedPodEndpoint(map[string]string{
+				scraperAnnotation: "redis",
+				configAnnotation:  "collection_interval: 20s\npassword: secret\ntls:\n  ca_file: /etc/ca.pem\n",
+			}),
+			expected: receiverTemplate{
+				receiverConfig: receiverConfig{
+					id: component.NewIDWithName("redis", discoveredReceiverName),
+					config: userConfigMap{
+						"endpoint":            "`endpoint`:6379",
+						"collection_interval": "20s",
+						"password":            "secret",
+						"tls": map[string]interface{}{
+							"insecure": true,
+							"ca_file":  "/etc/ca.pem",
+						},
+					},
+					endpointID: "pod-1",
+				},
+				ResourceAttributes: map[string]interface{}{"discovered": "true"},
+			},
+			expectedOK: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			template, ok, err := tt.discovery.templateFromAnnotations(tt.endpoint)
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+				assert.False(t, ok)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOK, ok)
+			if tt.expectedOK {
+				assert.Equal(t, tt.expected, template)
+			}
+		})
+	}
+}
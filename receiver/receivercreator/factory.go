@@ -52,6 +52,12 @@ func createDefaultConfig() component.Config {
 				conventions.AttributeK8SNodeName: "`name`",
 				conventions.AttributeK8SNodeUID:  "`uid`",
 			},
+			observer.K8sServiceType: map[string]string{
+				conventions.AttributeK8SNamespaceName: "`namespace`",
+			},
+			observer.K8sIngressType: map[string]string{
+				conventions.AttributeK8SNamespaceName: "`namespace`",
+			},
 		},
 		receiverTemplates: map[string]receiverTemplate{},
 	}
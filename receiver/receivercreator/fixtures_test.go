@@ -69,6 +69,37 @@ var containerEndpoint = observer.Endpoint{
 	Details: &container,
 }
 
+var k8sServiceEndpoint = observer.Endpoint{
+	ID:     "k8s.service-1",
+	Target: "10.0.0.1:80",
+	Details: &observer.K8sService{
+		Name:        "service-1",
+		UID:         "service-uid-1",
+		Namespace:   "default",
+		ServiceType: "ClusterIP",
+		ClusterIP:   "10.0.0.1",
+		PortName:    "http",
+		Port:        80,
+		Transport:   observer.ProtocolTCP,
+		Labels: map[string]string{
+			"app": "web",
+		},
+	},
+}
+
+var k8sIngressEndpoint = observer.Endpoint{
+	ID:     "k8s.ingress-1",
+	Target: "https://host.example.com/api",
+	Details: &observer.K8sIngress{
+		Name:      "ingress-1",
+		UID:       "ingress-uid-1",
+		Namespace: "default",
+		Scheme:    "https",
+		Host:      "host.example.com",
+		Path:      "/api",
+	},
+}
+
 var k8sNodeEndpoint = observer.Endpoint{
 	ID:     "k8s.node-1",
 	Target: "2.3.4.5",
@@ -325,6 +325,43 @@ func TestOnAddForTraces(t *testing.T) {
 	}
 }
 
+func TestOnAddWithDiscovery(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Discovery = DiscoveryConfig{
+		Enabled: true,
+		Receivers: map[component.Type]discoveryTemplate{
+			"with.endpoint": {
+				Config:          userConfigMap{"endpoint": "`endpoint`:6379"},
+				OverridableKeys: []string{"int_field"},
+			},
+		},
+	}
+
+	handler, mr := newObserverHandler(t, cfg, nil, consumertest.NewNop(), nil)
+	handler.OnAdd([]observer.Endpoint{
+		annotatedPodEndpoint(map[string]string{
+			scraperAnnotation: "with.endpoint",
+			configAnnotation:  "int_field: 42",
+		}),
+		// Not allowed receivers aren't started.
+		annotatedPodEndpoint(map[string]string{scraperAnnotation: "without.endpoint"}),
+		podEndpoint,
+	})
+
+	assert.Equal(t, 1, handler.receiversByEndpointID.Size())
+	require.NoError(t, mr.lastError)
+	require.NotNil(t, mr.startedComponent)
+
+	wr, ok := mr.startedComponent.(*wrappedReceiver)
+	require.True(t, ok)
+	v, ok := wr.metrics.(*nopWithEndpointReceiver)
+	require.True(t, ok)
+	require.Equal(t, &nopWithEndpointConfig{
+		IntField: 42,
+		Endpoint: "1.2.3.4:6379",
+	}, v.cfg)
+}
+
 func TestOnRemoveForMetrics(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	rcvrCfg := receiverConfig{
@@ -20,6 +20,8 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.NewID("file_storage")
+
 	tests := []struct {
 		fname        string
 		id           component.ID
@@ -54,6 +56,31 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id:    component.NewIDWithName(metadata.Type, ""),
+			fname: "config-logs.yaml",
+			expected: &Config{
+				ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+					CollectionInterval: 10 * time.Second,
+				},
+				Driver:     "mydriver",
+				DataSource: "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
+				StorageID:  &storageID,
+				Queries: []Query{
+					{
+						SQL:                "select id, body, level from audit where id > $1 order by id",
+						TrackingColumn:     "id",
+						TrackingStartValue: "100",
+						Logs: []LogsCfg{
+							{
+								BodyColumn:       "body",
+								AttributeColumns: []string{"level"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			fname:        "config-invalid-datatype.yaml",
 			id:           component.NewIDWithName(metadata.Type, ""),
@@ -97,13 +124,33 @@ func TestLoadConfig(t *testing.T) {
 		{
 			fname:        "config-invalid-missing-metrics.yaml",
 			id:           component.NewIDWithName(metadata.Type, ""),
-			errorMessage: "'query.metrics' cannot be empty",
+			errorMessage: "'query.metrics' and 'query.logs' cannot both be empty",
 		},
 		{
 			fname:        "config-invalid-missing-datasource.yaml",
 			id:           component.NewIDWithName(metadata.Type, ""),
 			errorMessage: "'datasource' cannot be empty",
 		},
+		{
+			fname:        "config-invalid-missing-bodycolumn.yaml",
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "'body_column' cannot be empty",
+		},
+		{
+			fname:        "config-invalid-tracking-metrics.yaml",
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "'query.tracking_column' is only supported by queries without metrics",
+		},
+		{
+			fname:        "config-invalid-tracking-start-value.yaml",
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "'query.tracking_start_value' requires 'query.tracking_column' to be set",
+		},
+		{
+			fname:        "config-invalid-tracking-duplicate-sql.yaml",
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "'query.sql' must be unique among queries with a 'query.tracking_column'",
+		},
 		{
 			fname:        "config-unnecessary-aggregation.yaml",
 			id:           component.NewIDWithName(metadata.Type, ""),
@@ -22,3 +22,14 @@ func TestNewFactory(t *testing.T) {
 	)
 	require.NoError(t, err)
 }
+
+func TestNewFactory_Logs(t *testing.T) {
+	factory := NewFactory()
+	_, err := factory.CreateLogsReceiver(
+		context.Background(),
+		receivertest.NewNopCreateSettings(),
+		factory.CreateDefaultConfig(),
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+}
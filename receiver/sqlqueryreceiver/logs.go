@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func rowToLog(row stringMap, cfg LogsCfg, dest plog.LogRecord, ts pcommon.Timestamp) error {
+	dest.SetObservedTimestamp(ts)
+	body, found := row[cfg.BodyColumn]
+	if !found {
+		return fmt.Errorf("rowToLog: body_column '%s' not found in result set", cfg.BodyColumn)
+	}
+	dest.Body().SetStr(body)
+	attrs := dest.Attributes()
+	for _, columnName := range cfg.AttributeColumns {
+		if attrVal, found := row[columnName]; found {
+			attrs.PutStr(columnName, attrVal)
+		} else {
+			return fmt.Errorf("rowToLog: attribute_column not found: '%s'", columnName)
+		}
+	}
+	return nil
+}
+
+// rowToLogs appends a log record for each logs config of the query, either all
+// of them are appended or none if the row can not be converted.
+func rowToLogs(row stringMap, logsCfgs []LogsCfg, lrs plog.LogRecordSlice, ts pcommon.Timestamp) error {
+	converted := plog.NewLogRecordSlice()
+	for _, logsCfg := range logsCfgs {
+		if err := rowToLog(row, logsCfg, converted.AppendEmpty(), ts); err != nil {
+			return err
+		}
+	}
+	converted.MoveAndAppendTo(lrs)
+	return nil
+}
@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const transport = "sql"
+
+// logsReceiver runs the queries configured with logs at every collection interval
+// and emits each returned row as a log record.
+type logsReceiver struct {
+	cfg                *Config
+	logger             *zap.Logger
+	consumer           consumer.Logs
+	obsrecv            *obsreport.Receiver
+	id                 component.ID
+	dbProviderFunc     dbProviderFunc
+	clientProviderFunc clientProviderFunc
+	queries            []*logsQuery
+	db                 *sql.DB
+	storageClient      storage.Client
+	cancel             context.CancelFunc
+	wg                 sync.WaitGroup
+}
+
+// logsQuery holds the state of a single query, trackingValue is the value of
+// the tracking column of the last row that was successfully consumed.
+type logsQuery struct {
+	id            string
+	query         Query
+	client        dbClient
+	trackingValue string
+}
+
+var _ receiver.Logs = (*logsReceiver)(nil)
+
+func newLogsReceiver(
+	cfg *Config,
+	settings receiver.CreateSettings,
+	sqlOpenerFunc sqlOpenerFunc,
+	clientProviderFunc clientProviderFunc,
+	consumer consumer.Logs,
+) (*logsReceiver, error) {
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             settings.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r := &logsReceiver{
+		cfg:      cfg,
+		logger:   settings.Logger,
+		consumer: consumer,
+		obsrecv:  obsrecv,
+		id:       settings.ID,
+		dbProviderFunc: func() (*sql.DB, error) {
+			return sqlOpenerFunc(cfg.Driver, cfg.DataSource)
+		},
+		clientProviderFunc: clientProviderFunc,
+		storageClient:      storage.NewNopClient(),
+	}
+	for _, query := range cfg.Queries {
+		if len(query.Logs) == 0 {
+			continue
+		}
+		r.queries = append(r.queries, &logsQuery{
+			id:            trackingValueKey(query),
+			query:         query,
+			trackingValue: query.TrackingStartValue,
+		})
+	}
+	return r, nil
+}
+
+func (r *logsReceiver) Start(ctx context.Context, host component.Host) error {
+	var err error
+	r.db, err = r.dbProviderFunc()
+	if err != nil {
+		return fmt.Errorf("failed to open db connection: %w", err)
+	}
+	r.storageClient, err = getStorageClient(ctx, host, r.cfg.StorageID, r.id)
+	if err != nil {
+		return fmt.Errorf("failed to get storage client: %w", err)
+	}
+	for _, q := range r.queries {
+		q.client = r.clientProviderFunc(dbWrapper{r.db}, q.query.SQL, r.logger)
+		r.loadTrackingValue(ctx, q)
+	}
+
+	pollCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go r.poll(pollCtx)
+	return nil
+}
+
+func (r *logsReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	var errs error
+	if r.storageClient != nil {
+		errs = multierr.Append(errs, r.storageClient.Close(ctx))
+	}
+	if r.db != nil {
+		errs = multierr.Append(errs, r.db.Close())
+	}
+	return errs
+}
+
+func (r *logsReceiver) poll(ctx context.Context) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.cfg.CollectionInterval)
+	defer ticker.Stop()
+	r.collect(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			r.collect(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *logsReceiver) collect(ctx context.Context) {
+	for _, q := range r.queries {
+		if err := r.collectQuery(ctx, q); err != nil {
+			r.logger.Error("failed to collect logs", zap.String("query", q.id), zap.Error(err))
+		}
+	}
+}
+
+func (r *logsReceiver) collectQuery(ctx context.Context, q *logsQuery) error {
+	var args []any
+	if q.query.TrackingColumn != "" {
+		args = append(args, q.trackingValue)
+	}
+	rows, err := q.client.queryRows(ctx, args...)
+	if err != nil {
+		if !errors.Is(err, errNullValueWarning) {
+			return err
+		}
+		r.logger.Warn("problems encountered getting log rows", zap.Error(err))
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	ts := pcommon.NewTimestampFromTime(time.Now())
+	// Rows are converted in order and the conversion stops at the first failed row,
+	// so the tracking value never moves past a row that has not been emitted.
+	var convErr error
+	converted := len(rows)
+	for i, row := range rows {
+		if convErr = rowToLogs(row, q.query.Logs, lrs, ts); convErr != nil {
+			convErr = fmt.Errorf("row %d: %w", i, convErr)
+			converted = i
+			break
+		}
+	}
+
+	if lrs.Len() > 0 {
+		obsCtx := r.obsrecv.StartLogsOp(ctx)
+		err = r.consumer.ConsumeLogs(obsCtx, ld)
+		r.obsrecv.EndLogsOp(obsCtx, transport, lrs.Len(), err)
+		if err != nil {
+			// The tracking value is left untouched so
+			// the rows are read again at the next collection.
+			return err
+		}
+	}
+	if converted > 0 {
+		if err = r.updateTrackingValue(ctx, q, rows[:converted]); err != nil {
+			return multierr.Append(convErr, err)
+		}
+	}
+	return convErr
+}
+
+// updateTrackingValue moves the tracking value of the query to the value of the
+// tracking column of the last row, which requires the query to order its result
+// by the tracking column.
+func (r *logsReceiver) updateTrackingValue(ctx context.Context, q *logsQuery, rows []stringMap) error {
+	if q.query.TrackingColumn == "" {
+		return nil
+	}
+	for i := len(rows) - 1; i >= 0; i-- {
+		if value, ok := rows[i][q.query.TrackingColumn]; ok {
+			q.trackingValue = value
+			return r.storageClient.Set(ctx, q.id, []byte(value))
+		}
+	}
+	return fmt.Errorf("tracking_column '%s' not found in result set", q.query.TrackingColumn)
+}
+
+// trackingValueKey returns the key the tracking value of the query is stored under.
+// It only depends on the sql statement so that reordering the queries keeps their
+// tracking values, while changing the statement restarts from the start value.
+func trackingValueKey(query Query) string {
+	return "query: " + query.SQL
+}
+
+func (r *logsReceiver) loadTrackingValue(ctx context.Context, q *logsQuery) {
+	if q.query.TrackingColumn == "" {
+		return
+	}
+	value, err := r.storageClient.Get(ctx, q.id)
+	if err != nil {
+		r.logger.Info("unable to load tracking value from storage client, continuing from the start value", zap.String("query", q.id), zap.Error(err))
+		return
+	}
+	if value != nil {
+		q.trackingValue = string(value)
+	}
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+	ext, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+	return storageExt.GetClient(ctx, component.KindReceiver, componentID, "")
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlqueryreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newTestLogsConfig() *Config {
+	return &Config{
+		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+			CollectionInterval: time.Hour,
+		},
+		Driver:     "mydriver",
+		DataSource: "my-datasource",
+		Queries: []Query{{
+			SQL:                "select id, body from audit where id > $1 order by id",
+			TrackingColumn:     "id",
+			TrackingStartValue: "0",
+			Logs: []LogsCfg{{
+				BodyColumn:       "body",
+				AttributeColumns: []string{"id"},
+			}},
+		}},
+	}
+}
+
+func newTestLogsReceiver(t *testing.T, cfg *Config, client *fakeDBClient, next consumer.Logs) *logsReceiver {
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), fakeDBConnect, func(db, string, *zap.Logger) dbClient {
+		return client
+	}, next)
+	require.NoError(t, err)
+	for _, q := range r.queries {
+		q.client = client
+	}
+	return r
+}
+
+func TestLogsReceiver_Collect(t *testing.T) {
+	client := &fakeDBClient{
+		stringMaps: [][]stringMap{
+			{{"id": "1", "body": "first"}, {"id": "2", "body": "second"}},
+			{{"id": "3", "body": "third"}},
+		},
+	}
+	sink := &consumertest.LogsSink{}
+	r := newTestLogsReceiver(t, newTestLogsConfig(), client, sink)
+	require.Len(t, r.queries, 1)
+	q := r.queries[0]
+
+	require.NoError(t, r.collectQuery(context.Background(), q))
+	require.Equal(t, 2, sink.LogRecordCount())
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1)
+	assert.Equal(t, "second", lr.Body().Str())
+	assert.Equal(t, map[string]any{"id": "2"}, lr.Attributes().AsRaw())
+	assert.Equal(t, "2", q.trackingValue)
+
+	require.NoError(t, r.collectQuery(context.Background(), q))
+	assert.Equal(t, 3, sink.LogRecordCount())
+	assert.Equal(t, "3", q.trackingValue)
+	assert.Equal(t, [][]any{{"0"}, {"2"}}, client.args)
+}
+
+func TestLogsReceiver_CollectWithoutTracking(t *testing.T) {
+	cfg := newTestLogsConfig()
+	cfg.Queries[0].TrackingColumn = ""
+	cfg.Queries[0].TrackingStartValue = ""
+	client := &fakeDBClient{
+		stringMaps: [][]stringMap{{{"id": "1", "body": "first"}}},
+	}
+	sink := &consumertest.LogsSink{}
+	r := newTestLogsReceiver(t, cfg, client, sink)
+
+	require.NoError(t, r.collectQuery(context.Background(), r.queries[0]))
+	assert.Equal(t, 1, sink.LogRecordCount())
+	assert.Equal(t, [][]any{nil}, client.args)
+}
+
+func TestLogsReceiver_ConsumeErrorKeepsTrackingValue(t *testing.T) {
+	client := &fakeDBClient{
+		stringMaps: [][]stringMap{{{"id": "1", "body": "first"}}},
+	}
+	r := newTestLogsReceiver(t, newTestLogsConfig(), client, consumertest.NewErr(errors.New("oops")))
+	q := r.queries[0]
+
+	require.Error(t, r.collectQuery(context.Background(), q))
+	assert.Equal(t, "0", q.trackingValue)
+}
+
+func TestLogsReceiver_ConversionErrorStopsAtFailedRow(t *testing.T) {
+	client := &fakeDBClient{
+		stringMaps: [][]stringMap{
+			{{"id": "1", "body": "first"}, {"id": "2"}, {"id": "3", "body": "third"}},
+			{{"id": "2", "body": "second"}, {"id": "3", "body": "third"}},
+		},
+	}
+	sink := &consumertest.LogsSink{}
+	r := newTestLogsReceiver(t, newTestLogsConfig(), client, sink)
+	q := r.queries[0]
+
+	// Only the rows before the failed row are emitted and tracked.
+	assert.ErrorContains(t, r.collectQuery(context.Background(), q), "row 1: rowToLog: body_column 'body' not found in result set")
+	assert.Equal(t, 1, sink.LogRecordCount())
+	assert.Equal(t, "1", q.trackingValue)
+
+	require.NoError(t, r.collectQuery(context.Background(), q))
+	assert.Equal(t, 3, sink.LogRecordCount())
+	assert.Equal(t, "3", q.trackingValue)
+	assert.Equal(t, [][]any{{"0"}, {"1"}}, client.args)
+}
+
+func TestLogsReceiver_ConversionErrorOnFirstRow(t *testing.T) {
+	client := &fakeDBClient{
+		stringMaps: [][]stringMap{{{"id": "1"}, {"id": "2", "body": "second"}}},
+	}
+	sink := &consumertest.LogsSink{}
+	r := newTestLogsReceiver(t, newTestLogsConfig(), client, sink)
+	q := r.queries[0]
+
+	require.Error(t, r.collectQuery(context.Background(), q))
+	assert.Equal(t, 0, sink.LogRecordCount())
+	assert.Equal(t, "0", q.trackingValue)
+}
+
+func TestLogsReceiver_SkipsMetricsQueries(t *testing.T) {
+	cfg := newTestLogsConfig()
+	cfg.Queries = append(cfg.Queries, Query{
+		SQL:     "select count(*) as count from audit",
+		Metrics: []MetricCfg{{MetricName: "audit.count", ValueColumn: "count"}},
+	})
+	r := newTestLogsReceiver(t, cfg, &fakeDBClient{}, consumertest.NewNop())
+	require.Len(t, r.queries, 1)
+	assert.Equal(t, "query: select id, body from audit where id > $1 order by id", r.queries[0].id)
+}
+
+func TestLogsReceiver_PersistsTrackingValue(t *testing.T) {
+	ctx := context.Background()
+	storageID := storagetest.NewStorageID("test")
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	cfg := newTestLogsConfig()
+	cfg.StorageID = &storageID
+
+	client := &fakeDBClient{
+		stringMaps: [][]stringMap{{{"id": "5", "body": "first"}}},
+	}
+	r := newTestLogsReceiver(t, cfg, client, consumertest.NewNop())
+	require.NoError(t, r.Start(ctx, host))
+	assert.Eventually(t, func() bool {
+		value, err := r.storageClient.Get(ctx, r.queries[0].id)
+		return err == nil && string(value) == "5"
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(ctx))
+
+	// A new receiver resumes from the stored value instead of the start value.
+	client = &fakeDBClient{
+		stringMaps: [][]stringMap{{}},
+	}
+	r = newTestLogsReceiver(t, cfg, client, consumertest.NewNop())
+	require.NoError(t, r.Start(ctx, host))
+	require.NoError(t, r.Shutdown(ctx))
+	assert.Equal(t, [][]any{{"5"}}, client.args)
+}
+
+func TestLogsReceiver_MissingStorageExtension(t *testing.T) {
+	storageID := component.NewID("missing")
+	cfg := newTestLogsConfig()
+	cfg.StorageID = &storageID
+	r := newTestLogsReceiver(t, cfg, &fakeDBClient{}, consumertest.NewNop())
+	assert.ErrorContains(t, r.Start(context.Background(), componenttest.NewNopHost()), "storage extension 'missing' not found")
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlqueryreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestRowToLog(t *testing.T) {
+	lr := plog.NewLogRecord()
+	err := rowToLog(stringMap{"id": "1", "body": "user logged in", "level": "info"}, LogsCfg{
+		BodyColumn:       "body",
+		AttributeColumns: []string{"level"},
+	}, lr, pcommon.Timestamp(42))
+	require.NoError(t, err)
+	assert.Equal(t, "user logged in", lr.Body().Str())
+	assert.Equal(t, pcommon.Timestamp(42), lr.ObservedTimestamp())
+	assert.Equal(t, map[string]any{"level": "info"}, lr.Attributes().AsRaw())
+}
+
+func TestRowToLog_MissingColumns(t *testing.T) {
+	err := rowToLog(stringMap{"id": "1"}, LogsCfg{BodyColumn: "body"}, plog.NewLogRecord(), 0)
+	assert.EqualError(t, err, "rowToLog: body_column 'body' not found in result set")
+
+	err = rowToLog(stringMap{"body": "hello"}, LogsCfg{
+		BodyColumn:       "body",
+		AttributeColumns: []string{"level"},
+	}, plog.NewLogRecord(), 0)
+	assert.EqualError(t, err, "rowToLog: attribute_column not found: 'level'")
+}
@@ -31,6 +31,9 @@ func createReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clientPr
 		sqlCfg := cfg.(*Config)
 		var opts []scraperhelper.ScraperControllerOption
 		for i, query := range sqlCfg.Queries {
+			if len(query.Metrics) == 0 {
+				continue
+			}
 			id := component.NewIDWithName("sqlqueryreceiver", fmt.Sprintf("query-%d: %s", i, query.SQL))
 			mp := &scraper{
 				id:        id,
@@ -53,3 +56,14 @@ func createReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clientPr
 		)
 	}
 }
+
+func createLogsReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clientProviderFunc) receiver.CreateLogsFunc {
+	return func(
+		ctx context.Context,
+		settings receiver.CreateSettings,
+		cfg component.Config,
+		consumer consumer.Logs,
+	) (receiver.Logs, error) {
+		return newLogsReceiver(cfg.(*Config), settings, sqlOpenerFunc, clientProviderFunc, consumer)
+	}
+}